@@ -148,6 +148,14 @@ type A2ATaskSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="5s"
 	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+	// UpdateMode specifies how task status updates are received. With "poll" the A2A server is
+	// asked for the task status every PollInterval. With "stream" the updates arrive on the
+	// message/stream connection of the query executing the task. With "push" the A2A server
+	// calls the controller's push notification endpoint, and is only polled as a fallback.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="poll"
+	// +kubebuilder:validation:Enum=poll;stream;push
+	UpdateMode string `json:"updateMode,omitempty"`
 }
 
 // A2ATaskStatus defines the observed state of an A2ATask.
@@ -82,6 +82,10 @@ type AgentSpec struct {
 	// +kubebuilder:validation:Optional
 	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
 	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxItems=5
+	// FallbackModels are tried in order when the model in modelRef is unavailable or fails with a retryable error
+	FallbackModels []AgentModelRef `json:"fallbackModels,omitempty"`
+	// +kubebuilder:validation:Optional
 	// ExecutionEngine to use for running this agent. If not specified, uses the built-in OpenAI-compatible engine
 	ExecutionEngine *ExecutionEngineRef `json:"executionEngine,omitempty"`
 	Tools           []AgentTool         `json:"tools,omitempty"`
@@ -93,8 +97,72 @@ type AgentSpec struct {
 	OutputSchema *runtime.RawExtension `json:"outputSchema,omitempty"`
 	// +kubebuilder:validation:Optional
 	Overrides []Override `json:"overrides,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of tool calls from a single model response that run concurrently.
+	// Only tools annotated with readOnlyHint or idempotentHint run in parallel; defaults to 1 (sequential)
+	MaxConcurrentToolCalls *int `json:"maxConcurrentToolCalls,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Limits bound the tool loop of a single agent execution
+	Limits *AgentLimits `json:"limits,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ToolApproval makes queries wait for a human decision before the agent runs certain tool calls
+	ToolApproval *ToolApprovalPolicy `json:"toolApproval,omitempty"`
+}
+
+// ToolApprovalPolicy selects the tool calls of an agent that need approval
+type ToolApprovalPolicy struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=destructive;all
+	// +kubebuilder:default=destructive
+	// Mode destructive requires approval for tools annotated with destructiveHint (and not
+	// readOnlyHint); all requires approval for every tool call
+	Mode string `json:"mode,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Tools always require approval, whatever their annotations
+	Tools []string `json:"tools,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1h"
+	// How long to wait for a decision before the call is rejected. The wait also counts against
+	// the query timeout.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
+const (
+	// ToolApprovalModeDestructive requires approval for destructive tools only
+	ToolApprovalModeDestructive = "destructive"
+	// ToolApprovalModeAll requires approval for every tool call
+	ToolApprovalModeAll = "all"
+)
+
+// AgentLimits bounds the work an agent may do in a single execution
+type AgentLimits struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of model responses that request tool calls
+	MaxToolIterations *int `json:"maxToolIterations,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of tool calls across all iterations
+	MaxToolCalls *int `json:"maxToolCalls,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of tokens used by the agent's model calls
+	MaxTokens *int64 `json:"maxTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=finalAnswer;fail
+	// +kubebuilder:default=finalAnswer
+	// What to do when a limit is reached: finalAnswer asks the model for an answer without tools, fail stops the execution with an error
+	OnLimitExceeded string `json:"onLimitExceeded,omitempty"`
+}
+
+const (
+	// AgentLimitActionFinalAnswer asks the model for a final answer without tools once a limit is reached
+	AgentLimitActionFinalAnswer = "finalAnswer"
+	// AgentLimitActionFail fails the execution once a limit is reached
+	AgentLimitActionFail = "fail"
+)
+
 type AgentStatus struct {
 	// Conditions represent the latest available observations of an agent's state
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
@@ -88,7 +88,7 @@ type Override struct {
 	// +kubebuilder:validation:Required
 	Headers []Header `json:"headers"`
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=model;mcpserver
+	// +kubebuilder:validation:Enum=model;mcpserver;tool;a2aserver;executionengine;memory
 	ResourceType string `json:"resourceType"`
 	// +kubebuilder:validation:Optional
 	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
@@ -14,6 +14,10 @@ type ModelConfig struct {
 	Azure *AzureModelConfig `json:"azure,omitempty"`
 	// +kubebuilder:validation:Optional
 	Bedrock *BedrockModelConfig `json:"bedrock,omitempty"`
+	// +kubebuilder:validation:Optional
+	Anthropic *AnthropicModelConfig `json:"anthropic,omitempty"`
+	// +kubebuilder:validation:Optional
+	Gemini *GeminiModelConfig `json:"gemini,omitempty"`
 }
 
 // AzureModelConfig contains Azure OpenAI specific parameters
@@ -42,6 +46,44 @@ type OpenAIModelConfig struct {
 	Properties map[string]ValueSource `json:"properties,omitempty"`
 }
 
+// AnthropicModelConfig contains Anthropic Messages API specific parameters
+type AnthropicModelConfig struct {
+	// +kubebuilder:validation:Required
+	// BaseURL is the API root the messages endpoint is appended to, e.g. https://api.anthropic.com/v1
+	BaseURL ValueSource `json:"baseUrl"`
+	// +kubebuilder:validation:Required
+	APIKey ValueSource `json:"apiKey"`
+	// +kubebuilder:validation:Optional
+	Headers []Header `json:"headers,omitempty"`
+	// +kubebuilder:validation:Optional
+	Properties map[string]ValueSource `json:"properties,omitempty"`
+}
+
+// GeminiModelConfig contains Google Gemini specific parameters. Models are called through the
+// Gemini API with an API key, or through Vertex AI with a service account key.
+type GeminiModelConfig struct {
+	// +kubebuilder:validation:Optional
+	// BaseURL overrides the API root the model path is appended to. Defaults to the Gemini API,
+	// or to the Vertex AI endpoint of the project and location when a service account key is set.
+	BaseURL *ValueSource `json:"baseUrl,omitempty"`
+	// +kubebuilder:validation:Optional
+	// APIKey authenticates with the Gemini API
+	APIKey *ValueSource `json:"apiKey,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ServiceAccountKey is a service account JSON key used to authenticate with Vertex AI
+	ServiceAccountKey *ValueSource `json:"serviceAccountKey,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Project is the Vertex AI project. Defaults to the project of the service account key.
+	Project *ValueSource `json:"project,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Location is the Vertex AI region, e.g. us-central1 or global. Defaults to us-central1.
+	Location *ValueSource `json:"location,omitempty"`
+	// +kubebuilder:validation:Optional
+	Headers []Header `json:"headers,omitempty"`
+	// +kubebuilder:validation:Optional
+	Properties map[string]ValueSource `json:"properties,omitempty"`
+}
+
 // BedrockModelConfig contains AWS Bedrock specific parameters
 type BedrockModelConfig struct {
 	// +kubebuilder:validation:Optional
@@ -77,15 +119,55 @@ type ModelSpec struct {
 	// +kubebuilder:validation:Enum=completions;openai;azure;bedrock
 	// +kubebuilder:default=completions
 	Type string `json:"type,omitempty"`
-	// Provider specifies the AI provider client to use (openai, azure, bedrock).
+	// Provider specifies the AI provider client to use (openai, azure, bedrock, anthropic, gemini).
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=openai;azure;bedrock
+	// +kubebuilder:validation:Enum=openai;azure;bedrock;anthropic;gemini
 	Provider string `json:"provider"`
 	// +kubebuilder:validation:Required
 	Config ModelConfig `json:"config"`
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="1m"
 	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Pricing is used to compute the cost of queries and enforce query cost budgets
+	Pricing *ModelPricing `json:"pricing,omitempty"`
+	// +kubebuilder:validation:Optional
+	// RetryPolicy retries model calls that fail with a retryable status such as 429 or 5xx
+	RetryPolicy *ModelRetryPolicy `json:"retryPolicy,omitempty"`
+}
+
+// ModelRetryPolicy configures retries of failed model calls with exponential backoff and jitter.
+// A Retry-After header returned by the provider takes precedence over the computed backoff.
+type ModelRetryPolicy struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:default=3
+	// MaxAttempts is the total number of attempts, including the first call
+	MaxAttempts *int32 `json:"maxAttempts,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="1s"
+	// InitialBackoff is the delay before the first retry, doubled for each further retry
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="30s"
+	// MaxBackoff caps the delay between attempts
+	MaxBackoff *metav1.Duration `json:"maxBackoff,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Pattern=^[1-5]([0-9][0-9]|xx)$
+	// RetryOn lists the HTTP status codes (e.g. 429) or classes (e.g. 5xx) that are retried.
+	// Defaults to 408, 429 and 5xx.
+	RetryOn []string `json:"retryOn,omitempty"`
+}
+
+// ModelPricing holds token prices as decimal amounts per million tokens
+type ModelPricing struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=^[0-9]+(\.[0-9]+)?$
+	InputPerMillionTokens string `json:"inputPerMillionTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=^[0-9]+(\.[0-9]+)?$
+	OutputPerMillionTokens string `json:"outputPerMillionTokens,omitempty"`
 }
 
 type ModelStatus struct {
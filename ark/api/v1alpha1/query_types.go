@@ -82,8 +82,146 @@ type QuerySpec struct {
 	Cancel bool `json:"cancel,omitempty"`
 	// +kubebuilder:validation:Optional
 	Overrides []Override `json:"overrides,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ModelOverrides run the selected agents with another model or other model properties for
+	// this query only. When several entries select the same agent, later entries take precedence.
+	ModelOverrides []ModelOverride `json:"modelOverrides,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=resume;fail
+	// +kubebuilder:default=resume
+	// RecoveryPolicy controls what happens when the controller executing the query goes away
+	// (restart or leader failover): resume from the last checkpoint, or fail the query
+	RecoveryPolicy string `json:"recoveryPolicy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Budget caps the tokens and cost the query may use; execution stops as soon as it is crossed
+	Budget *QueryBudget `json:"budget,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ToolApprovals answer the tool calls listed in status.pendingToolCalls. A decision cannot be
+	// changed once recorded.
+	ToolApprovals []ToolApproval `json:"toolApprovals,omitempty"`
+	// +kubebuilder:validation:Optional
+	// InputResponses answer the questions listed in status.pendingInputs. An answer cannot be
+	// changed once recorded.
+	InputResponses []InputResponse `json:"inputResponses,omitempty"`
+}
+
+// InputResponse is the end user's answer to a pending input request
+type InputResponse struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// ID of the request, as listed in status.pendingInputs
+	RequestID string `json:"requestId"`
+	// +kubebuilder:validation:Required
+	Answer string `json:"answer"`
+}
+
+// PendingInput is a question waiting for an answer in spec.inputResponses
+type PendingInput struct {
+	ID    string `json:"id"`
+	Agent string `json:"agent,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A2A task that asked for input, when the question comes from an A2A agent
+	TaskID   string `json:"taskId,omitempty"`
+	Question string `json:"question"`
+	// +kubebuilder:validation:Optional
+	RequestedAt *metav1.Time `json:"requestedAt,omitempty"`
+}
+
+// ToolApproval is an approver's decision on a pending tool call
+type ToolApproval struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// ID of the approval request, as listed in status.pendingToolCalls
+	RequestID string `json:"requestId"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=approved;rejected
+	Decision string `json:"decision"`
+	// +kubebuilder:validation:Optional
+	// Reason is passed to the agent when the call is rejected
+	Reason string `json:"reason,omitempty"`
+}
+
+// Query phases, set by the query controller in status.phase
+const (
+	QueryPhasePending = "pending"
+	QueryPhaseRunning = "running"
+	// QueryPhaseAwaitingApproval is set while a tool call of the query waits for a decision in spec.toolApprovals
+	QueryPhaseAwaitingApproval = "awaiting-approval"
+	// QueryPhaseInputRequired is set while a question of the query waits for an answer in spec.inputResponses
+	QueryPhaseInputRequired  = "input-required"
+	QueryPhaseDone           = "done"
+	QueryPhaseError          = "error"
+	QueryPhaseCanceled       = "canceled"
+	QueryPhaseBudgetExceeded = "budget-exceeded"
+)
+
+const (
+	// ToolApprovalApproved lets the tool call run
+	ToolApprovalApproved = "approved"
+	// ToolApprovalRejected returns a rejection to the agent instead of running the tool call
+	ToolApprovalRejected = "rejected"
+)
+
+// PendingToolCall is a tool call waiting for a decision in spec.toolApprovals
+type PendingToolCall struct {
+	// ID of the approval request, generated by the controller
+	ID string `json:"id"`
+	// +kubebuilder:validation:Optional
+	// ToolCallID is the ID the model gave the tool call
+	ToolCallID string `json:"toolCallId,omitempty"`
+	Agent      string `json:"agent,omitempty"`
+	Tool       string `json:"tool"`
+	Arguments  string `json:"arguments,omitempty"`
+	// +kubebuilder:validation:Optional
+	RequestedAt *metav1.Time `json:"requestedAt,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ExpiresAt is when the call is rejected if no decision has been made
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
+// QueryBudget limits the resources a single query may consume. Token limits apply to the
+// usage summed over all model calls of the query; the cost limit uses the pricing of each Model.
+type QueryBudget struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPromptTokens *int64 `json:"maxPromptTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxCompletionTokens *int64 `json:"maxCompletionTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	MaxTotalTokens *int64 `json:"maxTotalTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=^[0-9]+(\.[0-9]+)?$
+	// Maximum cost as a decimal amount, in the currency used for Model pricing (e.g. "0.50")
+	MaxCost string `json:"maxCost,omitempty"`
+}
+
+// ModelOverride substitutes the model of the selected agents and overrides its properties.
+// An entry with neither agent nor labelSelector applies to every agent of the query.
+type ModelOverride struct {
+	// +kubebuilder:validation:Optional
+	// Agent selects a single agent by name
+	Agent string `json:"agent,omitempty"`
+	// +kubebuilder:validation:Optional
+	// LabelSelector selects agents by their labels
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ModelRef replaces the model of the selected agents; their fallback models are kept
+	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Properties are merged over the properties of the models the selected agents use, using the
+	// same keys as the model configuration (e.g. temperature, max_tokens, reasoning_effort, seed)
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+const (
+	// QueryRecoveryPolicyResume resumes interrupted queries from their last checkpoint
+	QueryRecoveryPolicyResume = "resume"
+	// QueryRecoveryPolicyFail fails interrupted queries
+	QueryRecoveryPolicyFail = "fail"
+)
+
 // A2AMetadata contains optional A2A protocol metadata
 type A2AMetadata struct {
 	// +kubebuilder:validation:Optional
@@ -101,6 +239,14 @@ type Response struct {
 	Raw     string      `json:"raw,omitempty"`
 	Phase   string      `json:"phase,omitempty"`
 	// +kubebuilder:validation:Optional
+	// Model is the Model resource that produced the response. It differs from the agent's
+	// modelRef when a fallback model answered.
+	Model string `json:"model,omitempty"`
+	// +kubebuilder:validation:Optional
+	// TerminationReason explains why a team stopped before its strategy ended, such as a
+	// termination condition, the terminate tool or maxTurns
+	TerminationReason string `json:"terminationReason,omitempty"`
+	// +kubebuilder:validation:Optional
 	// A2A contains optional A2A protocol metadata (contextId, taskId)
 	A2A *A2AMetadata `json:"a2a,omitempty"`
 }
@@ -126,9 +272,38 @@ type TokenUsage struct {
 	TotalTokens      int64 `json:"totalTokens,omitempty"`
 }
 
+// QueryCheckpoint records the progress of a running query so that a new controller
+// instance can resume or deterministically fail it after a restart.
+type QueryCheckpoint struct {
+	// +kubebuilder:validation:Optional
+	// ExecutorID identifies the controller instance executing the query
+	ExecutorID string `json:"executorId,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Attempt is the number of times execution of the query has been started
+	Attempt int32 `json:"attempt,omitempty"`
+	// +kubebuilder:validation:Optional
+	// CompletedTurns is the number of completed agent or team member turns
+	CompletedTurns int32 `json:"completedTurns,omitempty"`
+	// +kubebuilder:validation:Optional
+	// CompletedToolCalls is the number of tool results recorded in the checkpoint
+	CompletedToolCalls int32 `json:"completedToolCalls,omitempty"`
+	// +kubebuilder:validation:Optional
+	// CompletedTokens is the number of tokens the completed turns of an agent used
+	CompletedTokens int64 `json:"completedTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Messages produced by completed turns, serialized as JSON
+	Messages string `json:"messages,omitempty"`
+	// +kubebuilder:validation:Optional
+	// NextMember is the team member that runs the next turn, for strategies that choose it
+	// from the output of the previous turn
+	NextMember string `json:"nextMember,omitempty"`
+	// +kubebuilder:validation:Optional
+	LastCheckpointTime *metav1.Time `json:"lastCheckpointTime,omitempty"`
+}
+
 type QueryStatus struct {
 	// +kubebuilder:default="pending"
-	// +kubebuilder:validation:Enum=pending;running;error;done;canceled
+	// +kubebuilder:validation:Enum=pending;running;awaiting-approval;input-required;error;done;canceled;budget-exceeded
 	Phase string `json:"phase,omitempty"`
 	// +kubebuilder:validation:Optional
 	// Conditions represent the latest available observations of a query's state
@@ -136,10 +311,22 @@ type QueryStatus struct {
 	Response   *Response          `json:"response,omitempty"`
 	TokenUsage TokenUsage         `json:"tokenUsage,omitempty"`
 	// +kubebuilder:validation:Optional
+	// Cost of the tokens used so far, as a decimal amount computed from Model pricing
+	Cost string `json:"cost,omitempty"`
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinLength=1
 	ConversationId string `json:"conversationId,omitempty"`
 	// +kubebuilder:validation:Optional
 	Duration *metav1.Duration `json:"duration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Checkpoint records execution progress while the query is running
+	Checkpoint *QueryCheckpoint `json:"checkpoint,omitempty"`
+	// +kubebuilder:validation:Optional
+	// PendingToolCalls are the tool calls waiting for approval while the phase is awaiting-approval
+	PendingToolCalls []PendingToolCall `json:"pendingToolCalls,omitempty"`
+	// +kubebuilder:validation:Optional
+	// PendingInputs are the questions to the end user while the phase is input-required
+	PendingInputs []PendingInput `json:"pendingInputs,omitempty"`
 }
 
 // +kubebuilder:object:root=true
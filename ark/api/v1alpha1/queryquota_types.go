@@ -0,0 +1,96 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// QueryQuotaActionQueue keeps new queries pending until the quota has room again
+	QueryQuotaActionQueue = "queue"
+	// QueryQuotaActionReject fails new queries while the quota is exhausted
+	QueryQuotaActionReject = "reject"
+
+	// QueryQuotaExhausted is the condition type set while the quota admits no new queries
+	QueryQuotaExhausted = "Exhausted"
+)
+
+// QueryQuotaSpec defines the limits applied to the queries of a namespace.
+type QueryQuotaSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of queries running at the same time in the namespace
+	MaxRunningQueries *int32 `json:"maxRunningQueries,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// Maximum number of tokens used by queries of the namespace within the window
+	MaxTotalTokens *int64 `json:"maxTotalTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="24h"
+	// Window is the rolling period over which token usage is counted
+	Window *metav1.Duration `json:"window,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=queue;reject
+	// +kubebuilder:default=queue
+	// Action taken for new queries while the quota is exhausted
+	Action string `json:"action,omitempty"`
+}
+
+// QueryQuotaStatus defines the observed usage of a QueryQuota.
+type QueryQuotaStatus struct {
+	// +kubebuilder:validation:Optional
+	// Number of queries currently running in the namespace
+	RunningQueries int32 `json:"runningQueries,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Number of queries waiting for the quota to admit them
+	QueuedQueries int32 `json:"queuedQueries,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Tokens used by queries of the namespace within the window
+	UsedTokens int64 `json:"usedTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ConsumedTokens are the tokens charged by finished queries, in buckets of a 24th of the
+	// window. They count towards the quota after the queries themselves are deleted.
+	ConsumedTokens []QueryQuotaTokenBucket `json:"consumedTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Conditions represent the latest available observations of the quota's state
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// QueryQuotaTokenBucket holds the tokens charged by queries that finished within one bucket
+type QueryQuotaTokenBucket struct {
+	// Start of the bucket
+	Start  metav1.Time `json:"start"`
+	Tokens int64       `json:"tokens"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Running",type="integer",JSONPath=".status.runningQueries"
+// +kubebuilder:printcolumn:name="Max Running",type="integer",JSONPath=".spec.maxRunningQueries"
+// +kubebuilder:printcolumn:name="Tokens",type="integer",JSONPath=".status.usedTokens"
+// +kubebuilder:printcolumn:name="Max Tokens",type="integer",JSONPath=".spec.maxTotalTokens"
+// +kubebuilder:printcolumn:name="Exhausted",type="string",JSONPath=`.status.conditions[?(@.type=="Exhausted")].status`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// QueryQuota limits the running queries and token usage of a namespace.
+type QueryQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   QueryQuotaSpec   `json:"spec,omitempty"`
+	Status QueryQuotaStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// QueryQuotaList contains a list of QueryQuota.
+type QueryQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []QueryQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&QueryQuota{}, &QueryQuotaList{})
+}
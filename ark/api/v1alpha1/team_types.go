@@ -14,17 +14,95 @@ type TeamMember struct {
 type TeamSelectorSpec struct {
 	Agent          string `json:"agent,omitempty"`
 	SelectorPrompt string `json:"selectorPrompt,omitempty"`
+	// +kubebuilder:validation:Optional
+	// AllowTerminate lets the selector end the team instead of choosing the next member
+	AllowTerminate bool `json:"allowTerminate,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MaxRetries is how often the selector is asked again after choosing a member that cannot
+	// respond next. Defaults to 2.
+	MaxRetries *int `json:"maxRetries,omitempty"`
+}
+
+// TeamGraphEdgeCondition decides whether a graph edge is taken, based on the last answer of
+// the member the edge leaves. Exactly one of regex and jsonPath must be set.
+type TeamGraphEdgeCondition struct {
+	// +kubebuilder:validation:Optional
+	// Regex is matched against the answer
+	Regex string `json:"regex,omitempty"`
+	// +kubebuilder:validation:Optional
+	// JSONPath is evaluated against the answer parsed as JSON, such as the structured output of
+	// an agent, for example '.decision'
+	JSONPath string `json:"jsonPath,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Value the JSONPath result must equal. Without a value the edge is taken when the result is
+	// not empty, false or null.
+	Value string `json:"value,omitempty"`
 }
 
 type TeamGraphEdge struct {
 	From string `json:"from"`
 	To   string `json:"to"`
+	// +kubebuilder:validation:Optional
+	// Condition for taking the edge. The edges leaving a member are evaluated in order and the
+	// first one that matches is taken; an edge without a condition always matches.
+	Condition *TeamGraphEdgeCondition `json:"condition,omitempty"`
 }
 
 type TeamGraphSpec struct {
 	Edges []TeamGraphEdge `json:"edges"`
 }
 
+// TeamParallelSpec configures the parallel strategy, which gives the same input to several
+// members at once and optionally merges their outputs
+type TeamParallelSpec struct {
+	// +kubebuilder:validation:Optional
+	// Members that run, by name. All members run when empty.
+	Members []string `json:"members,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Aggregator is an agent that merges the outputs of the members into the final answer
+	Aggregator string `json:"aggregator,omitempty"`
+	// +kubebuilder:validation:Optional
+	// AggregatorPrompt is a Go template for the instructions given to the aggregator, with the
+	// member outputs in the Outputs field
+	AggregatorPrompt string `json:"aggregatorPrompt,omitempty"`
+}
+
+// TeamTerminationCondition matches a member turn. All fields that are set must match, so a
+// condition with only a member matches every turn of that member.
+type TeamTerminationCondition struct {
+	// +kubebuilder:validation:Optional
+	// Member whose turns are matched
+	Member string `json:"member,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Text the answer of the turn contains
+	Text string `json:"text,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Regex matched against the answer of the turn
+	Regex string `json:"regex,omitempty"`
+	// +kubebuilder:validation:Optional
+	// JSONPath evaluated against the answer parsed as JSON, for example '.consensus'
+	JSONPath string `json:"jsonPath,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Value the JSONPath result must equal. Without a value the condition matches when the
+	// result is not empty, false or null.
+	Value string `json:"value,omitempty"`
+}
+
+// TeamTerminationSpec stops a team before its strategy ends. The team stops after the first
+// member turn that meets any of the conditions or limits.
+type TeamTerminationSpec struct {
+	// +kubebuilder:validation:Optional
+	Conditions []TeamTerminationCondition `json:"conditions,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// MaxTokens stops the team once its members used this many tokens in total
+	MaxTokens *int64 `json:"maxTokens,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Timeout stops the team once it ran this long. A running turn is completed first.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
 type TeamSpec struct {
 	Members     []TeamMember      `json:"members"`
 	Strategy    string            `json:"strategy"`
@@ -32,6 +110,10 @@ type TeamSpec struct {
 	MaxTurns    *int              `json:"maxTurns,omitempty"`
 	Selector    *TeamSelectorSpec `json:"selector,omitempty"`
 	Graph       *TeamGraphSpec    `json:"graph,omitempty"`
+	Parallel    *TeamParallelSpec `json:"parallel,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Termination stops the team early, for example once a debate reached consensus
+	Termination *TeamTerminationSpec `json:"termination,omitempty"`
 }
 
 type TeamStatus struct {
@@ -91,6 +91,9 @@ type ToolSpec struct {
 	InputSchema *runtime.RawExtension `json:"inputSchema,omitempty"`
 	// Optional additional tool information
 	Annotations *ToolAnnotations `json:"annotations,omitempty"`
+	// +kubebuilder:validation:Optional
+	// RequiresApproval makes every agent using the tool wait for a human decision before each call
+	RequiresApproval bool `json:"requiresApproval,omitempty"`
 	// HTTP-specific configuration for HTTP-based tools
 	HTTP *HTTPSpec `json:"http,omitempty"`
 	// MCP-specific configuration for MCP server tools
@@ -304,6 +304,36 @@ func (in *Agent) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentLimits) DeepCopyInto(out *AgentLimits) {
+	*out = *in
+	if in.MaxToolIterations != nil {
+		in, out := &in.MaxToolIterations, &out.MaxToolIterations
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxToolCalls != nil {
+		in, out := &in.MaxToolCalls, &out.MaxToolCalls
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentLimits.
+func (in *AgentLimits) DeepCopy() *AgentLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentList) DeepCopyInto(out *AgentList) {
 	*out = *in
@@ -374,6 +404,11 @@ func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 		*out = new(AgentModelRef)
 		**out = **in
 	}
+	if in.FallbackModels != nil {
+		in, out := &in.FallbackModels, &out.FallbackModels
+		*out = make([]AgentModelRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.ExecutionEngine != nil {
 		in, out := &in.ExecutionEngine, &out.ExecutionEngine
 		*out = new(ExecutionEngineRef)
@@ -405,6 +440,21 @@ func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaxConcurrentToolCalls != nil {
+		in, out := &in.MaxConcurrentToolCalls, &out.MaxConcurrentToolCalls
+		*out = new(int)
+		**out = **in
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = new(AgentLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolApproval != nil {
+		in, out := &in.ToolApproval, &out.ToolApproval
+		*out = new(ToolApprovalPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
@@ -481,6 +531,37 @@ func (in *AgentToolRef) DeepCopy() *AgentToolRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AnthropicModelConfig) DeepCopyInto(out *AnthropicModelConfig) {
+	*out = *in
+	in.BaseURL.DeepCopyInto(&out.BaseURL)
+	in.APIKey.DeepCopyInto(&out.APIKey)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]ValueSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AnthropicModelConfig.
+func (in *AnthropicModelConfig) DeepCopy() *AnthropicModelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AnthropicModelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureModelConfig) DeepCopyInto(out *AzureModelConfig) {
 	*out = *in
@@ -1099,6 +1180,60 @@ func (in *ExpressionRule) DeepCopy() *ExpressionRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeminiModelConfig) DeepCopyInto(out *GeminiModelConfig) {
+	*out = *in
+	if in.BaseURL != nil {
+		in, out := &in.BaseURL, &out.BaseURL
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccountKey != nil {
+		in, out := &in.ServiceAccountKey, &out.ServiceAccountKey
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Project != nil {
+		in, out := &in.Project, &out.Project
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Location != nil {
+		in, out := &in.Location, &out.Location
+		*out = new(ValueSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]ValueSource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeminiModelConfig.
+func (in *GeminiModelConfig) DeepCopy() *GeminiModelConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GeminiModelConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSpec.
 func (in *HTTPSpec) DeepCopy() *HTTPSpec {
 	if in == nil {
@@ -1175,6 +1310,21 @@ func (in *HeaderValueSource) DeepCopy() *HeaderValueSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InputResponse) DeepCopyInto(out *InputResponse) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InputResponse.
+func (in *InputResponse) DeepCopy() *InputResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(InputResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServer) DeepCopyInto(out *MCPServer) {
 	*out = *in
@@ -1466,6 +1616,16 @@ func (in *ModelConfig) DeepCopyInto(out *ModelConfig) {
 		*out = new(BedrockModelConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Anthropic != nil {
+		in, out := &in.Anthropic, &out.Anthropic
+		*out = new(AnthropicModelConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Gemini != nil {
+		in, out := &in.Gemini, &out.Gemini
+		*out = new(GeminiModelConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelConfig.
@@ -1510,6 +1670,88 @@ func (in *ModelList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelOverride) DeepCopyInto(out *ModelOverride) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelRef != nil {
+		in, out := &in.ModelRef, &out.ModelRef
+		*out = new(AgentModelRef)
+		**out = **in
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelOverride.
+func (in *ModelOverride) DeepCopy() *ModelOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelPricing) DeepCopyInto(out *ModelPricing) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelPricing.
+func (in *ModelPricing) DeepCopy() *ModelPricing {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelPricing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelRetryPolicy) DeepCopyInto(out *ModelRetryPolicy) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.InitialBackoff != nil {
+		in, out := &in.InitialBackoff, &out.InitialBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RetryOn != nil {
+		in, out := &in.RetryOn, &out.RetryOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRetryPolicy.
+func (in *ModelRetryPolicy) DeepCopy() *ModelRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 	*out = *in
@@ -1520,6 +1762,16 @@ func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Pricing != nil {
+		in, out := &in.Pricing, &out.Pricing
+		*out = new(ModelPricing)
+		**out = **in
+	}
+	if in.RetryPolicy != nil {
+		in, out := &in.RetryPolicy, &out.RetryPolicy
+		*out = new(ModelRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
@@ -1632,6 +1884,48 @@ func (in *Parameter) DeepCopy() *Parameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingInput) DeepCopyInto(out *PendingInput) {
+	*out = *in
+	if in.RequestedAt != nil {
+		in, out := &in.RequestedAt, &out.RequestedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingInput.
+func (in *PendingInput) DeepCopy() *PendingInput {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingToolCall) DeepCopyInto(out *PendingToolCall) {
+	*out = *in
+	if in.RequestedAt != nil {
+		in, out := &in.RequestedAt, &out.RequestedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingToolCall.
+func (in *PendingToolCall) DeepCopy() *PendingToolCall {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingToolCall)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Query) DeepCopyInto(out *Query) {
 	*out = *in
@@ -1679,6 +1973,55 @@ func (in *QueryBasedEvaluationConfig) DeepCopy() *QueryBasedEvaluationConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryBudget) DeepCopyInto(out *QueryBudget) {
+	*out = *in
+	if in.MaxPromptTokens != nil {
+		in, out := &in.MaxPromptTokens, &out.MaxPromptTokens
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxCompletionTokens != nil {
+		in, out := &in.MaxCompletionTokens, &out.MaxCompletionTokens
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxTotalTokens != nil {
+		in, out := &in.MaxTotalTokens, &out.MaxTotalTokens
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryBudget.
+func (in *QueryBudget) DeepCopy() *QueryBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryCheckpoint) DeepCopyInto(out *QueryCheckpoint) {
+	*out = *in
+	if in.LastCheckpointTime != nil {
+		in, out := &in.LastCheckpointTime, &out.LastCheckpointTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryCheckpoint.
+func (in *QueryCheckpoint) DeepCopy() *QueryCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueryList) DeepCopyInto(out *QueryList) {
 	*out = *in
@@ -1726,6 +2069,140 @@ func (in *QueryParameterReference) DeepCopy() *QueryParameterReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryQuota) DeepCopyInto(out *QueryQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryQuota.
+func (in *QueryQuota) DeepCopy() *QueryQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QueryQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryQuotaList) DeepCopyInto(out *QueryQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]QueryQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryQuotaList.
+func (in *QueryQuotaList) DeepCopy() *QueryQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QueryQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryQuotaSpec) DeepCopyInto(out *QueryQuotaSpec) {
+	*out = *in
+	if in.MaxRunningQueries != nil {
+		in, out := &in.MaxRunningQueries, &out.MaxRunningQueries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTotalTokens != nil {
+		in, out := &in.MaxTotalTokens, &out.MaxTotalTokens
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryQuotaSpec.
+func (in *QueryQuotaSpec) DeepCopy() *QueryQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryQuotaStatus) DeepCopyInto(out *QueryQuotaStatus) {
+	*out = *in
+	if in.ConsumedTokens != nil {
+		in, out := &in.ConsumedTokens, &out.ConsumedTokens
+		*out = make([]QueryQuotaTokenBucket, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryQuotaStatus.
+func (in *QueryQuotaStatus) DeepCopy() *QueryQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryQuotaTokenBucket) DeepCopyInto(out *QueryQuotaTokenBucket) {
+	*out = *in
+	in.Start.DeepCopyInto(&out.Start)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryQuotaTokenBucket.
+func (in *QueryQuotaTokenBucket) DeepCopy() *QueryQuotaTokenBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryQuotaTokenBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *QueryRef) DeepCopyInto(out *QueryRef) {
 	*out = *in
@@ -1813,6 +2290,28 @@ func (in *QuerySpec) DeepCopyInto(out *QuerySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ModelOverrides != nil {
+		in, out := &in.ModelOverrides, &out.ModelOverrides
+		*out = make([]ModelOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(QueryBudget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolApprovals != nil {
+		in, out := &in.ToolApprovals, &out.ToolApprovals
+		*out = make([]ToolApproval, len(*in))
+		copy(*out, *in)
+	}
+	if in.InputResponses != nil {
+		in, out := &in.InputResponses, &out.InputResponses
+		*out = make([]InputResponse, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuerySpec.
@@ -1846,6 +2345,25 @@ func (in *QueryStatus) DeepCopyInto(out *QueryStatus) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Checkpoint != nil {
+		in, out := &in.Checkpoint, &out.Checkpoint
+		*out = new(QueryCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingToolCalls != nil {
+		in, out := &in.PendingToolCalls, &out.PendingToolCalls
+		*out = make([]PendingToolCall, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingInputs != nil {
+		in, out := &in.PendingInputs, &out.PendingInputs
+		*out = make([]PendingInput, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryStatus.
@@ -1965,6 +2483,11 @@ func (in *Team) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamGraphEdge) DeepCopyInto(out *TeamGraphEdge) {
 	*out = *in
+	if in.Condition != nil {
+		in, out := &in.Condition, &out.Condition
+		*out = new(TeamGraphEdgeCondition)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamGraphEdge.
@@ -1977,13 +2500,30 @@ func (in *TeamGraphEdge) DeepCopy() *TeamGraphEdge {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamGraphEdgeCondition) DeepCopyInto(out *TeamGraphEdgeCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamGraphEdgeCondition.
+func (in *TeamGraphEdgeCondition) DeepCopy() *TeamGraphEdgeCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamGraphEdgeCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamGraphSpec) DeepCopyInto(out *TeamGraphSpec) {
 	*out = *in
 	if in.Edges != nil {
 		in, out := &in.Edges, &out.Edges
 		*out = make([]TeamGraphEdge, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -2044,9 +2584,34 @@ func (in *TeamMember) DeepCopy() *TeamMember {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamParallelSpec) DeepCopyInto(out *TeamParallelSpec) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamParallelSpec.
+func (in *TeamParallelSpec) DeepCopy() *TeamParallelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamParallelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamSelectorSpec) DeepCopyInto(out *TeamSelectorSpec) {
 	*out = *in
+	if in.MaxRetries != nil {
+		in, out := &in.MaxRetries, &out.MaxRetries
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamSelectorSpec.
@@ -2075,13 +2640,23 @@ func (in *TeamSpec) DeepCopyInto(out *TeamSpec) {
 	if in.Selector != nil {
 		in, out := &in.Selector, &out.Selector
 		*out = new(TeamSelectorSpec)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Graph != nil {
 		in, out := &in.Graph, &out.Graph
 		*out = new(TeamGraphSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Parallel != nil {
+		in, out := &in.Parallel, &out.Parallel
+		*out = new(TeamParallelSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Termination != nil {
+		in, out := &in.Termination, &out.Termination
+		*out = new(TeamTerminationSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamSpec.
@@ -2116,6 +2691,51 @@ func (in *TeamStatus) DeepCopy() *TeamStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamTerminationCondition) DeepCopyInto(out *TeamTerminationCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamTerminationCondition.
+func (in *TeamTerminationCondition) DeepCopy() *TeamTerminationCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamTerminationCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamTerminationSpec) DeepCopyInto(out *TeamTerminationSpec) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]TeamTerminationCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxTokens != nil {
+		in, out := &in.MaxTokens, &out.MaxTokens
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamTerminationSpec.
+func (in *TeamTerminationSpec) DeepCopy() *TeamTerminationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamTerminationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamToolRef.
 func (in *TeamToolRef) DeepCopy() *TeamToolRef {
 	if in == nil {
@@ -2178,6 +2798,46 @@ func (in *ToolAnnotations) DeepCopy() *ToolAnnotations {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolApproval) DeepCopyInto(out *ToolApproval) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolApproval.
+func (in *ToolApproval) DeepCopy() *ToolApproval {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolApproval)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolApprovalPolicy) DeepCopyInto(out *ToolApprovalPolicy) {
+	*out = *in
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolApprovalPolicy.
+func (in *ToolApprovalPolicy) DeepCopy() *ToolApprovalPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolApprovalPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolFunction) DeepCopyInto(out *ToolFunction) {
 	*out = *in
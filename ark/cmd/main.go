@@ -3,6 +3,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"flag"
@@ -31,7 +32,9 @@ import (
 	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
 	"mckinsey.com/ark/internal/controller"
 	eventingconfig "mckinsey.com/ark/internal/eventing/config"
+	"mckinsey.com/ark/internal/genai"
 	telemetryconfig "mckinsey.com/ark/internal/telemetry/config"
+	arkmetrics "mckinsey.com/ark/internal/telemetry/metrics"
 	webhookv1 "mckinsey.com/ark/internal/webhook/v1"
 	webhookv1prealpha1 "mckinsey.com/ark/internal/webhook/v1prealpha1"
 	// +kubebuilder:scaffold:imports
@@ -63,6 +66,11 @@ type config struct {
 	probeAddr                                        string
 	secureMetrics                                    bool
 	enableHTTP2                                      bool
+	toolCallbackAddr, toolCallbackURL                string
+	a2aPushNotificationAddr, a2aPushNotificationURL  string
+	a2aPushNotificationKeyFile                       string
+	a2aEndpointAddr, a2aEndpointURL                  string
+	a2aEndpointAudience                              string
 }
 
 func main() {
@@ -96,7 +104,11 @@ func main() {
 	// Initialize eventing provider with direct client for broker discovery
 	eventingProvider := eventingconfig.NewProvider(mgr, directClient)
 
-	setupControllers(mgr, telemetryProvider, eventingProvider)
+	toolCallbacks := setupToolCallbacks(mgr, result.config)
+	a2aPushNotifications := setupA2APushNotifications(mgr, result.config)
+	setupA2AEndpoint(mgr, result.config)
+
+	setupControllers(mgr, telemetryProvider, eventingProvider, toolCallbacks, a2aPushNotifications)
 	setupWebhooks(mgr)
 	startManager(mgr, metricsCertWatcher, webhookCertWatcher)
 }
@@ -125,6 +137,22 @@ func parseFlags() struct {
 	flag.StringVar(&cfg.metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&cfg.enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.StringVar(&cfg.toolCallbackAddr, "tool-callback-bind-address", "0", "The address the tool callback endpoint "+
+		"for execution engines binds to. Use the port :8082. If not set, it will be 0 in order to disable the endpoint.")
+	flag.StringVar(&cfg.toolCallbackURL, "tool-callback-url", "",
+		"The URL execution engines use to reach the tool callback endpoint, e.g. http://ark-tool-callback-service.ark-system.svc:8082")
+	flag.StringVar(&cfg.a2aPushNotificationAddr, "a2a-push-notification-bind-address", "0", "The address the endpoint "+
+		"receiving A2A task push notifications binds to. Use the port :8083. If not set, it will be 0 in order to disable the endpoint.")
+	flag.StringVar(&cfg.a2aPushNotificationURL, "a2a-push-notification-url", "",
+		"The URL A2A servers use to reach the push notification endpoint, e.g. http://ark-a2a-push-notification-service.ark-system.svc:8083")
+	flag.StringVar(&cfg.a2aPushNotificationKeyFile, "a2a-push-notification-key-file", "",
+		"The file holding the key push notification tokens are derived from, shared by all controller replicas.")
+	flag.StringVar(&cfg.a2aEndpointAddr, "a2a-endpoint-bind-address", "0", "The address the A2A endpoint serving "+
+		"exposed agents and teams binds to. Use the port :8084. If not set, it will be 0 in order to disable the endpoint.")
+	flag.StringVar(&cfg.a2aEndpointURL, "a2a-endpoint-url", "",
+		"The URL A2A clients use to reach the A2A endpoint, advertised in agent cards, e.g. http://ark-a2a-endpoint-service.ark-system.svc:8084")
+	flag.StringVar(&cfg.a2aEndpointAudience, "a2a-endpoint-audience", "ark-a2a",
+		"The audience the service account tokens of A2A endpoint clients must be issued for.")
 	flag.BoolVar(&showVersion, "version", false, "Show version information and exit")
 
 	zapOpts := zap.Options{Development: false}
@@ -239,7 +267,73 @@ func setupMetricsServer(cfg config, baseTLSOpts []func(*tls.Config)) (metricsser
 	return metricsServerOptions, metricsCertWatcher
 }
 
-func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provider, eventingProvider *eventingconfig.Provider) {
+// setupToolCallbacks serves the endpoint execution engines use to run agent tools, if enabled
+func setupToolCallbacks(mgr ctrl.Manager, cfg config) *genai.ToolCallbackServer {
+	if cfg.toolCallbackAddr == "" || cfg.toolCallbackAddr == "0" {
+		return nil
+	}
+	if cfg.toolCallbackURL == "" {
+		setupLog.Error(nil, "--tool-callback-url is required when the tool callback endpoint is enabled")
+		os.Exit(1)
+	}
+
+	server := genai.NewToolCallbackServer(cfg.toolCallbackAddr, cfg.toolCallbackURL)
+	if err := mgr.Add(server); err != nil {
+		setupLog.Error(err, "unable to add tool callback server to manager")
+		os.Exit(1)
+	}
+	return server
+}
+
+// setupA2APushNotifications serves the endpoint A2A servers push task updates to, if enabled
+func setupA2APushNotifications(mgr ctrl.Manager, cfg config) *genai.A2APushNotificationServer {
+	if cfg.a2aPushNotificationAddr == "" || cfg.a2aPushNotificationAddr == "0" {
+		return nil
+	}
+	if cfg.a2aPushNotificationURL == "" {
+		setupLog.Error(nil, "--a2a-push-notification-url is required when the A2A push notification endpoint is enabled")
+		os.Exit(1)
+	}
+
+	if cfg.a2aPushNotificationKeyFile == "" {
+		setupLog.Error(nil, "--a2a-push-notification-key-file is required when the A2A push notification endpoint is enabled")
+		os.Exit(1)
+	}
+	key, err := os.ReadFile(cfg.a2aPushNotificationKeyFile)
+	if err != nil {
+		setupLog.Error(err, "unable to read A2A push notification key")
+		os.Exit(1)
+	}
+
+	server, err := genai.NewA2APushNotificationServer(cfg.a2aPushNotificationAddr, cfg.a2aPushNotificationURL, bytes.TrimSpace(key), mgr.GetClient())
+	if err != nil {
+		setupLog.Error(err, "unable to create A2A push notification server")
+		os.Exit(1)
+	}
+	if err := mgr.Add(server); err != nil {
+		setupLog.Error(err, "unable to add A2A push notification server to manager")
+		os.Exit(1)
+	}
+	return server
+}
+
+// setupA2AEndpoint serves the agents and teams annotated to be exposed over A2A, if enabled
+func setupA2AEndpoint(mgr ctrl.Manager, cfg config) {
+	if cfg.a2aEndpointAddr == "" || cfg.a2aEndpointAddr == "0" {
+		return
+	}
+	if cfg.a2aEndpointURL == "" {
+		setupLog.Error(nil, "--a2a-endpoint-url is required when the A2A endpoint is enabled")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(genai.NewA2AEndpoint(cfg.a2aEndpointAddr, cfg.a2aEndpointURL, cfg.a2aEndpointAudience, mgr.GetClient())); err != nil {
+		setupLog.Error(err, "unable to add A2A endpoint to manager")
+		os.Exit(1)
+	}
+}
+
+func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provider, eventingProvider *eventingconfig.Provider, toolCallbacks *genai.ToolCallbackServer, a2aPushNotifications *genai.A2APushNotificationServer) {
 	controllers := []struct {
 		name       string
 		reconciler interface{ SetupWithManager(ctrl.Manager) error }
@@ -250,10 +344,12 @@ func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provi
 			Eventing: eventingProvider,
 		}},
 		{"Query", &controller.QueryReconciler{
-			Client:    mgr.GetClient(),
-			Scheme:    mgr.GetScheme(),
-			Telemetry: telemetryProvider,
-			Eventing:  eventingProvider,
+			Client:               mgr.GetClient(),
+			Scheme:               mgr.GetScheme(),
+			Telemetry:            telemetryProvider,
+			Eventing:             eventingProvider,
+			ToolCallbacks:        toolCallbacks,
+			A2APushNotifications: a2aPushNotifications,
 		}},
 		{"Tool", &controller.ToolReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme()}},
 		{"Team", &controller.TeamReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme(), Recorder: mgr.GetEventRecorderFor("team-controller")}},
@@ -274,6 +370,7 @@ func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provi
 			Eventing:  eventingProvider,
 		}},
 		{"Memory", &controller.MemoryReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme()}},
+		{"QueryQuota", &controller.QueryQuotaReconciler{Client: mgr.GetClient(), Scheme: mgr.GetScheme()}},
 		{"ExecutionEngine", &controller.ExecutionEngineReconciler{
 			Client:   mgr.GetClient(),
 			Scheme:   mgr.GetScheme(),
@@ -294,6 +391,11 @@ func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provi
 			os.Exit(1)
 		}
 	}
+
+	if err := arkmetrics.RegisterA2ATaskCollector(mgr.GetClient()); err != nil {
+		setupLog.Error(err, "unable to register A2ATask metrics")
+		os.Exit(1)
+	}
 }
 
 func setupWebhooks(mgr ctrl.Manager) {
@@ -14,10 +14,14 @@ const (
 
 // A2A annotations
 const (
-	A2AServerName    = ARKPrefix + "a2a-server-name"
-	A2AServerAddress = ARKPrefix + "a2a-server-address"
-	A2AServerSkills  = ARKPrefix + "a2a-server-skills"
-	A2AContextID     = ARKPrefix + "a2a-context-id"
+	A2AServerName         = ARKPrefix + "a2a-server-name"
+	A2AServerAddress      = ARKPrefix + "a2a-server-address"
+	A2AServerSkills       = ARKPrefix + "a2a-server-skills"
+	A2AServerCapabilities = ARKPrefix + "a2a-server-capabilities"
+	A2AContextID          = ARKPrefix + "a2a-context-id"
+	A2AExpose             = ARKPrefix + "a2a-expose"
+	A2ACaller             = ARKPrefix + "a2a-caller"
+	A2ACallerContextID    = ARKPrefix + "a2a-caller-context-id"
 )
 
 // MCP annotations
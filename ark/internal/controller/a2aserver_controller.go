@@ -252,13 +252,15 @@ func (r *A2AServerReconciler) createAgentWithSkills(ctx context.Context, a2aServ
 }
 
 func (r *A2AServerReconciler) buildAgentWithSkills(a2aServer *arkv1prealpha1.A2AServer, agentCard *genai.A2AAgentCard, agentName string) *arkv1alpha1.Agent {
-	// Build skills annotation JSON
+	// Build skills and capabilities annotation JSON
 	skillsJSON, _ := json.Marshal(agentCard.Skills)
+	capabilitiesJSON, _ := json.Marshal(agentCard.Capabilities)
 
 	agentAnnotations := map[string]string{
-		annotations.A2AServerName:    a2aServer.Name,
-		annotations.A2AServerAddress: a2aServer.Status.LastResolvedAddress,
-		annotations.A2AServerSkills:  string(skillsJSON),
+		annotations.A2AServerName:         a2aServer.Name,
+		annotations.A2AServerAddress:      a2aServer.Status.LastResolvedAddress,
+		annotations.A2AServerSkills:       string(skillsJSON),
+		annotations.A2AServerCapabilities: string(capabilitiesJSON),
 	}
 
 	// Inherit ark.mckinsey.com annotations from A2AServer to Agent
@@ -310,8 +312,9 @@ func (r *A2AServerReconciler) createOrUpdateAgent(ctx context.Context, agent *ar
 		return false, fmt.Errorf("failed to get agent %s: %w", agentName, getErr)
 	}
 
-	// Only update if skills annotation has changed
-	if existingAgent.Annotations[annotations.A2AServerSkills] != agent.Annotations[annotations.A2AServerSkills] {
+	// Only update if skills or capabilities annotations have changed
+	if existingAgent.Annotations[annotations.A2AServerSkills] != agent.Annotations[annotations.A2AServerSkills] ||
+		existingAgent.Annotations[annotations.A2AServerCapabilities] != agent.Annotations[annotations.A2AServerCapabilities] {
 		existingAgent.Spec = agent.Spec
 		existingAgent.Annotations = agent.Annotations
 		if err := r.Update(ctx, existingAgent); err != nil {
@@ -7,12 +7,16 @@ import (
 	"fmt"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
 
@@ -22,6 +26,11 @@ import (
 	"mckinsey.com/ark/internal/genai"
 )
 
+// receivedUpdatesFallbackInterval is how long a task whose updates are streamed or pushed can go
+// without one before the A2A server is polled, in case the stream dropped or the controller
+// restarted and no longer accepts the server's push notifications
+const receivedUpdatesFallbackInterval = 5 * time.Minute
+
 type A2ATaskReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
@@ -31,7 +40,7 @@ type A2ATaskReconciler struct {
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=a2atasks,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=a2atasks/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=a2atasks/status,verbs=get;update;patch
-// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agents,verbs=get;list
 
 func (r *A2ATaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -66,11 +75,37 @@ func (r *A2ATaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, r.Status().Update(ctx, &a2aTask)
 	}
 
+	// Streamed and pushed updates set the phase, from which the conditions are derived
+	receivesUpdates := a2aTask.Spec.UpdateMode == genai.A2ATaskUpdateModeStream || a2aTask.Spec.UpdateMode == genai.A2ATaskUpdateModePush
+	if receivesUpdates && r.syncCompletedCondition(&a2aTask) {
+		if err := r.Status().Update(ctx, &a2aTask); err != nil {
+			log.Error(err, "unable to update A2ATask status")
+			return ctrl.Result{}, err
+		}
+	}
+
 	// Handle terminal states
 	if genai.IsTerminalPhase(a2aTask.Status.Phase) {
 		return ctrl.Result{}, nil
 	}
 
+	// The remote work of a query that no longer waits for it is cancelled too, so that it stops incurring cost
+	if r.isQueryStopped(ctx, a2aTask.Spec.QueryRef, a2aTask.Namespace) {
+		r.cancelA2ATask(ctx, &a2aTask)
+		if err := r.Status().Update(ctx, &a2aTask); err != nil {
+			log.Error(err, "unable to update A2ATask status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Tasks receiving updates are only polled when the updates stopped arriving
+	if receivesUpdates {
+		if wait := receivedUpdatesFallbackInterval - time.Since(lastA2ATaskUpdate(&a2aTask)); wait > 0 {
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+	}
+
 	// Fetch task status from A2A server for all non-terminal tasks
 	if err := r.fetchA2ATaskStatus(ctx, &a2aTask); err != nil {
 		log.Error(err, "failed to fetch A2A task status", "taskId", a2aTask.Spec.TaskID)
@@ -91,6 +126,9 @@ func (r *A2ATaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		if a2aTask.Spec.PollInterval != nil {
 			pollInterval = a2aTask.Spec.PollInterval.Duration
 		}
+		if receivesUpdates {
+			pollInterval = max(pollInterval, receivedUpdatesFallbackInterval)
+		}
 		return ctrl.Result{RequeueAfter: pollInterval}, nil
 	}
 
@@ -100,9 +138,93 @@ func (r *A2ATaskReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 func (r *A2ATaskReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&arkv1alpha1.A2ATask{}).
+		// Watch for Query events to cancel the tasks of stopped or deleted queries
+		Watches(
+			&arkv1alpha1.Query{},
+			handler.EnqueueRequestsFromMapFunc(r.findA2ATasksForQuery),
+		).
 		Complete(r)
 }
 
+// findA2ATasksForQuery finds the tasks created by the given query once it is stopped or deleted
+func (r *A2ATaskReconciler) findA2ATasksForQuery(ctx context.Context, obj client.Object) []reconcile.Request {
+	query, ok := obj.(*arkv1alpha1.Query)
+	if !ok || !r.isQueryStopped(ctx, arkv1alpha1.QueryRef{Name: query.Name}, query.Namespace) {
+		return nil
+	}
+
+	var a2aTasks arkv1alpha1.A2ATaskList
+	if err := r.List(ctx, &a2aTasks, client.InNamespace(query.Namespace)); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list A2A tasks", "namespace", query.Namespace)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, a2aTask := range a2aTasks.Items {
+		if a2aTask.Spec.QueryRef.Name == query.Name && !genai.IsTerminalPhase(a2aTask.Status.Phase) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: a2aTask.Name, Namespace: a2aTask.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// isQueryStopped returns whether the query no longer waits for its A2A tasks: it was cancelled,
+// deleted, or ended in an error such as a timeout or an exceeded budget. The query is read rather
+// than taken from the watch event, so that a deleted query is recognised as well.
+func (r *A2ATaskReconciler) isQueryStopped(ctx context.Context, queryRef arkv1alpha1.QueryRef, namespace string) bool {
+	if queryRef.Namespace != "" {
+		namespace = queryRef.Namespace
+	}
+
+	var query arkv1alpha1.Query
+	if err := r.Get(ctx, client.ObjectKey{Name: queryRef.Name, Namespace: namespace}, &query); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true
+		}
+		logf.FromContext(ctx).Error(err, "unable to get query of A2ATask", "query", queryRef.Name)
+		return false
+	}
+	if query.Spec.Cancel || query.DeletionTimestamp != nil {
+		return true
+	}
+	switch query.Status.Phase {
+	case arkv1alpha1.QueryPhaseError, arkv1alpha1.QueryPhaseCanceled, arkv1alpha1.QueryPhaseBudgetExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// cancelA2ATask sends tasks/cancel to the A2A server and marks the task cancelled. The task is
+// cancelled even when the server cannot be reached or keeps it running, as its query no longer
+// waits for it; only a task the server already finished keeps its phase.
+func (r *A2ATaskReconciler) cancelA2ATask(ctx context.Context, a2aTask *arkv1alpha1.A2ATask) {
+	log := logf.FromContext(ctx)
+	oldPhase := a2aTask.Status.Phase
+
+	a2aClient, err := r.createA2AClient(ctx, a2aTask)
+	if err == nil {
+		var task *protocol.Task
+		if task, err = a2aClient.CancelTasks(ctx, protocol.TaskIDParams{ID: a2aTask.Spec.TaskID}); err == nil {
+			genai.UpdateA2ATaskStatus(&a2aTask.Status, task)
+		}
+	}
+	if err != nil {
+		log.Error(err, "failed to cancel A2A task on the A2A server", "taskId", a2aTask.Spec.TaskID)
+	}
+
+	if !genai.IsTerminalPhase(a2aTask.Status.Phase) {
+		a2aTask.Status.Phase = genai.PhaseCancelled
+	}
+	if a2aTask.Status.CompletionTime == nil {
+		now := metav1.Now()
+		a2aTask.Status.CompletionTime = &now
+	}
+	r.updateConditionsAndEvents(a2aTask, oldPhase)
+}
+
 // fetchA2ATaskStatus queries the A2A server for the current task status and updates the A2ATask
 func (r *A2ATaskReconciler) fetchA2ATaskStatus(ctx context.Context, a2aTask *arkv1alpha1.A2ATask) error {
 	a2aClient, err := r.createA2AClient(ctx, a2aTask)
@@ -121,7 +243,8 @@ func (r *A2ATaskReconciler) fetchA2ATaskStatus(ctx context.Context, a2aTask *ark
 	return nil
 }
 
-// createA2AClient creates an A2A client for the task
+// createA2AClient creates an A2A client for the task, sending the headers of its A2A server and
+// those the overrides of its agent and query set for the server, as when the task was sent
 func (r *A2ATaskReconciler) createA2AClient(ctx context.Context, a2aTask *arkv1alpha1.A2ATask) (*a2aclient.A2AClient, error) {
 	serverNamespace := a2aTask.Spec.A2AServerRef.Namespace
 	if serverNamespace == "" {
@@ -139,9 +262,51 @@ func (r *A2ATaskReconciler) createA2AClient(ctx context.Context, a2aTask *arkv1a
 		return nil, fmt.Errorf("A2AServer %v has no resolved address", serverKey)
 	}
 
-	agentName := a2aTask.Spec.AgentRef.Name
+	overrideHeaders, err := r.resolveOverrideHeaders(ctx, a2aTask)
+	if err != nil {
+		return nil, err
+	}
+
+	return genai.CreateA2AClient(ctx, r.Client, a2aServerAddress, a2aServer.Spec.Headers, overrideHeaders, serverNamespace, r.Eventing.A2aRecorder())
+}
+
+// resolveOverrideHeaders resolves the override headers of the task's A2A server again from its
+// agent and query, so that forwarded credentials such as the end user's token reach every call
+// for the task. Overrides of an agent or query that no longer exists are skipped.
+func (r *A2ATaskReconciler) resolveOverrideHeaders(ctx context.Context, a2aTask *arkv1alpha1.A2ATask) (map[string]string, error) {
+	queryKey := client.ObjectKey{Name: a2aTask.Spec.QueryRef.Name, Namespace: a2aTask.Spec.QueryRef.Namespace}
+	if queryKey.Namespace == "" {
+		queryKey.Namespace = a2aTask.Namespace
+	}
+	query := &arkv1alpha1.Query{}
+	if err := r.Get(ctx, queryKey, query); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("unable to get query %v: %w", queryKey, err)
+		}
+		query = nil
+	}
+
+	var agent *arkv1alpha1.Agent
+	if a2aTask.Spec.AgentRef.Name != "" {
+		agentKey := client.ObjectKey{Name: a2aTask.Spec.AgentRef.Name, Namespace: a2aTask.Spec.AgentRef.Namespace}
+		if agentKey.Namespace == "" {
+			agentKey.Namespace = a2aTask.Namespace
+		}
+		agent = &arkv1alpha1.Agent{}
+		if err := r.Get(ctx, agentKey, agent); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("unable to get agent %v: %w", agentKey, err)
+			}
+			agent = nil
+		}
+	}
 
-	return genai.CreateA2AClient(ctx, r.Client, a2aServerAddress, a2aServer.Spec.Headers, serverNamespace, agentName, r.Eventing.A2aRecorder())
+	headers, err := genai.ResolveA2AServerOverrideHeaders(ctx, r.Client, agent, query, a2aTask.Spec.A2AServerRef.Name)
+	if err != nil {
+		r.Eventing.A2aRecorder().A2AHeaderResolutionFailed(ctx, fmt.Sprintf("failed to resolve override headers of A2ATask %s: %v", a2aTask.Name, err))
+		return nil, err
+	}
+	return headers, nil
 }
 
 // queryTaskStatus queries the A2A server for task status
@@ -179,6 +344,27 @@ func (r *A2ATaskReconciler) updateConditionsAndEvents(a2aTask *arkv1alpha1.A2ATa
 	}
 }
 
+// syncCompletedCondition derives the Completed condition from a phase set by streamed or pushed
+// updates, returning whether it changed
+func (r *A2ATaskReconciler) syncCompletedCondition(a2aTask *arkv1alpha1.A2ATask) bool {
+	var reason string
+	if condition := meta.FindStatusCondition(a2aTask.Status.Conditions, string(arkv1alpha1.A2ATaskCompleted)); condition != nil {
+		reason = condition.Reason
+	}
+	r.updateConditionsAndEvents(a2aTask, "")
+	condition := meta.FindStatusCondition(a2aTask.Status.Conditions, string(arkv1alpha1.A2ATaskCompleted))
+	return condition != nil && condition.Reason != reason
+}
+
+// lastA2ATaskUpdate returns when the A2A server last reported the task status, or when the task
+// was created if it never did
+func lastA2ATaskUpdate(a2aTask *arkv1alpha1.A2ATask) time.Time {
+	if timestamp, err := time.Parse(time.RFC3339, a2aTask.Status.LastStatusTimestamp); err == nil {
+		return timestamp
+	}
+	return a2aTask.CreationTimestamp.Time
+}
+
 // setConditionCompleted sets the Completed condition on the A2ATask
 func (r *A2ATaskReconciler) setConditionCompleted(a2aTask *arkv1alpha1.A2ATask, status metav1.ConditionStatus, reason, message string) {
 	meta.SetStatusCondition(&a2aTask.Status.Conditions, metav1.Condition{
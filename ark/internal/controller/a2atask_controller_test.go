@@ -0,0 +1,370 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("A2ATask update modes", func() {
+	var (
+		a2aServer *httptest.Server
+		polls     int
+	)
+
+	BeforeEach(func() {
+		polls = 0
+		a2aServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				ID any `json:"id"`
+			}
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+			polls++
+			id, _ := json.Marshal(request.ID)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":"working"}}}`, id)
+		}))
+	})
+
+	AfterEach(func() {
+		a2aServer.Close()
+	})
+
+	reconcileTask := func(a2aTask *arkv1alpha1.A2ATask) (ctrl.Result, *arkv1alpha1.A2ATask) {
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(arkv1prealpha1.AddToScheme(scheme)).To(Succeed())
+		server := &arkv1prealpha1.A2AServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-server", Namespace: "default"},
+			Status:     arkv1prealpha1.A2AServerStatus{LastResolvedAddress: a2aServer.URL},
+		}
+		query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weather-query", Namespace: "default"}}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&arkv1alpha1.A2ATask{}).WithObjects(a2aTask, server, query).Build()
+		reconciler := &A2ATaskReconciler{Client: fakeClient, Scheme: scheme, Eventing: eventnoop.NewProvider()}
+
+		key := types.NamespacedName{Name: a2aTask.Name, Namespace: a2aTask.Namespace}
+		result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		updated := &arkv1alpha1.A2ATask{}
+		Expect(fakeClient.Get(context.Background(), key, updated)).To(Succeed())
+		return result, updated
+	}
+
+	newTask := func(updateMode, phase, lastUpdate string) *arkv1alpha1.A2ATask {
+		return &arkv1alpha1.A2ATask{
+			ObjectMeta: metav1.ObjectMeta{Name: "a2a-task-task-1", Namespace: "default", CreationTimestamp: metav1.Now()},
+			Spec: arkv1alpha1.A2ATaskSpec{
+				TaskID:       "task-1",
+				UpdateMode:   updateMode,
+				QueryRef:     arkv1alpha1.QueryRef{Name: "weather-query"},
+				A2AServerRef: arkv1alpha1.A2AServerRef{Name: "weather-server"},
+			},
+			Status: arkv1alpha1.A2ATaskStatus{
+				Phase:               phase,
+				LastStatusTimestamp: lastUpdate,
+				Conditions: []metav1.Condition{{
+					Type: string(arkv1alpha1.A2ATaskCompleted), Status: metav1.ConditionFalse, Reason: "TaskRunning", Message: "Task is running",
+				}},
+			},
+		}
+	}
+
+	It("completes streamed tasks from the phase set by their updates", func() {
+		result, updated := reconcileTask(newTask(genai.A2ATaskUpdateModeStream, genai.PhaseCompleted, time.Now().UTC().Format(time.RFC3339)))
+
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(polls).To(BeZero())
+		condition := meta.FindStatusCondition(updated.Status.Conditions, string(arkv1alpha1.A2ATaskCompleted))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("TaskSucceeded"))
+	})
+
+	It("does not poll tasks whose updates are pushed while they keep arriving", func() {
+		result, updated := reconcileTask(newTask(genai.A2ATaskUpdateModePush, genai.PhaseRunning, time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)))
+
+		Expect(result.RequeueAfter).To(BeNumerically("~", 4*time.Minute, 5*time.Second))
+		Expect(updated.Status.Phase).To(Equal(genai.PhaseRunning))
+		Expect(polls).To(BeZero())
+	})
+
+	It("polls tasks whose pushed updates stopped arriving", func() {
+		result, _ := reconcileTask(newTask(genai.A2ATaskUpdateModePush, genai.PhaseRunning, time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)))
+
+		Expect(result.RequeueAfter).To(Equal(receivedUpdatesFallbackInterval))
+		Expect(polls).To(Equal(1))
+	})
+
+	It("polls tasks on the poll interval by default", func() {
+		result, _ := reconcileTask(newTask("", genai.PhaseRunning, ""))
+
+		Expect(result.RequeueAfter).To(Equal(5 * time.Second))
+		Expect(polls).To(Equal(1))
+	})
+})
+
+var _ = Describe("A2ATask cancellation", func() {
+	var (
+		a2aServer *httptest.Server
+		methods   []string
+		state     string
+	)
+
+	BeforeEach(func() {
+		methods = nil
+		state = "canceled"
+		a2aServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				ID     any    `json:"id"`
+				Method string `json:"method"`
+			}
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+			methods = append(methods, request.Method)
+			id, _ := json.Marshal(request.ID)
+			w.Header().Set("Content-Type", "application/json")
+			if state == "" {
+				_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32002,"message":"Task cannot be canceled"}}`, id)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":%q}}}`, id, state)
+		}))
+	})
+
+	AfterEach(func() {
+		a2aServer.Close()
+	})
+
+	newReconciler := func(cancel bool, phase string) (*A2ATaskReconciler, types.NamespacedName) {
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(arkv1prealpha1.AddToScheme(scheme)).To(Succeed())
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-query", Namespace: "default"},
+			Spec:       arkv1alpha1.QuerySpec{Cancel: cancel},
+		}
+		server := &arkv1prealpha1.A2AServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-server", Namespace: "default"},
+			Status:     arkv1prealpha1.A2AServerStatus{LastResolvedAddress: a2aServer.URL},
+		}
+		a2aTask := &arkv1alpha1.A2ATask{
+			ObjectMeta: metav1.ObjectMeta{Name: "a2a-task-task-1", Namespace: "default", CreationTimestamp: metav1.Now()},
+			Spec: arkv1alpha1.A2ATaskSpec{
+				TaskID:       "task-1",
+				QueryRef:     arkv1alpha1.QueryRef{Name: "weather-query"},
+				A2AServerRef: arkv1alpha1.A2AServerRef{Name: "weather-server"},
+			},
+			Status: arkv1alpha1.A2ATaskStatus{
+				Phase: phase,
+				Conditions: []metav1.Condition{{
+					Type: string(arkv1alpha1.A2ATaskCompleted), Status: metav1.ConditionFalse, Reason: "TaskRunning", Message: "Task is running",
+				}},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&arkv1alpha1.A2ATask{}).WithObjects(query, server, a2aTask).Build()
+		reconciler := &A2ATaskReconciler{Client: fakeClient, Scheme: scheme, Eventing: eventnoop.NewProvider()}
+		return reconciler, types.NamespacedName{Name: a2aTask.Name, Namespace: a2aTask.Namespace}
+	}
+
+	reconcileTask := func(reconciler *A2ATaskReconciler, key types.NamespacedName) *arkv1alpha1.A2ATask {
+		result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(BeZero())
+
+		updated := &arkv1alpha1.A2ATask{}
+		Expect(reconciler.Get(context.Background(), key, updated)).To(Succeed())
+		return updated
+	}
+
+	It("sends tasks/cancel for the running tasks of cancelled queries", func() {
+		reconciler, key := newReconciler(true, genai.PhaseRunning)
+		updated := reconcileTask(reconciler, key)
+
+		Expect(methods).To(Equal([]string{"tasks/cancel"}))
+		Expect(updated.Status.Phase).To(Equal(genai.PhaseCancelled))
+		Expect(updated.Status.CompletionTime).NotTo(BeNil())
+		condition := meta.FindStatusCondition(updated.Status.Conditions, string(arkv1alpha1.A2ATaskCompleted))
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Reason).To(Equal("TaskCancelled"))
+	})
+
+	It("cancels the task when the A2A server refuses to", func() {
+		state = ""
+		reconciler, key := newReconciler(true, genai.PhaseRunning)
+		updated := reconcileTask(reconciler, key)
+
+		Expect(methods).To(Equal([]string{"tasks/cancel"}))
+		Expect(updated.Status.Phase).To(Equal(genai.PhaseCancelled))
+	})
+
+	It("keeps the phase of tasks the A2A server finished", func() {
+		state = "completed"
+		reconciler, key := newReconciler(true, genai.PhaseRunning)
+		updated := reconcileTask(reconciler, key)
+
+		Expect(updated.Status.Phase).To(Equal(genai.PhaseCompleted))
+	})
+
+	It("cancels the tasks of queries that ended in an error", func() {
+		reconciler, key := newReconciler(false, genai.PhaseRunning)
+		query := &arkv1alpha1.Query{}
+		Expect(reconciler.Get(context.Background(), types.NamespacedName{Name: "weather-query", Namespace: "default"}, query)).To(Succeed())
+		query.Status.Phase = arkv1alpha1.QueryPhaseError
+		Expect(reconciler.Update(context.Background(), query)).To(Succeed())
+
+		updated := reconcileTask(reconciler, key)
+
+		Expect(methods).To(Equal([]string{"tasks/cancel"}))
+		Expect(updated.Status.Phase).To(Equal(genai.PhaseCancelled))
+	})
+
+	It("cancels the tasks of deleted queries", func() {
+		reconciler, key := newReconciler(false, genai.PhaseRunning)
+		query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weather-query", Namespace: "default"}}
+		Expect(reconciler.Delete(context.Background(), query)).To(Succeed())
+
+		updated := reconcileTask(reconciler, key)
+
+		Expect(methods).To(Equal([]string{"tasks/cancel"}))
+		Expect(updated.Status.Phase).To(Equal(genai.PhaseCancelled))
+	})
+
+	It("leaves finished tasks alone", func() {
+		reconciler, key := newReconciler(true, genai.PhaseCompleted)
+		reconcileTask(reconciler, key)
+
+		Expect(methods).To(BeEmpty())
+	})
+
+	It("reconciles the running tasks of a query once it is cancelled", func() {
+		reconciler, key := newReconciler(false, genai.PhaseRunning)
+		query := &arkv1alpha1.Query{}
+		Expect(reconciler.Get(context.Background(), types.NamespacedName{Name: "weather-query", Namespace: "default"}, query)).To(Succeed())
+		Expect(reconciler.findA2ATasksForQuery(context.Background(), query)).To(BeEmpty())
+
+		query.Spec.Cancel = true
+		Expect(reconciler.Update(context.Background(), query)).To(Succeed())
+		Expect(reconciler.findA2ATasksForQuery(context.Background(), query)).To(ConsistOf(reconcile.Request{NamespacedName: key}))
+	})
+
+	It("reconciles the running tasks of a query once it is deleted", func() {
+		reconciler, key := newReconciler(false, genai.PhaseRunning)
+		query := &arkv1alpha1.Query{}
+		Expect(reconciler.Get(context.Background(), types.NamespacedName{Name: "weather-query", Namespace: "default"}, query)).To(Succeed())
+		Expect(reconciler.Delete(context.Background(), query)).To(Succeed())
+
+		Expect(reconciler.findA2ATasksForQuery(context.Background(), query)).To(ConsistOf(reconcile.Request{NamespacedName: key}))
+	})
+})
+
+var _ = Describe("A2ATask override headers", func() {
+	var (
+		a2aServer *httptest.Server
+		requests  []string
+	)
+
+	BeforeEach(func() {
+		requests = nil
+		a2aServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var request struct {
+				ID     any    `json:"id"`
+				Method string `json:"method"`
+			}
+			Expect(json.NewDecoder(r.Body).Decode(&request)).To(Succeed())
+			requests = append(requests, fmt.Sprintf("%s %s %s", request.Method, r.Header.Get("Authorization"), r.Header.Get("X-Team")))
+			state := "working"
+			if request.Method == "tasks/cancel" {
+				state = "canceled"
+			}
+			id, _ := json.Marshal(request.ID)
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":"%s"}}}`, id, state)
+		}))
+	})
+
+	AfterEach(func() {
+		a2aServer.Close()
+	})
+
+	It("polls and cancels tasks with the headers the overrides of their agent and query set", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		Expect(arkv1prealpha1.AddToScheme(scheme)).To(Succeed())
+		header := func(name string, value arkv1alpha1.HeaderValue) []arkv1alpha1.Header {
+			return []arkv1alpha1.Header{{Name: name, Value: value}}
+		}
+		agent := &arkv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-agent", Namespace: "default"},
+			Spec: arkv1alpha1.AgentSpec{Overrides: []arkv1alpha1.Override{{
+				ResourceType: "a2aserver",
+				Headers:      header("X-Team", arkv1alpha1.HeaderValue{Value: "weather"}),
+			}}},
+		}
+		query := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-query", Namespace: "default"},
+			Spec: arkv1alpha1.QuerySpec{
+				Parameters: []arkv1alpha1.Parameter{{Name: "token", Value: "Bearer end-user"}},
+				Overrides: []arkv1alpha1.Override{{
+					ResourceType: "a2aserver",
+					Headers: header("Authorization", arkv1alpha1.HeaderValue{ValueFrom: &arkv1alpha1.HeaderValueSource{
+						QueryParameterRef: &arkv1alpha1.QueryParameterReference{Name: "token"},
+					}}),
+				}},
+			},
+		}
+		server := &arkv1prealpha1.A2AServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-server", Namespace: "default"},
+			Status:     arkv1prealpha1.A2AServerStatus{LastResolvedAddress: a2aServer.URL},
+		}
+		a2aTask := &arkv1alpha1.A2ATask{
+			ObjectMeta: metav1.ObjectMeta{Name: "a2a-task-task-1", Namespace: "default", CreationTimestamp: metav1.Now()},
+			Spec: arkv1alpha1.A2ATaskSpec{
+				TaskID:       "task-1",
+				QueryRef:     arkv1alpha1.QueryRef{Name: "weather-query"},
+				AgentRef:     arkv1alpha1.AgentRef{Name: "weather-agent"},
+				A2AServerRef: arkv1alpha1.A2AServerRef{Name: "weather-server"},
+			},
+			Status: arkv1alpha1.A2ATaskStatus{
+				Phase: genai.PhaseRunning,
+				Conditions: []metav1.Condition{{
+					Type: string(arkv1alpha1.A2ATaskCompleted), Status: metav1.ConditionFalse, Reason: "TaskRunning", Message: "Task is running",
+				}},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&arkv1alpha1.A2ATask{}).WithObjects(agent, query, server, a2aTask).Build()
+		reconciler := &A2ATaskReconciler{Client: fakeClient, Scheme: scheme, Eventing: eventnoop.NewProvider()}
+		key := types.NamespacedName{Name: a2aTask.Name, Namespace: a2aTask.Namespace}
+
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(query), query)).To(Succeed())
+		query.Spec.Cancel = true
+		Expect(fakeClient.Update(ctx, query)).To(Succeed())
+		_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(requests).To(Equal([]string{"tasks/get Bearer end-user weather", "tasks/cancel Bearer end-user weather"}))
+	})
+})
@@ -116,13 +116,33 @@ func (r *AgentReconciler) checkDependencies(ctx context.Context, agent *arkv1alp
 	return true, "Available", "All dependencies are available"
 }
 
-// checkModelDependency validates model dependency
+// checkModelDependency validates model dependency. The agent can run as long as its model
+// or one of its fallback models is available.
 func (r *AgentReconciler) checkModelDependency(ctx context.Context, agent *arkv1alpha1.Agent) (bool, string) {
-	modelName := agent.Spec.ModelRef.Name
+	ok, msg := r.checkModelRef(ctx, agent, agent.Spec.ModelRef)
+	if ok {
+		return true, ""
+	}
+
+	for i := range agent.Spec.FallbackModels {
+		if fallbackOK, _ := r.checkModelRef(ctx, agent, &agent.Spec.FallbackModels[i]); fallbackOK {
+			return true, ""
+		}
+	}
+
+	if len(agent.Spec.FallbackModels) > 0 {
+		msg = fmt.Sprintf("%s and no fallback model is available", msg)
+	}
+	return false, msg
+}
+
+// checkModelRef validates a single model reference of an agent
+func (r *AgentReconciler) checkModelRef(ctx context.Context, agent *arkv1alpha1.Agent, modelRef *arkv1alpha1.AgentModelRef) (bool, string) {
+	modelName := modelRef.Name
 	modelNamespace := agent.Namespace
 
-	if agent.Spec.ModelRef.Namespace != "" {
-		modelNamespace = agent.Spec.ModelRef.Namespace
+	if modelRef.Namespace != "" {
+		modelNamespace = modelRef.Namespace
 	}
 
 	var model arkv1alpha1.Model
@@ -345,9 +365,17 @@ func (r *AgentReconciler) agentDependsOnTool(agent *arkv1alpha1.Agent, toolName
 	return false
 }
 
-// agentDependsOnModel checks if an agent depends on a specific model
+// agentDependsOnModel checks if an agent depends on a specific model, either as its model or as a fallback
 func (r *AgentReconciler) agentDependsOnModel(agent *arkv1alpha1.Agent, modelName string) bool {
-	return agent.Spec.ModelRef != nil && agent.Spec.ModelRef.Name == modelName
+	if agent.Spec.ModelRef != nil && agent.Spec.ModelRef.Name == modelName {
+		return true
+	}
+	for _, fallback := range agent.Spec.FallbackModels {
+		if fallback.Name == modelName {
+			return true
+		}
+	}
+	return false
 }
 
 // findAgentsForA2AServer finds agents owned by the given A2AServer
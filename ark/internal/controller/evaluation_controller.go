@@ -557,7 +557,7 @@ func (r *EvaluationReconciler) fetchQuery(ctx context.Context, evaluation arkv1a
 	}
 
 	// Validate query is complete
-	if query.Status.Phase != "done" {
+	if query.Status.Phase != arkv1alpha1.QueryPhaseDone {
 		return nil, fmt.Errorf("query '%s' is not complete (phase: %s)", query.Name, query.Status.Phase)
 	}
 
@@ -716,7 +716,7 @@ var _ = Describe("Evaluation Controller", func() {
 
 			Expect(k8sClient.Create(ctx, query)).Should(Succeed())
 
-			query.Status.Phase = statusDone
+			query.Status.Phase = arkv1alpha1.QueryPhaseDone
 			Expect(k8sClient.Status().Update(ctx, query)).Should(Succeed())
 
 			evaluation := &arkv1alpha1.Evaluation{
@@ -321,7 +321,7 @@ func (r *EvaluatorReconciler) processEvaluatorWithSelector(ctx context.Context,
 
 	// Process each matching query
 	for _, query := range matchingQueries {
-		if query.Status.Phase == statusDone {
+		if query.Status.Phase == arkv1alpha1.QueryPhaseDone {
 			if err := r.createEvaluationForQuery(ctx, evaluator, &query); err != nil {
 				log.Error(err, "Failed to create evaluation", "evaluator", evaluator.Name, "query", query.Name)
 				continue
@@ -437,7 +437,7 @@ func (r *EvaluatorReconciler) shouldRetriggerEvaluation(evaluation *arkv1alpha1.
 	currentPhase := query.Status.Phase
 	lastPhase := evaluation.Annotations[annotations.QueryPhase]
 
-	return currentPhase == "done" && currentPhase != lastPhase
+	return currentPhase == arkv1alpha1.QueryPhaseDone && currentPhase != lastPhase
 }
 
 // updateEvaluationForQuery updates an existing evaluation to retrigger evaluation
@@ -371,6 +371,7 @@ func (r *MCPServerReconciler) buildToolCRD(mcpServer *arkv1alpha1.MCPServer, mcp
 			Type:        "mcp",
 			Description: mcpTool.Description,
 			InputSchema: r.convertInputSchemaToRawExtension(mcpTool.InputSchema),
+			Annotations: r.convertToolAnnotations(mcpTool.Annotations),
 			MCP: &arkv1alpha1.MCPToolRef{
 				MCPServerRef: arkv1alpha1.MCPServerRef{
 					Name:      mcpServer.Name,
@@ -439,6 +440,20 @@ func (r *MCPServerReconciler) convertInputSchemaToRawExtension(schema any) *runt
 	return &runtime.RawExtension{Raw: bytes}
 }
 
+// convertToolAnnotations copies the MCP tool hints, applying the MCP defaults for unset pointer hints
+func (r *MCPServerReconciler) convertToolAnnotations(annotations *mcp.ToolAnnotations) *arkv1alpha1.ToolAnnotations {
+	if annotations == nil {
+		return nil
+	}
+	return &arkv1alpha1.ToolAnnotations{
+		DestructiveHint: annotations.DestructiveHint == nil || *annotations.DestructiveHint,
+		IdempotentHint:  annotations.IdempotentHint,
+		OpenWorldHint:   annotations.OpenWorldHint == nil || *annotations.OpenWorldHint,
+		ReadOnlyHint:    annotations.ReadOnlyHint,
+		Title:           annotations.Title,
+	}
+}
+
 func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&arkv1alpha1.MCPServer{}).
@@ -0,0 +1,79 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+const reasonQueryAwaitingApproval = "QueryAwaitingApproval"
+
+// RequestApproval lists the tool call in status.pendingToolCalls until a decision is recorded
+// in spec.toolApprovals or the call expires. Decisions answer the request ID generated here
+// rather than the call ID chosen by the model, which other calls of the query may reuse. The
+// wait never outlasts the execution, so an expiry is reported before the query times out.
+func (w *queryWaiter) RequestApproval(ctx context.Context, request genai.ToolApprovalRequest) (genai.ToolApprovalDecision, error) {
+	log := logf.FromContext(ctx)
+
+	timeout := request.Timeout
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+	now := metav1.Now()
+	expiresAt := metav1.NewTime(now.Add(timeout))
+	pending := arkv1alpha1.PendingToolCall{
+		ID:          string(uuid.NewUUID()),
+		ToolCallID:  request.Call.ID,
+		Agent:       request.Agent,
+		Tool:        request.Call.Function.Name,
+		Arguments:   request.Call.Function.Arguments,
+		RequestedAt: &now,
+		ExpiresAt:   &expiresAt,
+	}
+	message := fmt.Sprintf("Tool call %s to %s is awaiting approval", pending.ToolCallID, pending.Tool)
+	if err := w.pause(ctx, reasonQueryAwaitingApproval, message, func(status *arkv1alpha1.QueryStatus) {
+		status.PendingToolCalls = append(status.PendingToolCalls, pending)
+	}); err != nil {
+		return genai.ToolApprovalDecision{}, fmt.Errorf("failed to request approval for tool call %s: %w", pending.ToolCallID, err)
+	}
+	log.Info("tool call awaiting approval", "query", w.key.String(), "request", pending.ID, "toolCall", pending.ToolCallID, "tool", pending.Tool)
+	defer w.resume(ctx, func(status *arkv1alpha1.QueryStatus) {
+		status.PendingToolCalls = slices.DeleteFunc(status.PendingToolCalls, func(call arkv1alpha1.PendingToolCall) bool {
+			return call.ID == pending.ID
+		})
+	})
+
+	var approval arkv1alpha1.ToolApproval
+	decided, err := w.waitFor(ctx, timeout, func(query *arkv1alpha1.Query) bool {
+		index := slices.IndexFunc(query.Spec.ToolApprovals, func(approval arkv1alpha1.ToolApproval) bool {
+			return approval.RequestID == pending.ID
+		})
+		if index < 0 {
+			return false
+		}
+		approval = query.Spec.ToolApprovals[index]
+		return true
+	})
+	if err != nil {
+		return genai.ToolApprovalDecision{}, err
+	}
+	if !decided {
+		return genai.ToolApprovalDecision{Reason: fmt.Sprintf("no decision was made within %s", timeout.Round(time.Second))}, nil
+	}
+
+	log.Info("tool call decided", "query", w.key.String(), "request", pending.ID, "toolCall", pending.ToolCallID, "decision", approval.Decision)
+	return genai.ToolApprovalDecision{
+		Approved: approval.Decision == arkv1alpha1.ToolApprovalApproved,
+		Reason:   approval.Reason,
+	}, nil
+}
@@ -0,0 +1,74 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/openai/openai-go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("Query tool approvals", func() {
+	It("matches decisions on the generated request ID rather than the model's call ID", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		// A decision for an earlier call the model also named call_1
+		stored := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "ops-query", Namespace: "default"},
+			Spec: arkv1alpha1.QuerySpec{ToolApprovals: []arkv1alpha1.ToolApproval{
+				{RequestID: "call_1", Decision: arkv1alpha1.ToolApprovalApproved},
+			}},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&arkv1alpha1.Query{}).WithObjects(stored).Build()
+
+		query := &arkv1alpha1.Query{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(stored), query)).To(Succeed())
+		waiter := newQueryWaiter(&QueryReconciler{Client: fakeClient}, query)
+		waiter.pollInterval = 10 * time.Millisecond
+
+		decisions := make(chan genai.ToolApprovalDecision, 1)
+		go func() {
+			defer GinkgoRecover()
+			decision, err := waiter.RequestApproval(ctx, genai.ToolApprovalRequest{
+				Agent:   "ops-agent",
+				Call:    genai.ToolCall{ID: "call_1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "delete-pod"}},
+				Timeout: time.Minute,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			decisions <- decision
+		}()
+
+		var pending arkv1alpha1.PendingToolCall
+		Eventually(func(g Gomega) {
+			latest := &arkv1alpha1.Query{}
+			g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(stored), latest)).To(Succeed())
+			g.Expect(latest.Status.PendingToolCalls).To(HaveLen(1))
+			pending = latest.Status.PendingToolCalls[0]
+		}).Should(Succeed())
+		Expect(pending.ToolCallID).To(Equal("call_1"))
+		Expect(pending.ID).NotTo(Equal("call_1"))
+		Consistently(decisions, 100*time.Millisecond).ShouldNot(Receive())
+
+		latest := &arkv1alpha1.Query{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(stored), latest)).To(Succeed())
+		latest.Spec.ToolApprovals = append(latest.Spec.ToolApprovals,
+			arkv1alpha1.ToolApproval{RequestID: pending.ID, Decision: arkv1alpha1.ToolApprovalRejected, Reason: "not now"})
+		Expect(fakeClient.Update(ctx, latest)).To(Succeed())
+
+		var decision genai.ToolApprovalDecision
+		Eventually(decisions).Should(Receive(&decision))
+		Expect(decision.Approved).To(BeFalse())
+		Expect(decision.Reason).To(Equal("not now"))
+	})
+})
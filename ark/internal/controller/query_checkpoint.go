@@ -0,0 +1,230 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/retry"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+const (
+	// maxQueryExecutionAttempts bounds how often an interrupted query is resumed, so that a
+	// query which repeatedly brings the controller down is failed instead of retried forever.
+	maxQueryExecutionAttempts = 3
+
+	// maxCheckpointMessagesSize bounds the serialized message history kept in the query status.
+	// Objects in etcd are limited to 1.5 MiB, and a status that outgrows it fails every write.
+	maxCheckpointMessagesSize = 512 * 1024
+
+	reasonQueryResumed  = "QueryResumed"
+	reasonQueryOrphaned = "QueryOrphaned"
+)
+
+var (
+	executorIDOnce sync.Once
+	executorID     string
+)
+
+// currentExecutorID returns an identity that is unique to this controller process. Queries
+// record it when execution starts, so a running query whose executor differs from the
+// current process was interrupted by a restart or leader failover.
+func currentExecutorID() string {
+	executorIDOnce.Do(func() {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = "ark-controller"
+		}
+		executorID = fmt.Sprintf("%s_%s", hostname, uuid.NewUUID())
+	})
+	return executorID
+}
+
+// isInterrupted reports whether a running query was started by an execution that is no
+// longer tracked by this process.
+func isInterrupted(query *arkv1alpha1.Query) bool {
+	return query.Status.Checkpoint != nil && query.Status.Checkpoint.ExecutorID != ""
+}
+
+// canResume reports whether an interrupted query should be resumed rather than failed
+func canResume(query *arkv1alpha1.Query) (bool, string) {
+	if query.Spec.RecoveryPolicy == arkv1alpha1.QueryRecoveryPolicyFail {
+		return false, "Query execution was interrupted by a controller restart and recoveryPolicy is fail"
+	}
+	if query.Status.Checkpoint.Attempt >= maxQueryExecutionAttempts {
+		return false, fmt.Sprintf("Query execution was interrupted %d times and will not be resumed again", query.Status.Checkpoint.Attempt)
+	}
+	return true, ""
+}
+
+// claimQuery records this process as the executor of the query and increments the attempt
+// counter. The status update relies on the cached resourceVersion, so a stale cache or a
+// competing controller instance results in a conflict instead of a duplicate execution.
+func (r *QueryReconciler) claimQuery(ctx context.Context, query *arkv1alpha1.Query) error {
+	checkpoint := query.Status.Checkpoint
+	if checkpoint == nil {
+		checkpoint = &arkv1alpha1.QueryCheckpoint{}
+		query.Status.Checkpoint = checkpoint
+	}
+
+	previousExecutor := checkpoint.ExecutorID
+	checkpoint.ExecutorID = currentExecutorID()
+	checkpoint.Attempt++
+	now := metav1.Now()
+	checkpoint.LastCheckpointTime = &now
+	// Calls waiting for approval and questions waiting for answers are asked again by the
+	// resumed execution, which runs until then
+	query.Status.PendingToolCalls = nil
+	query.Status.PendingInputs = nil
+	query.Status.Phase = waitingPhase(&query.Status)
+
+	if previousExecutor != "" {
+		message := fmt.Sprintf("Resuming query interrupted on %s (attempt %d of %d)", previousExecutor, checkpoint.Attempt, maxQueryExecutionAttempts)
+		r.setConditionCompleted(query, metav1.ConditionFalse, reasonQueryResumed, message)
+		r.Eventing.QueryRecorder().QueryResumed(ctx, query, message)
+	}
+
+	return r.Status().Update(ctx, query)
+}
+
+// failInterruptedQuery fails a query that was interrupted and cannot be resumed
+func (r *QueryReconciler) failInterruptedQuery(ctx context.Context, query *arkv1alpha1.Query, message string) error {
+	var target arkv1alpha1.QueryTarget
+	if query.Spec.Target != nil {
+		target = *query.Spec.Target
+	}
+	response := r.createErrorResponse(target, fmt.Errorf("%s", message))
+	query.Status.Response = &response
+	query.Status.Phase = arkv1alpha1.QueryPhaseError
+	r.setConditionCompleted(query, metav1.ConditionTrue, reasonQueryOrphaned, message)
+	r.Eventing.QueryRecorder().QueryOrphaned(ctx, query, message)
+
+	return r.Status().Update(ctx, query)
+}
+
+// queryCheckpointer persists the progress reported by the query target into the query status
+type queryCheckpointer struct {
+	reconciler *QueryReconciler
+	query      *arkv1alpha1.Query
+	resume     genai.ExecutionProgress
+	priorUsage arkv1alpha1.TokenUsage
+}
+
+func newQueryCheckpointer(ctx context.Context, r *QueryReconciler, query *arkv1alpha1.Query, priorUsage arkv1alpha1.TokenUsage) *queryCheckpointer {
+	checkpointer := &queryCheckpointer{
+		reconciler: r,
+		query:      query,
+		priorUsage: priorUsage,
+	}
+
+	checkpoint := query.Status.Checkpoint
+	if checkpoint == nil || checkpoint.Messages == "" {
+		return checkpointer
+	}
+
+	messages, err := genai.UnmarshalMessages(checkpoint.Messages)
+	if err != nil {
+		// An unreadable checkpoint only loses progress; the query restarts from the beginning.
+		logf.FromContext(ctx).Error(err, "failed to restore query checkpoint, restarting execution", "query", query.Name)
+		return checkpointer
+	}
+	checkpointer.resume = genai.ExecutionProgress{
+		Messages:   messages,
+		Turns:      int(checkpoint.CompletedTurns),
+		NextMember: checkpoint.NextMember,
+		Tokens:     checkpoint.CompletedTokens,
+	}
+	return checkpointer
+}
+
+func (c *queryCheckpointer) Resume() genai.ExecutionProgress {
+	return c.resume
+}
+
+// Checkpoint stores the completed messages and the tokens used so far. Token usage is read
+// from the context of the caller, which accumulates the usage of the current attempt.
+func (c *queryCheckpointer) Checkpoint(ctx context.Context, progress genai.ExecutionProgress) {
+	if ctx.Err() != nil {
+		return
+	}
+	log := logf.FromContext(ctx)
+
+	raw, err := genai.MarshalMessages(progress.Messages)
+	if err != nil {
+		log.Error(err, "failed to serialize query checkpoint", "query", c.query.Name)
+		return
+	}
+
+	toolCalls := int32(0)
+	for _, msg := range progress.Messages {
+		if msg.OfTool != nil {
+			toolCalls++
+		}
+	}
+
+	checkpoint := c.query.Status.Checkpoint
+	if checkpoint == nil {
+		checkpoint = &arkv1alpha1.QueryCheckpoint{ExecutorID: currentExecutorID()}
+		c.query.Status.Checkpoint = checkpoint
+	}
+	checkpoint.CompletedTurns = int32(progress.Turns)
+	checkpoint.CompletedToolCalls = toolCalls
+	checkpoint.CompletedTokens = progress.Tokens
+	if len(raw) > maxCheckpointMessagesSize {
+		// Without messages a resumed query starts over, which is better than losing every
+		// later status write
+		log.Info("message history is too large to checkpoint, a resumed query restarts from the beginning",
+			"query", c.query.Name, "size", len(raw), "limit", maxCheckpointMessagesSize)
+		raw = ""
+	}
+	checkpoint.Messages = raw
+	checkpoint.NextMember = progress.NextMember
+	now := metav1.Now()
+	checkpoint.LastCheckpointTime = &now
+
+	c.query.Status.TokenUsage = addTokenUsage(c.priorUsage, c.reconciler.Eventing.QueryRecorder().GetTokenSummary(ctx))
+	if tracker := genai.GetBudgetTracker(ctx); tracker != nil {
+		c.query.Status.Cost = tracker.Cost()
+	}
+	if err := c.updateStatus(ctx); err != nil {
+		log.Error(err, "failed to record query checkpoint", "query", c.query.Name)
+	}
+}
+
+// updateStatus writes the query status, picking up the latest resourceVersion and spec when
+// an approval, an answer or a cancellation changed the query since it was read
+func (c *queryCheckpointer) updateStatus(ctx context.Context) error {
+	key := types.NamespacedName{Name: c.query.Name, Namespace: c.query.Namespace}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := c.reconciler.Status().Update(ctx, c.query)
+		if err == nil || !apierrors.IsConflict(err) {
+			return err
+		}
+		var latest arkv1alpha1.Query
+		if getErr := c.reconciler.Get(ctx, key, &latest); getErr != nil {
+			return getErr
+		}
+		c.query.ResourceVersion = latest.ResourceVersion
+		c.query.Spec = latest.Spec
+		return err
+	})
+}
+
+func addTokenUsage(a, b arkv1alpha1.TokenUsage) arkv1alpha1.TokenUsage {
+	return arkv1alpha1.TokenUsage{
+		PromptTokens:     a.PromptTokens + b.PromptTokens,
+		CompletionTokens: a.CompletionTokens + b.CompletionTokens,
+		TotalTokens:      a.TotalTokens + b.TotalTokens,
+	}
+}
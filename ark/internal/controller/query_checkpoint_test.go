@@ -0,0 +1,128 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventingconfig "mckinsey.com/ark/internal/eventing/config"
+)
+
+// eventRecorderManager provides the event recorder the eventing provider needs from a manager
+type eventRecorderManager struct {
+	ctrl.Manager
+}
+
+func (eventRecorderManager) GetEventRecorderFor(string) record.EventRecorder {
+	return record.NewFakeRecorder(10)
+}
+
+var _ = Describe("Query checkpoints", func() {
+	It("treats only queries with a recorded executor as interrupted", func() {
+		query := &arkv1alpha1.Query{}
+		Expect(isInterrupted(query)).To(BeFalse())
+
+		query.Status.Checkpoint = &arkv1alpha1.QueryCheckpoint{}
+		Expect(isInterrupted(query)).To(BeFalse())
+
+		query.Status.Checkpoint.ExecutorID = "previous-controller"
+		Expect(isInterrupted(query)).To(BeTrue())
+	})
+
+	It("resumes interrupted queries until the attempt limit is reached", func() {
+		query := &arkv1alpha1.Query{
+			Status: arkv1alpha1.QueryStatus{
+				Checkpoint: &arkv1alpha1.QueryCheckpoint{ExecutorID: "previous-controller", Attempt: 1},
+			},
+		}
+		resume, _ := canResume(query)
+		Expect(resume).To(BeTrue())
+
+		query.Status.Checkpoint.Attempt = maxQueryExecutionAttempts
+		resume, message := canResume(query)
+		Expect(resume).To(BeFalse())
+		Expect(message).To(ContainSubstring("will not be resumed"))
+	})
+
+	It("fails interrupted queries when the recovery policy is fail", func() {
+		query := &arkv1alpha1.Query{
+			Spec: arkv1alpha1.QuerySpec{RecoveryPolicy: arkv1alpha1.QueryRecoveryPolicyFail},
+			Status: arkv1alpha1.QueryStatus{
+				Checkpoint: &arkv1alpha1.QueryCheckpoint{ExecutorID: "previous-controller", Attempt: 1},
+			},
+		}
+		resume, message := canResume(query)
+		Expect(resume).To(BeFalse())
+		Expect(message).To(ContainSubstring("recoveryPolicy"))
+	})
+
+	It("runs queries resumed while awaiting approval until the call is asked again", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		requestedAt := metav1.Now()
+		stored := &arkv1alpha1.Query{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-query", Namespace: "default"},
+			Status: arkv1alpha1.QueryStatus{
+				Phase:            arkv1alpha1.QueryPhaseAwaitingApproval,
+				PendingToolCalls: []arkv1alpha1.PendingToolCall{{ID: "req-1", Tool: "delete-pod", RequestedAt: &requestedAt}},
+				Checkpoint:       &arkv1alpha1.QueryCheckpoint{ExecutorID: "previous-controller", Attempt: 1},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&arkv1alpha1.Query{}).WithObjects(stored).Build()
+		reconciler := &QueryReconciler{Client: fakeClient, Eventing: eventingconfig.NewProvider(eventRecorderManager{}, nil)}
+
+		query := &arkv1alpha1.Query{}
+		key := types.NamespacedName{Name: "weather-query", Namespace: "default"}
+		Expect(fakeClient.Get(ctx, key, query)).To(Succeed())
+		Expect(reconciler.claimQuery(ctx, query)).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, key, query)).To(Succeed())
+		Expect(query.Status.Phase).To(Equal(arkv1alpha1.QueryPhaseRunning))
+		Expect(query.Status.PendingToolCalls).To(BeEmpty())
+		Expect(query.Status.Checkpoint.Attempt).To(Equal(int32(2)))
+	})
+
+	It("accumulates token usage across attempts", func() {
+		total := addTokenUsage(
+			arkv1alpha1.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+			arkv1alpha1.TokenUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+		)
+		Expect(total).To(Equal(arkv1alpha1.TokenUsage{PromptTokens: 13, CompletionTokens: 7, TotalTokens: 20}))
+	})
+
+	It("records checkpoints over a stale resourceVersion", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		stored := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "weather-query", Namespace: "default"}}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&arkv1alpha1.Query{}).WithObjects(stored).Build()
+
+		query := &arkv1alpha1.Query{}
+		key := types.NamespacedName{Name: "weather-query", Namespace: "default"}
+		Expect(fakeClient.Get(ctx, key, query)).To(Succeed())
+
+		// An approval lands after the executing controller read the query
+		latest := query.DeepCopy()
+		latest.Spec.ToolApprovals = []arkv1alpha1.ToolApproval{{RequestID: "req-1", Decision: arkv1alpha1.ToolApprovalApproved}}
+		Expect(fakeClient.Update(ctx, latest)).To(Succeed())
+
+		checkpointer := &queryCheckpointer{reconciler: &QueryReconciler{Client: fakeClient}, query: query}
+		query.Status.Checkpoint = &arkv1alpha1.QueryCheckpoint{CompletedTurns: 2}
+		Expect(checkpointer.updateStatus(ctx)).To(Succeed())
+
+		Expect(fakeClient.Get(ctx, key, latest)).To(Succeed())
+		Expect(latest.Status.Checkpoint.CompletedTurns).To(Equal(int32(2)))
+		Expect(latest.Spec.ToolApprovals).To(HaveLen(1))
+	})
+})
@@ -44,15 +44,25 @@ const (
 // - Never import OTEL packages directly - use the abstraction layer
 type QueryReconciler struct {
 	client.Client
-	Scheme     *runtime.Scheme
-	Telemetry  *telemetryconfig.Provider
-	Eventing   *eventingconfig.Provider
-	operations sync.Map
+	Scheme    *runtime.Scheme
+	Telemetry *telemetryconfig.Provider
+	Eventing  *eventingconfig.Provider
+	// ToolCallbacks lets execution engines run agent tools through Ark, nil when disabled
+	ToolCallbacks *genai.ToolCallbackServer
+	// A2APushNotifications receives the task updates of A2A agents, nil when disabled
+	A2APushNotifications *genai.A2APushNotificationServer
+	operations           sync.Map
+	// admissionLocks serializes quota admission per namespace, and admitted holds the queries
+	// admitted by a quota that are not yet tracked in operations
+	admissionLocks sync.Map
+	admitted       sync.Map
 }
 
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queryquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queryquotas/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agents,verbs=get;list
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=teams,verbs=get;list
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=models,verbs=get;list
@@ -118,9 +128,11 @@ func (r *QueryReconciler) handleFinalizer(ctx context.Context, obj *arkv1alpha1.
 func (r *QueryReconciler) handleQueryExecution(ctx context.Context, req ctrl.Request, obj arkv1alpha1.Query) (ctrl.Result, error) {
 	expiry := obj.CreationTimestamp.Add(obj.Spec.TTL.Duration)
 
-	if obj.Spec.Cancel && obj.Status.Phase != statusCanceled {
+	if obj.Spec.Cancel && obj.Status.Phase != arkv1alpha1.QueryPhaseCanceled {
 		r.cleanupExistingOperation(req.NamespacedName)
-		if err := r.updateStatus(ctx, &obj, statusCanceled); err != nil {
+		obj.Status.PendingToolCalls = nil
+		obj.Status.PendingInputs = nil
+		if err := r.updateStatus(ctx, &obj, arkv1alpha1.QueryPhaseCanceled); err != nil {
 			return ctrl.Result{
 				RequeueAfter: time.Until(expiry),
 			}, err
@@ -129,14 +141,22 @@ func (r *QueryReconciler) handleQueryExecution(ctx context.Context, req ctrl.Req
 	}
 
 	switch obj.Status.Phase {
-	case statusDone, statusError, statusCanceled:
+	case arkv1alpha1.QueryPhaseDone, arkv1alpha1.QueryPhaseError, arkv1alpha1.QueryPhaseCanceled, arkv1alpha1.QueryPhaseBudgetExceeded:
 		return ctrl.Result{
 			RequeueAfter: time.Until(expiry),
 		}, nil
-	case statusRunning:
+	case arkv1alpha1.QueryPhaseRunning, arkv1alpha1.QueryPhaseAwaitingApproval, arkv1alpha1.QueryPhaseInputRequired:
 		return r.handleRunningPhase(ctx, req, obj)
 	default:
-		if err := r.updateStatus(ctx, &obj, statusRunning); err != nil {
+		admitted, err := r.admitQuery(ctx, &obj)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !admitted {
+			return ctrl.Result{RequeueAfter: queuedQueryRetryInterval}, nil
+		}
+		if err := r.updateStatus(ctx, &obj, arkv1alpha1.QueryPhaseRunning); err != nil {
+			r.admitted.Delete(req.NamespacedName)
 			return ctrl.Result{
 				RequeueAfter: time.Until(expiry),
 			}, err
@@ -153,8 +173,23 @@ func (r *QueryReconciler) handleRunningPhase(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, nil
 	}
 
+	// The query is running but not tracked by this process: its executor was restarted
+	// or lost leadership, so resume it from the last checkpoint or fail it.
+	if isInterrupted(&obj) {
+		if resume, message := canResume(&obj); !resume {
+			log.Info("failing interrupted query", "query", req.NamespacedName.String(), "reason", message)
+			return ctrl.Result{}, r.failInterruptedQuery(ctx, &obj, message)
+		}
+		log.Info("resuming interrupted query", "query", req.NamespacedName.String(), "executor", obj.Status.Checkpoint.ExecutorID)
+	}
+
+	if err := r.claimQuery(ctx, &obj); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	opCtx, cancel := context.WithCancel(ctx)
 	r.operations.Store(req.NamespacedName, cancel)
+	r.admitted.Delete(req.NamespacedName)
 
 	go r.executeQueryAsync(opCtx, obj, req.NamespacedName)
 	return ctrl.Result{}, nil
@@ -164,6 +199,7 @@ func (r *QueryReconciler) executeQueryAsync(opCtx context.Context, obj arkv1alph
 	log := logf.FromContext(opCtx)
 	cleanupCache := true
 	startTime := time.Now()
+	priorUsage := obj.Status.TokenUsage
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -207,7 +243,43 @@ func (r *QueryReconciler) executeQueryAsync(opCtx context.Context, obj arkv1alph
 
 	opCtx = r.Eventing.QueryRecorder().InitializeQueryContext(opCtx, &obj)
 	opCtx = r.Eventing.QueryRecorder().StartTokenCollection(opCtx)
+	// Quotas are charged before the final phase is written: from then on admission no longer
+	// counts the query as running, so its tokens must already be in the quota. The deferred
+	// call charges queries that were cancelled or deleted, whose phase is written elsewhere.
+	charged := false
+	chargeQuotas := func() {
+		if charged {
+			return
+		}
+		charged = true
+		r.chargeQueryQuotas(opCtx, obj.Namespace, addTokenUsage(priorUsage, r.Eventing.QueryRecorder().GetTokenSummary(opCtx)).TotalTokens)
+	}
+	defer chargeQuotas()
 	opCtx = r.Eventing.QueryRecorder().Start(opCtx, "QueryExecution", fmt.Sprintf("Executing query %s", obj.Name), nil)
+	opCtx = genai.WithCheckpointer(opCtx, newQueryCheckpointer(opCtx, r, &obj, priorUsage))
+
+	// The target runs in its own context so that crossing the budget stops the execution
+	// while the final status can still be written with opCtx.
+	execCtx, cancelExec := context.WithCancelCause(opCtx)
+	defer cancelExec(nil)
+	budgetTracker, err := genai.NewBudgetTracker(obj.Spec.Budget, priorUsage, obj.Status.Cost, cancelExec)
+	if err != nil {
+		r.Telemetry.QueryRecorder().RecordError(span, err)
+		r.Eventing.QueryRecorder().Fail(opCtx, "QueryExecution", fmt.Sprintf("Query execution failed: %v", err), err, nil)
+		chargeQuotas()
+		_ = r.updateStatus(opCtx, &obj, arkv1alpha1.QueryPhaseError)
+		return
+	}
+	execCtx = genai.WithBudgetTracker(execCtx, budgetTracker)
+	if r.ToolCallbacks != nil {
+		execCtx = genai.WithToolCallbackServer(execCtx, r.ToolCallbacks)
+	}
+	if r.A2APushNotifications != nil {
+		execCtx = genai.WithA2APushNotificationServer(execCtx, r.A2APushNotifications)
+	}
+	waiter := newQueryWaiter(r, &obj)
+	execCtx = genai.WithToolApprover(execCtx, waiter)
+	execCtx = genai.WithInputRequester(execCtx, waiter)
 
 	inputMessages, err := genai.GetQueryInputMessages(opCtx, obj, impersonatedClient)
 	if err == nil {
@@ -215,29 +287,45 @@ func (r *QueryReconciler) executeQueryAsync(opCtx context.Context, obj arkv1alph
 		r.Telemetry.QueryRecorder().RecordRootInput(span, queryInput)
 	}
 
-	response, eventStream, err := r.reconcileQueue(opCtx, obj, impersonatedClient, memory)
+	response, eventStream, err := r.reconcileQueue(execCtx, obj, impersonatedClient, memory)
 	if err != nil {
 		genai.StreamError(opCtx, eventStream, err, "query_execution_failed", "query")
 		r.Telemetry.QueryRecorder().RecordError(span, err)
 		r.Eventing.QueryRecorder().Fail(opCtx, "QueryExecution", fmt.Sprintf("Query execution failed: %v", err), err, nil)
-		_ = r.updateStatus(opCtx, &obj, statusError)
+		chargeQuotas()
+		_ = r.updateStatus(opCtx, &obj, arkv1alpha1.QueryPhaseError)
 		return
 	}
 
+	queryStatus := r.determineQueryStatus(response)
+	if exceeded := budgetTracker.Exceeded(); exceeded != nil {
+		var target arkv1alpha1.QueryTarget
+		if response != nil {
+			target = response.Target
+		}
+		errResponse := r.createErrorResponse(target, exceeded)
+		response = &errResponse
+		queryStatus = arkv1alpha1.QueryPhaseBudgetExceeded
+	}
 	obj.Status.Response = response
+	obj.Status.Cost = budgetTracker.Cost()
 
-	if response != nil && response.Phase == statusDone {
+	if response != nil && response.Phase == arkv1alpha1.QueryPhaseDone {
 		r.Telemetry.QueryRecorder().RecordRootOutput(span, response.Content)
 	}
 
-	tokenSummary := r.Eventing.QueryRecorder().GetTokenSummary(opCtx)
+	tokenSummary := addTokenUsage(priorUsage, r.Eventing.QueryRecorder().GetTokenSummary(opCtx))
 	obj.Status.TokenUsage = tokenSummary
+	if obj.Status.Checkpoint != nil {
+		// The response now holds the full conversation, so the checkpointed messages are no longer needed
+		obj.Status.Checkpoint.Messages = ""
+	}
 
 	if tokenSummary.TotalTokens > 0 {
 		r.Telemetry.QueryRecorder().RecordTokenUsage(span, tokenSummary.PromptTokens, tokenSummary.CompletionTokens, tokenSummary.TotalTokens)
 	}
 
-	queryStatus := r.determineQueryStatus(response)
+	chargeQuotas()
 	_ = r.updateStatus(opCtx, &obj, queryStatus)
 
 	duration := &metav1.Duration{Duration: time.Since(startTime)}
@@ -288,13 +376,15 @@ func (r *QueryReconciler) finalizeEventStream(ctx context.Context, eventStream g
 func (r *QueryReconciler) setupQueryExecution(opCtx context.Context, obj arkv1alpha1.Query, conversationId string) (client.Client, genai.MemoryInterface, error) {
 	impersonatedClient, err := r.getClientForQuery(obj)
 	if err != nil {
-		_ = r.updateStatus(opCtx, &obj, statusError)
+		_ = r.updateStatus(opCtx, &obj, arkv1alpha1.QueryPhaseError)
 		return nil, nil, fmt.Errorf("failed to create impersonated client: %w", err)
 	}
 
-	memory, err := genai.NewMemoryForQuery(opCtx, impersonatedClient, obj.Spec.Memory, obj.Namespace, conversationId, obj.Name, r.Eventing.MemoryRecorder())
+	// The memory resolves query parameters and overrides of its headers from the query
+	memoryCtx := context.WithValue(opCtx, genai.QueryContextKey, &obj)
+	memory, err := genai.NewMemoryForQuery(memoryCtx, impersonatedClient, obj.Spec.Memory, obj.Namespace, conversationId, obj.Name, r.Eventing.MemoryRecorder())
 	if err != nil {
-		_ = r.updateStatus(opCtx, &obj, statusError)
+		_ = r.updateStatus(opCtx, &obj, arkv1alpha1.QueryPhaseError)
 		return nil, nil, fmt.Errorf("failed to create memory client: %w", err)
 	}
 
@@ -437,6 +527,10 @@ func (r *QueryReconciler) executeTarget(ctx context.Context, query arkv1alpha1.Q
 	}
 
 	response := r.createSuccessResponse(target, executionResult.Messages)
+	if response.Phase == arkv1alpha1.QueryPhaseDone {
+		response.Model = executionResult.Model
+		response.TerminationReason = executionResult.TerminationReason
+	}
 	if executionResult.A2AResponse != nil {
 		response.A2A = &arkv1alpha1.A2AMetadata{
 			ContextID: executionResult.A2AResponse.ContextID,
@@ -458,7 +552,7 @@ func (r *QueryReconciler) createSuccessResponse(target arkv1alpha1.QueryTarget,
 		Target:  target,
 		Content: messageToText(messages[len(messages)-1]),
 		Raw:     rawJSON,
-		Phase:   statusDone,
+		Phase:   arkv1alpha1.QueryPhaseDone,
 	}
 }
 
@@ -528,18 +622,24 @@ func (r *QueryReconciler) updateStatusWithDuration(ctx context.Context, query *a
 	}
 	query.Status.Phase = status
 	switch status {
-	case statusRunning:
+	case arkv1alpha1.QueryPhaseRunning:
 		r.setConditionCompleted(query, metav1.ConditionFalse, "QueryRunning", "Query is running")
-	case statusDone:
+	case arkv1alpha1.QueryPhaseDone:
 		r.setConditionCompleted(query, metav1.ConditionTrue, "QuerySucceeded", "Query completed successfully")
-	case statusError:
+	case arkv1alpha1.QueryPhaseError:
 		errorMsg := "Query completed with error"
-		if query.Status.Response != nil && query.Status.Response.Phase == statusError && query.Status.Response.Content != "" {
+		if query.Status.Response != nil && query.Status.Response.Phase == arkv1alpha1.QueryPhaseError && query.Status.Response.Content != "" {
 			errorMsg = query.Status.Response.Content
 		}
-		r.setConditionCompleted(query, metav1.ConditionTrue, "QueryErrored", errorMsg)
-	case statusCanceled:
+		r.setConditionCompleted(query, metav1.ConditionTrue, errorConditionReason(query.Status.Response), errorMsg)
+	case arkv1alpha1.QueryPhaseCanceled:
 		r.setConditionCompleted(query, metav1.ConditionTrue, "QueryCanceled", "Query canceled")
+	case arkv1alpha1.QueryPhaseBudgetExceeded:
+		errorMsg := "Query budget exceeded"
+		if query.Status.Response != nil && query.Status.Response.Content != "" {
+			errorMsg = query.Status.Response.Content
+		}
+		r.setConditionCompleted(query, metav1.ConditionTrue, genai.ReasonBudgetExceeded, errorMsg)
 	}
 	if duration != nil {
 		query.Status.Duration = duration
@@ -551,12 +651,27 @@ func (r *QueryReconciler) updateStatusWithDuration(ctx context.Context, query *a
 	return err
 }
 
+// errorConditionReason returns the reason recorded in an error response, so that queries
+// stopped by a limit can be told apart from other failures
+func errorConditionReason(response *arkv1alpha1.Response) string {
+	if response == nil || response.Phase != arkv1alpha1.QueryPhaseError || response.Raw == "" {
+		return "QueryErrored"
+	}
+	var errorMessages []struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(response.Raw), &errorMessages); err != nil || len(errorMessages) == 0 || errorMessages[0].Reason == "" {
+		return "QueryErrored"
+	}
+	return errorMessages[0].Reason
+}
+
 // determineQueryStatus checks if any responses have error phase and returns appropriate query status
 func (r *QueryReconciler) determineQueryStatus(response *arkv1alpha1.Response) string {
-	if response != nil && response.Phase == statusError {
-		return statusError
+	if response != nil && response.Phase == arkv1alpha1.QueryPhaseError {
+		return arkv1alpha1.QueryPhaseError
 	}
-	return statusDone
+	return arkv1alpha1.QueryPhaseDone
 }
 
 // createErrorResponse creates a standardized error response for a failed target
@@ -566,13 +681,16 @@ func (r *QueryReconciler) createErrorResponse(target arkv1alpha1.QueryTarget, er
 		"error":   "target_execution_error",
 		"message": err.Error(),
 	}
+	if reason := genai.GetExecutionLimitReason(err); reason != "" {
+		errorMessage["reason"] = reason
+	}
 	errorRaw, _ := json.Marshal([]map[string]interface{}{errorMessage})
 
 	return arkv1alpha1.Response{
 		Target:  target,
 		Content: err.Error(),
 		Raw:     string(errorRaw),
-		Phase:   statusError,
+		Phase:   arkv1alpha1.QueryPhaseError,
 	}
 }
 
@@ -581,6 +699,7 @@ func (r *QueryReconciler) finalize(ctx context.Context, query *arkv1alpha1.Query
 	log.Info("finalizing query", "name", query.Name, "namespace", query.Namespace)
 
 	nsName := types.NamespacedName{Name: query.Name, Namespace: query.Namespace}
+	r.admitted.Delete(nsName)
 	if cancel, exists := r.operations.Load(nsName); exists {
 		if cancelFunc, ok := cancel.(context.CancelFunc); ok {
 			cancelFunc()
@@ -651,7 +770,7 @@ func (r *QueryReconciler) performTargetExecution(ctx context.Context, query arkv
 	case targetTypeModel:
 		var messages []genai.Message
 		messages, err = r.executeModel(execCtx, query, inputMessages, target.Name, impersonatedClient, memory, eventStream)
-		result = &genai.ExecutionResult{Messages: messages}
+		result = &genai.ExecutionResult{Messages: messages, Model: target.Name}
 	case targetTypeTool:
 		var messages []genai.Message
 		messages, err = r.executeTool(execCtx, query, inputMessages, target.Name, impersonatedClient)
@@ -873,6 +992,13 @@ func (r *QueryReconciler) executeTool(ctx context.Context, crd arkv1alpha1.Query
 	if err != nil {
 		return nil, fmt.Errorf("failed to create tool executor: %w", err)
 	}
+	if httpExecutor, ok := executor.(*genai.HTTPExecutor); ok {
+		toolHeaders, err := genai.ResolveHeadersFromOverrides(ctx, impersonatedClient, crd.Spec.Overrides, crd.Namespace, genai.OverrideTypeTool)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve tool headers from query overrides: %w", err)
+		}
+		httpExecutor.Headers = toolHeaders[toolCRD.Name]
+	}
 	toolRegistry.RegisterTool(toolDefinition, executor)
 
 	// Execute the tool using the same ExecuteTool method agents use
@@ -4,6 +4,7 @@ package controller
 
 import (
 	"context"
+	"fmt"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -235,3 +236,18 @@ var _ = Describe("Query Controller Message Serialization", func() {
 		})
 	})
 })
+
+var _ = Describe("Query Controller Error Reasons", func() {
+	It("should report limit errors with their own condition reason", func() {
+		r := &QueryReconciler{}
+		target := arkv1alpha1.QueryTarget{Type: "agent", Name: "looping-agent"}
+
+		limitErr := &genai.ExecutionLimitError{Reason: genai.ReasonAgentLimitExceeded, Message: "maximum of 5 tool calls reached"}
+		response := r.createErrorResponse(target, fmt.Errorf("agent failed: %w", limitErr))
+		Expect(errorConditionReason(&response)).To(Equal(genai.ReasonAgentLimitExceeded))
+
+		response = r.createErrorResponse(target, fmt.Errorf("model unavailable"))
+		Expect(errorConditionReason(&response)).To(Equal("QueryErrored"))
+		Expect(errorConditionReason(nil)).To(Equal("QueryErrored"))
+	})
+})
@@ -0,0 +1,61 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+const reasonQueryInputRequired = "QueryInputRequired"
+
+// RequestInput lists the question in status.pendingInputs until an answer is recorded in
+// spec.inputResponses. Questions do not expire; the query timeout bounds the wait.
+func (w *queryWaiter) RequestInput(ctx context.Context, request genai.InputRequest) (string, error) {
+	log := logf.FromContext(ctx)
+
+	now := metav1.Now()
+	pending := arkv1alpha1.PendingInput{
+		ID:          string(uuid.NewUUID()),
+		Agent:       request.Agent,
+		TaskID:      request.TaskID,
+		Question:    request.Question,
+		RequestedAt: &now,
+	}
+	if err := w.pause(ctx, reasonQueryInputRequired, request.Question, func(status *arkv1alpha1.QueryStatus) {
+		status.PendingInputs = append(status.PendingInputs, pending)
+	}); err != nil {
+		return "", fmt.Errorf("failed to request input: %w", err)
+	}
+	log.Info("query awaiting input", "query", w.key.String(), "request", pending.ID, "agent", pending.Agent)
+	defer w.resume(ctx, func(status *arkv1alpha1.QueryStatus) {
+		status.PendingInputs = slices.DeleteFunc(status.PendingInputs, func(input arkv1alpha1.PendingInput) bool {
+			return input.ID == pending.ID
+		})
+	})
+
+	var answer string
+	if _, err := w.waitFor(ctx, 0, func(query *arkv1alpha1.Query) bool {
+		index := slices.IndexFunc(query.Spec.InputResponses, func(response arkv1alpha1.InputResponse) bool {
+			return response.RequestID == pending.ID
+		})
+		if index < 0 {
+			return false
+		}
+		answer = query.Spec.InputResponses[index].Answer
+		return true
+	}); err != nil {
+		return "", err
+	}
+
+	log.Info("query input received", "query", w.key.String(), "request", pending.ID)
+	return answer, nil
+}
@@ -0,0 +1,168 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const (
+	// queuedQueryRetryInterval is how often a queued query checks whether its quota has room
+	queuedQueryRetryInterval = 15 * time.Second
+	// queryQuotaChargeTimeout bounds the status updates that charge a finished query
+	queryQuotaChargeTimeout = 10 * time.Second
+
+	reasonQueryQueued   = "QueryQueued"
+	reasonQuotaExceeded = "QuotaExceeded"
+)
+
+// isQueryQueued reports whether the query is pending because a quota did not admit it
+func isQueryQueued(query *arkv1alpha1.Query) bool {
+	completed := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+	return query.Status.Phase == arkv1alpha1.QueryPhasePending && completed != nil && completed.Reason == reasonQueryQueued
+}
+
+// activeQueries returns the queries of the namespace admitted or executed by this controller.
+// A query started moments ago may not show as running in the cache yet, but must count
+// towards the running query limit.
+func (r *QueryReconciler) activeQueries(namespace string) map[string]bool {
+	active := make(map[string]bool)
+	collect := func(key, _ any) bool {
+		if name, ok := key.(types.NamespacedName); ok && name.Namespace == namespace {
+			active[name.Name] = true
+		}
+		return true
+	}
+	r.operations.Range(collect)
+	r.admitted.Range(collect)
+	return active
+}
+
+// admitQuery checks the QueryQuotas of the query's namespace before the query starts. When
+// a quota is exhausted the query is either failed or left pending to be retried later,
+// depending on the quota's action. It returns true when the query may start.
+//
+// Admission is serialized per namespace and an admitted query counts as running right away,
+// so that concurrent reconciles cannot admit more queries than the quota allows.
+func (r *QueryReconciler) admitQuery(ctx context.Context, query *arkv1alpha1.Query) (bool, error) {
+	lock, _ := r.admissionLocks.LoadOrStore(query.Namespace, &sync.Mutex{})
+	lock.(*sync.Mutex).Lock()
+	defer lock.(*sync.Mutex).Unlock()
+
+	var quotas arkv1alpha1.QueryQuotaList
+	if err := r.List(ctx, &quotas, client.InNamespace(query.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list query quotas: %w", err)
+	}
+	if len(quotas.Items) == 0 {
+		return true, nil
+	}
+
+	var queries arkv1alpha1.QueryList
+	if err := r.List(ctx, &queries, client.InNamespace(query.Namespace)); err != nil {
+		return false, fmt.Errorf("failed to list queries: %w", err)
+	}
+	// The query being admitted is not running yet and must not count against itself
+	others := make([]arkv1alpha1.Query, 0, len(queries.Items))
+	for _, q := range queries.Items {
+		if q.Name != query.Name {
+			others = append(others, q)
+		}
+	}
+
+	active := r.activeQueries(query.Namespace)
+	now := time.Now()
+	reject := false
+	var messages []string
+	for i := range quotas.Items {
+		quota := &quotas.Items[i]
+		message := quotaExhaustedMessage(quota, computeQueryQuotaUsage(quota, others, active, now))
+		if message == "" {
+			continue
+		}
+		messages = append(messages, message)
+		if quota.Spec.Action == arkv1alpha1.QueryQuotaActionReject {
+			reject = true
+		}
+	}
+	if len(messages) == 0 {
+		r.admitted.Store(types.NamespacedName{Name: query.Name, Namespace: query.Namespace}, struct{}{})
+		return true, nil
+	}
+
+	message := strings.Join(messages, "; ")
+	if reject {
+		return false, r.rejectQuery(ctx, query, message)
+	}
+	return false, r.queueQuery(ctx, query, message)
+}
+
+// queueQuery records that the query is waiting for quota. The status is only written when
+// the message changes, so that retries do not generate a stream of updates.
+func (r *QueryReconciler) queueQuery(ctx context.Context, query *arkv1alpha1.Query, message string) error {
+	completed := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryCompleted))
+	if query.Status.Phase == arkv1alpha1.QueryPhasePending && completed != nil && completed.Reason == reasonQueryQueued && completed.Message == message {
+		return nil
+	}
+	query.Status.Phase = arkv1alpha1.QueryPhasePending
+	r.setConditionCompleted(query, metav1.ConditionFalse, reasonQueryQueued, message)
+	return r.Status().Update(ctx, query)
+}
+
+// rejectQuery fails a query that a quota with the reject action did not admit
+func (r *QueryReconciler) rejectQuery(ctx context.Context, query *arkv1alpha1.Query, message string) error {
+	var target arkv1alpha1.QueryTarget
+	if query.Spec.Target != nil {
+		target = *query.Spec.Target
+	}
+	response := r.createErrorResponse(target, fmt.Errorf("%s", message))
+	query.Status.Response = &response
+	query.Status.Phase = arkv1alpha1.QueryPhaseError
+	r.setConditionCompleted(query, metav1.ConditionTrue, reasonQuotaExceeded, message)
+	return r.Status().Update(ctx, query)
+}
+
+// chargeQueryQuotas records the tokens of a finished query in the quotas of its namespace.
+// The usage is kept in the quota status, so deleting the query does not return it. Charging
+// also happens when the query was cancelled or deleted while running, so it does not depend
+// on the context of the execution.
+func (r *QueryReconciler) chargeQueryQuotas(ctx context.Context, namespace string, tokens int64) {
+	if tokens <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), queryQuotaChargeTimeout)
+	defer cancel()
+	log := logf.FromContext(ctx)
+
+	var quotas arkv1alpha1.QueryQuotaList
+	if err := r.List(ctx, &quotas, client.InNamespace(namespace)); err != nil {
+		log.Error(err, "failed to list query quotas to charge", "namespace", namespace)
+		return
+	}
+	for _, quota := range quotas.Items {
+		key := types.NamespacedName{Name: quota.Name, Namespace: quota.Namespace}
+		err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			var latest arkv1alpha1.QueryQuota
+			if err := r.Get(ctx, key, &latest); err != nil {
+				return err
+			}
+			addConsumedTokens(&latest, tokens, time.Now())
+			return r.Status().Update(ctx, &latest)
+		})
+		if err != nil {
+			log.Error(err, "failed to charge tokens to query quota", "quota", key.String(), "tokens", tokens)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// queryWaitPollInterval is how often a paused query is checked for decisions and answers
+const queryWaitPollInterval = 2 * time.Second
+
+// queryWaiter pauses a running query while it waits for people: tool calls to approve and
+// questions to answer are listed in the status, and the decisions and answers are read from
+// the spec. Several calls may wait at once, so the status is only changed under the lock.
+type queryWaiter struct {
+	reconciler   *QueryReconciler
+	query        *arkv1alpha1.Query
+	key          types.NamespacedName
+	pollInterval time.Duration
+	mu           sync.Mutex
+}
+
+func newQueryWaiter(r *QueryReconciler, query *arkv1alpha1.Query) *queryWaiter {
+	return &queryWaiter{
+		reconciler:   r,
+		query:        query,
+		key:          types.NamespacedName{Name: query.Name, Namespace: query.Namespace},
+		pollInterval: queryWaitPollInterval,
+	}
+}
+
+// pause records what the query waits for and moves it to the matching phase
+func (w *queryWaiter) pause(ctx context.Context, reason, message string, record func(status *arkv1alpha1.QueryStatus)) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	record(&w.query.Status)
+	w.query.Status.Phase = waitingPhase(&w.query.Status)
+	w.reconciler.setConditionCompleted(w.query, metav1.ConditionFalse, reason, message)
+	return w.updateStatus(ctx)
+}
+
+// resume removes what the query waited for and returns it to running once nothing is left.
+// When the query is canceled the reconciler owns the status, so only the in-memory copy is
+// changed.
+func (w *queryWaiter) resume(ctx context.Context, remove func(status *arkv1alpha1.QueryStatus)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	remove(&w.query.Status)
+	w.query.Status.Phase = waitingPhase(&w.query.Status)
+	if w.query.Status.Phase == arkv1alpha1.QueryPhaseRunning {
+		w.reconciler.setConditionCompleted(w.query, metav1.ConditionFalse, "QueryRunning", "Query is running")
+	}
+
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+	if err := w.updateStatus(context.WithoutCancel(ctx)); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to resume query", "query", w.key.String())
+	}
+}
+
+// waitFor polls the query until found reports a result, the timeout expires or the context is
+// done. It returns false when the timeout expired.
+func (w *queryWaiter) waitFor(ctx context.Context, timeout time.Duration, found func(query *arkv1alpha1.Query) bool) (bool, error) {
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	poll := time.NewTicker(w.pollInterval)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		case <-expired:
+			return false, nil
+		case <-poll.C:
+			var latest arkv1alpha1.Query
+			if err := w.reconciler.Get(ctx, w.key, &latest); err != nil {
+				logf.FromContext(ctx).Error(err, "failed to check paused query", "query", w.key.String())
+				continue
+			}
+			if found(&latest) {
+				return true, nil
+			}
+		}
+	}
+}
+
+// updateStatus writes the query status. People update the spec while the query waits, so
+// conflicts are resolved by taking the latest spec and resourceVersion; the status is owned by
+// the execution.
+func (w *queryWaiter) updateStatus(ctx context.Context) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := w.reconciler.Status().Update(ctx, w.query)
+		if err == nil || !apierrors.IsConflict(err) {
+			return err
+		}
+		var latest arkv1alpha1.Query
+		if getErr := w.reconciler.Get(ctx, w.key, &latest); getErr != nil {
+			return getErr
+		}
+		w.query.ResourceVersion = latest.ResourceVersion
+		w.query.Spec = latest.Spec
+		return err
+	})
+}
+
+// waitingPhase returns the phase of a query with the given pending calls and questions.
+// Questions take precedence, since the end user has to act on them.
+func waitingPhase(status *arkv1alpha1.QueryStatus) string {
+	switch {
+	case len(status.PendingInputs) > 0:
+		return arkv1alpha1.QueryPhaseInputRequired
+	case len(status.PendingToolCalls) > 0:
+		return arkv1alpha1.QueryPhaseAwaitingApproval
+	default:
+		return arkv1alpha1.QueryPhaseRunning
+	}
+}
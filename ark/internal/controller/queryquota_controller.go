@@ -0,0 +1,210 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const (
+	// queryQuotaResyncInterval refreshes quota usage as completed queries leave the rolling window
+	queryQuotaResyncInterval = time.Minute
+
+	defaultQueryQuotaWindow = 24 * time.Hour
+)
+
+// QueryQuotaReconciler reconciles a QueryQuota object
+type QueryQuotaReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queryquotas,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queryquotas/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=queries,verbs=get;list;watch
+
+func (r *QueryQuotaReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var quota arkv1alpha1.QueryQuota
+	if err := r.Get(ctx, req.NamespacedName, &quota); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch QueryQuota")
+		return ctrl.Result{}, err
+	}
+
+	var queries arkv1alpha1.QueryList
+	if err := r.List(ctx, &queries, client.InNamespace(quota.Namespace)); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list queries: %w", err)
+	}
+
+	usage := computeQueryQuotaUsage(&quota, queries.Items, nil, time.Now())
+	quota.Status.RunningQueries = usage.running
+	quota.Status.QueuedQueries = usage.queued
+	quota.Status.UsedTokens = usage.tokens
+	quota.Status.ConsumedTokens = pruneConsumedTokens(&quota, time.Now())
+
+	if message := quotaExhaustedMessage(&quota, usage); message != "" {
+		r.setConditionExhausted(&quota, metav1.ConditionTrue, "QuotaExhausted", message)
+	} else {
+		r.setConditionExhausted(&quota, metav1.ConditionFalse, "QuotaAvailable", "Quota admits new queries")
+	}
+
+	if err := r.Status().Update(ctx, &quota); err != nil {
+		log.Error(err, "failed to update query quota status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: queryQuotaResyncInterval}, nil
+}
+
+func (r *QueryQuotaReconciler) setConditionExhausted(quota *arkv1alpha1.QueryQuota, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&quota.Status.Conditions, metav1.Condition{
+		Type:               arkv1alpha1.QueryQuotaExhausted,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: quota.Generation,
+	})
+}
+
+func (r *QueryQuotaReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arkv1alpha1.QueryQuota{}).
+		// Watch for Query events to keep the usage of the namespace's quotas current
+		Watches(
+			&arkv1alpha1.Query{},
+			handler.EnqueueRequestsFromMapFunc(r.findQuotasForQuery),
+		).
+		Named("queryquota").
+		Complete(r)
+}
+
+// findQuotasForQuery finds the quotas in the namespace of the given query
+func (r *QueryQuotaReconciler) findQuotasForQuery(ctx context.Context, obj client.Object) []reconcile.Request {
+	var quotas arkv1alpha1.QueryQuotaList
+	if err := r.List(ctx, &quotas, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to list query quotas", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(quotas.Items))
+	for _, quota := range quotas.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: quota.Name, Namespace: quota.Namespace},
+		})
+	}
+	return requests
+}
+
+// queryQuotaUsage is the usage of a namespace counted against one quota
+type queryQuotaUsage struct {
+	running int32
+	queued  int32
+	tokens  int64
+}
+
+// computeQueryQuotaUsage counts running and queued queries, and the tokens used by running
+// queries plus those charged to the quota within its window. Queries in active are counted
+// as running even when the listed object does not show it yet.
+func computeQueryQuotaUsage(quota *arkv1alpha1.QueryQuota, queries []arkv1alpha1.Query, active map[string]bool, now time.Time) queryQuotaUsage {
+	usage := queryQuotaUsage{tokens: consumedTokens(quota, now)}
+	seen := make(map[string]bool, len(queries))
+	for i := range queries {
+		query := &queries[i]
+		seen[query.Name] = true
+
+		switch {
+		case query.Status.Phase == arkv1alpha1.QueryPhaseRunning || query.Status.Phase == arkv1alpha1.QueryPhaseAwaitingApproval || query.Status.Phase == arkv1alpha1.QueryPhaseInputRequired || active[query.Name]:
+			usage.running++
+			usage.tokens += query.Status.TokenUsage.TotalTokens
+		case isQueryQueued(query):
+			usage.queued++
+		}
+	}
+
+	for name := range active {
+		if !seen[name] {
+			usage.running++
+		}
+	}
+	return usage
+}
+
+func queryQuotaWindow(quota *arkv1alpha1.QueryQuota) time.Duration {
+	if quota.Spec.Window != nil {
+		return quota.Spec.Window.Duration
+	}
+	return defaultQueryQuotaWindow
+}
+
+// queryQuotaBucketSize is the time covered by one bucket of consumed tokens
+func queryQuotaBucketSize(quota *arkv1alpha1.QueryQuota) time.Duration {
+	return max(queryQuotaWindow(quota)/24, time.Minute)
+}
+
+// consumedTokens sums the charged tokens of the buckets that overlap the window
+func consumedTokens(quota *arkv1alpha1.QueryQuota, now time.Time) int64 {
+	var tokens int64
+	for _, bucket := range pruneConsumedTokens(quota, now) {
+		tokens += bucket.Tokens
+	}
+	return tokens
+}
+
+// pruneConsumedTokens returns the buckets of consumed tokens that still overlap the window
+func pruneConsumedTokens(quota *arkv1alpha1.QueryQuota, now time.Time) []arkv1alpha1.QueryQuotaTokenBucket {
+	windowStart := now.Add(-queryQuotaWindow(quota))
+	bucketSize := queryQuotaBucketSize(quota)
+	var buckets []arkv1alpha1.QueryQuotaTokenBucket
+	for _, bucket := range quota.Status.ConsumedTokens {
+		if bucket.Start.Add(bucketSize).After(windowStart) {
+			buckets = append(buckets, bucket)
+		}
+	}
+	return buckets
+}
+
+// addConsumedTokens charges tokens to the bucket of now, dropping buckets that left the window
+func addConsumedTokens(quota *arkv1alpha1.QueryQuota, tokens int64, now time.Time) {
+	buckets := pruneConsumedTokens(quota, now)
+	start := now.Truncate(queryQuotaBucketSize(quota))
+	if last := len(buckets) - 1; last >= 0 && buckets[last].Start.Time.Equal(start) {
+		buckets[last].Tokens += tokens
+	} else {
+		buckets = append(buckets, arkv1alpha1.QueryQuotaTokenBucket{Start: metav1.NewTime(start), Tokens: tokens})
+	}
+	quota.Status.ConsumedTokens = buckets
+}
+
+// quotaExhaustedMessage describes why the quota admits no new query, or returns an empty string
+func quotaExhaustedMessage(quota *arkv1alpha1.QueryQuota, usage queryQuotaUsage) string {
+	var reasons []string
+	if quota.Spec.MaxRunningQueries != nil && usage.running >= *quota.Spec.MaxRunningQueries {
+		reasons = append(reasons, fmt.Sprintf("%d of %d queries running", usage.running, *quota.Spec.MaxRunningQueries))
+	}
+	if quota.Spec.MaxTotalTokens != nil && usage.tokens >= *quota.Spec.MaxTotalTokens {
+		reasons = append(reasons, fmt.Sprintf("%d of %d tokens used", usage.tokens, *quota.Spec.MaxTotalTokens))
+	}
+	if len(reasons) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("QueryQuota %s is exhausted: %s", quota.Name, strings.Join(reasons, ", "))
+}
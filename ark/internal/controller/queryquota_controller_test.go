@@ -0,0 +1,133 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var _ = Describe("QueryQuota usage", func() {
+	now := time.Now()
+
+	It("counts running and queued queries, and tokens of running queries and in-window charges", func() {
+		quota := &arkv1alpha1.QueryQuota{
+			Spec: arkv1alpha1.QueryQuotaSpec{Window: &metav1.Duration{Duration: time.Hour}},
+			Status: arkv1alpha1.QueryQuotaStatus{ConsumedTokens: []arkv1alpha1.QueryQuotaTokenBucket{
+				{Start: metav1.NewTime(now.Add(-2 * time.Hour)), Tokens: 1000},
+				{Start: metav1.NewTime(now.Add(-30 * time.Minute)), Tokens: 100},
+			}},
+		}
+		queries := []arkv1alpha1.Query{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "running"},
+				Status: arkv1alpha1.QueryStatus{
+					Phase:      arkv1alpha1.QueryPhaseRunning,
+					TokenUsage: arkv1alpha1.TokenUsage{TotalTokens: 50},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "queued"},
+				Status: arkv1alpha1.QueryStatus{
+					Phase: arkv1alpha1.QueryPhasePending,
+					Conditions: []metav1.Condition{{
+						Type:   string(arkv1alpha1.QueryCompleted),
+						Status: metav1.ConditionFalse,
+						Reason: reasonQueryQueued,
+					}},
+				},
+			},
+			{
+				// Finished queries count through the charges in the quota status only
+				ObjectMeta: metav1.ObjectMeta{Name: "done"},
+				Status: arkv1alpha1.QueryStatus{
+					Phase:      arkv1alpha1.QueryPhaseDone,
+					TokenUsage: arkv1alpha1.TokenUsage{TotalTokens: 100},
+				},
+			},
+		}
+
+		usage := computeQueryQuotaUsage(quota, queries, map[string]bool{"just-started": true}, now)
+		Expect(usage).To(Equal(queryQuotaUsage{running: 2, queued: 1, tokens: 150}))
+	})
+
+	It("charges tokens to buckets and drops buckets that left the window", func() {
+		quota := &arkv1alpha1.QueryQuota{
+			Spec: arkv1alpha1.QueryQuotaSpec{Window: &metav1.Duration{Duration: 24 * time.Hour}},
+			Status: arkv1alpha1.QueryQuotaStatus{ConsumedTokens: []arkv1alpha1.QueryQuotaTokenBucket{
+				{Start: metav1.NewTime(now.Add(-48 * time.Hour)), Tokens: 1000},
+			}},
+		}
+
+		addConsumedTokens(quota, 100, now)
+		addConsumedTokens(quota, 50, now)
+		Expect(quota.Status.ConsumedTokens).To(HaveLen(1))
+		Expect(quota.Status.ConsumedTokens[0].Tokens).To(Equal(int64(150)))
+
+		addConsumedTokens(quota, 10, now.Add(2*time.Hour))
+		Expect(quota.Status.ConsumedTokens).To(HaveLen(2))
+		Expect(consumedTokens(quota, now.Add(2*time.Hour))).To(Equal(int64(160)))
+		Expect(consumedTokens(quota, now.Add(25*time.Hour))).To(Equal(int64(10)))
+	})
+
+	It("admits no more concurrent queries than the quota allows", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		maxRunning := int32(1)
+		objects := []client.Object{&arkv1alpha1.QueryQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-quota", Namespace: "default"},
+			Spec:       arkv1alpha1.QueryQuotaSpec{MaxRunningQueries: &maxRunning, Action: arkv1alpha1.QueryQuotaActionQueue},
+		}}
+		var queries []*arkv1alpha1.Query
+		for i := range 5 {
+			query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("query-%d", i), Namespace: "default"}}
+			queries = append(queries, query)
+			objects = append(objects, query)
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&arkv1alpha1.Query{}).WithObjects(objects...).Build()
+		reconciler := &QueryReconciler{Client: fakeClient}
+
+		var admittedCount atomic.Int32
+		var wg sync.WaitGroup
+		for _, query := range queries {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				admitted, err := reconciler.admitQuery(ctx, query)
+				Expect(err).NotTo(HaveOccurred())
+				if admitted {
+					admittedCount.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		Expect(admittedCount.Load()).To(Equal(int32(1)))
+	})
+
+	It("reports the limits a quota has reached", func() {
+		maxRunning := int32(2)
+		maxTokens := int64(100)
+		quota := &arkv1alpha1.QueryQuota{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-quota"},
+			Spec:       arkv1alpha1.QueryQuotaSpec{MaxRunningQueries: &maxRunning, MaxTotalTokens: &maxTokens},
+		}
+
+		Expect(quotaExhaustedMessage(quota, queryQuotaUsage{running: 1, tokens: 99})).To(BeEmpty())
+		Expect(quotaExhaustedMessage(quota, queryQuotaUsage{running: 2, tokens: 100})).To(Equal(
+			"QueryQuota team-quota is exhausted: 2 of 2 queries running, 100 of 100 tokens used"))
+	})
+})
@@ -5,7 +5,6 @@ package controller
 import "mckinsey.com/ark/internal/annotations"
 
 const (
-	statusPending  = "pending"
 	statusRunning  = "running"
 	statusDone     = "done"
 	statusError    = "error"
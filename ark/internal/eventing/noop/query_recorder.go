@@ -28,3 +28,9 @@ func (n *noopQueryRecorder) QueryParameterResolutionFailed(ctx context.Context,
 
 func (n *noopQueryRecorder) QueryParameterNotFound(ctx context.Context, obj runtime.Object, parameterName string) {
 }
+
+func (n *noopQueryRecorder) QueryResumed(ctx context.Context, obj runtime.Object, reason string) {
+}
+
+func (n *noopQueryRecorder) QueryOrphaned(ctx context.Context, obj runtime.Object, reason string) {
+}
@@ -32,3 +32,11 @@ func (qr *queryRecorder) QueryParameterResolutionFailed(ctx context.Context, obj
 func (qr *queryRecorder) QueryParameterNotFound(ctx context.Context, obj runtime.Object, parameterName string) {
 	qr.emitter.EmitWarning(ctx, obj, "QueryParameterNotFound", fmt.Sprintf("Parameter not found: %s", parameterName))
 }
+
+func (qr *queryRecorder) QueryResumed(ctx context.Context, obj runtime.Object, reason string) {
+	qr.emitter.EmitNormal(ctx, obj, "QueryResumed", reason)
+}
+
+func (qr *queryRecorder) QueryOrphaned(ctx context.Context, obj runtime.Object, reason string) {
+	qr.emitter.EmitWarning(ctx, obj, "QueryOrphaned", reason)
+}
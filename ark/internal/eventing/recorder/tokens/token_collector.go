@@ -2,6 +2,7 @@ package tokens
 
 import (
 	"context"
+	"sync"
 
 	"github.com/openai/openai-go"
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
@@ -11,6 +12,13 @@ type tokenUsageKeyType struct{}
 
 var tokenUsageKey = tokenUsageKeyType{}
 
+// collectedUsage is the usage collected for one query. It is shared by tool calls and team
+// branches executing concurrently, so it carries its own lock.
+type collectedUsage struct {
+	mu    sync.Mutex
+	usage arkv1alpha1.TokenUsage
+}
+
 type TokenCollector struct{}
 
 func NewTokenCollector() TokenCollector {
@@ -18,19 +26,20 @@ func NewTokenCollector() TokenCollector {
 }
 
 func (tc *TokenCollector) StartTokenCollection(ctx context.Context) context.Context {
-	usage := &arkv1alpha1.TokenUsage{}
-	return context.WithValue(ctx, tokenUsageKey, usage)
+	return context.WithValue(ctx, tokenUsageKey, &collectedUsage{})
 }
 
 func (tc *TokenCollector) AddTokens(ctx context.Context, promptTokens, completionTokens, totalTokens int64) {
-	usage, ok := ctx.Value(tokenUsageKey).(*arkv1alpha1.TokenUsage)
-	if !ok || usage == nil {
+	collected, ok := ctx.Value(tokenUsageKey).(*collectedUsage)
+	if !ok || collected == nil {
 		return
 	}
 
-	usage.PromptTokens += promptTokens
-	usage.CompletionTokens += completionTokens
-	usage.TotalTokens += totalTokens
+	collected.mu.Lock()
+	defer collected.mu.Unlock()
+	collected.usage.PromptTokens += promptTokens
+	collected.usage.CompletionTokens += completionTokens
+	collected.usage.TotalTokens += totalTokens
 }
 
 func (tc *TokenCollector) AddTokenUsage(ctx context.Context, usage arkv1alpha1.TokenUsage) {
@@ -42,10 +51,12 @@ func (tc *TokenCollector) AddCompletionUsage(ctx context.Context, usage openai.C
 }
 
 func (tc *TokenCollector) GetTokenSummary(ctx context.Context) arkv1alpha1.TokenUsage {
-	usage, ok := ctx.Value(tokenUsageKey).(*arkv1alpha1.TokenUsage)
-	if !ok || usage == nil {
+	collected, ok := ctx.Value(tokenUsageKey).(*collectedUsage)
+	if !ok || collected == nil {
 		return arkv1alpha1.TokenUsage{}
 	}
 
-	return *usage
+	collected.mu.Lock()
+	defer collected.mu.Unlock()
+	return collected.usage
 }
@@ -2,6 +2,7 @@ package tokens
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/openai/openai-go"
@@ -15,12 +16,10 @@ func TestTokenCollector_StartTokenCollection(t *testing.T) {
 
 	ctx = tc.StartTokenCollection(ctx)
 
-	usage, ok := ctx.Value(tokenUsageKey).(*arkv1alpha1.TokenUsage)
+	collected, ok := ctx.Value(tokenUsageKey).(*collectedUsage)
 	assert.True(t, ok, "Expected tokenUsageKey to be set in context")
-	assert.NotNil(t, usage, "Expected usage to be initialized")
-	assert.Equal(t, int64(0), usage.PromptTokens)
-	assert.Equal(t, int64(0), usage.CompletionTokens)
-	assert.Equal(t, int64(0), usage.TotalTokens)
+	assert.NotNil(t, collected, "Expected usage to be initialized")
+	assert.Equal(t, arkv1alpha1.TokenUsage{}, collected.usage)
 }
 
 func TestTokenCollector_AddTokens(t *testing.T) {
@@ -48,6 +47,23 @@ func TestTokenCollector_AddTokens_Multiple(t *testing.T) {
 	assert.Equal(t, int64(450), usage.TotalTokens)
 }
 
+func TestTokenCollector_AddTokens_Concurrent(t *testing.T) {
+	tc := NewTokenCollector()
+	ctx := tc.StartTokenCollection(context.Background())
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			tc.AddTokens(ctx, 10, 5, 15)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, arkv1alpha1.TokenUsage{PromptTokens: 100, CompletionTokens: 50, TotalTokens: 150}, tc.GetTokenSummary(ctx))
+}
+
 func TestTokenCollector_AddTokens_NoCollection(t *testing.T) {
 	tc := NewTokenCollector()
 	ctx := context.Background()
@@ -68,6 +68,8 @@ type QueryRecorder interface {
 	TokenCollector
 	QueryParameterResolutionFailed(ctx context.Context, obj runtime.Object, parameterName, reason string)
 	QueryParameterNotFound(ctx context.Context, obj runtime.Object, parameterName string)
+	QueryResumed(ctx context.Context, obj runtime.Object, reason string)
+	QueryOrphaned(ctx context.Context, obj runtime.Object, reason string)
 }
 
 type ToolRecorder interface {
@@ -6,11 +6,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"strings"
 	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
@@ -33,6 +36,33 @@ type A2AResponse struct {
 	Content   string
 	ContextID string
 	TaskID    string
+	// TokenUsage is the usage the agent reported in the "usage" metadata of its response
+	TokenUsage arkv1alpha1.TokenUsage
+}
+
+// A2AExecutionOptions selects the optional A2A protocol features used to call an agent, as
+// advertised by its agent card, and the headers to call it with
+type A2AExecutionOptions struct {
+	// Streaming sends messages with message/stream rather than waiting on message/send
+	Streaming bool
+	// OnText receives the agent text of status and artifact updates as they are streamed
+	OnText func(text string)
+	// PushNotifications receives the updates of the agent's tasks, so that they are not polled
+	PushNotifications *A2APushNotificationServer
+	// Headers are sent on top of the A2A server's headers, e.g. as set by overrides
+	Headers map[string]string
+}
+
+// updateMode returns how the A2ATask controller learns about the tasks of the execution
+func (o A2AExecutionOptions) updateMode() string {
+	switch {
+	case o.PushNotifications != nil:
+		return A2ATaskUpdateModePush
+	case o.Streaming:
+		return A2ATaskUpdateModeStream
+	default:
+		return A2ATaskUpdateModePoll
+	}
 }
 
 // DiscoverA2AAgents discovers agents from an A2A server using simplified HTTP approach
@@ -79,37 +109,43 @@ func DiscoverA2AAgentsWithRecorder(ctx context.Context, k8sClient client.Client,
 }
 
 // ExecuteA2AAgent executes a task on an A2A agent with optional K8s event recording and query context
-func ExecuteA2AAgent(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, namespace, input, agentName, queryName, contextID string, a2aRecorder eventing.A2aRecorder, obj client.Object) (*A2AResponse, error) {
+func ExecuteA2AAgent(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, namespace, input, agentName, queryName, contextID string, a2aRecorder eventing.A2aRecorder, obj client.Object, options A2AExecutionOptions) (*A2AResponse, error) {
 	rpcURL := strings.TrimSuffix(address, "/")
 
 	// Create and configure A2A client
-	a2aClient, err := CreateA2AClient(ctx, k8sClient, rpcURL, headers, namespace, agentName, a2aRecorder)
+	a2aClient, err := CreateA2AClient(ctx, k8sClient, rpcURL, headers, options.Headers, namespace, a2aRecorder)
 	if err != nil {
 		return nil, err
 	}
 
 	// Execute agent and get response
-	return executeA2AAgentMessage(ctx, k8sClient, a2aClient, input, agentName, namespace, queryName, contextID, obj, a2aRecorder)
+	return executeA2AAgentMessage(ctx, k8sClient, a2aClient, input, agentName, namespace, queryName, contextID, obj, a2aRecorder, options)
 }
 
-// CreateA2AClient creates and configures A2A client with header resolution and injection
-func CreateA2AClient(ctx context.Context, k8sClient client.Client, rpcURL string, headers []arkv1prealpha1.Header, namespace, agentName string, a2aRecorder eventing.A2aRecorder) (*a2aclient.A2AClient, error) {
+// CreateA2AClient creates an A2A client sending the resolved headers of the A2A server, followed
+// by overrideHeaders
+func CreateA2AClient(ctx context.Context, k8sClient client.Client, rpcURL string, headers []arkv1prealpha1.Header, overrideHeaders map[string]string, namespace string, a2aRecorder eventing.A2aRecorder) (*a2aclient.A2AClient, error) {
 	// Use context deadline if available, otherwise default
 	timeout := 5 * time.Minute
 	if deadline, ok := ctx.Deadline(); ok {
 		timeout = time.Until(deadline)
 	}
 
-	var clientOptions []a2aclient.Option
+	resolvedHeaders := make(map[string]string)
 	if len(headers) > 0 {
-		resolvedHeaders, err := resolveA2AHeaders(ctx, k8sClient, headers, namespace)
+		var err error
+		resolvedHeaders, err = resolveA2AHeaders(ctx, k8sClient, headers, namespace)
 		if err != nil {
 			if a2aRecorder != nil {
 				a2aRecorder.A2AHeaderResolutionFailed(ctx, fmt.Sprintf("failed to resolve A2A headers: %v", err))
 			}
 			return nil, err
 		}
+	}
+	maps.Copy(resolvedHeaders, overrideHeaders)
 
+	var clientOptions []a2aclient.Option
+	if len(resolvedHeaders) > 0 {
 		httpClient := &http.Client{Timeout: timeout}
 		clientOptions = append(clientOptions, a2aclient.WithHTTPClient(httpClient))
 		clientOptions = append(clientOptions, a2aclient.WithHTTPReqHandler(&customA2ARequestHandler{
@@ -127,8 +163,9 @@ func CreateA2AClient(ctx context.Context, k8sClient client.Client, rpcURL string
 	return a2aClient, nil
 }
 
-// executeA2AAgentMessage sends message to A2A agent and processes response
-func executeA2AAgentMessage(ctx context.Context, k8sClient client.Client, a2aClient *a2aclient.A2AClient, input, agentName, namespace, queryName, contextID string, obj client.Object, a2aRecorder eventing.A2aRecorder) (*A2AResponse, error) {
+// executeA2AAgentMessage sends message to A2A agent and processes response. When the agent
+// asks for input, the end user's answer is sent on the same task until it finishes.
+func executeA2AAgentMessage(ctx context.Context, k8sClient client.Client, a2aClient *a2aclient.A2AClient, input, agentName, namespace, queryName, contextID string, obj client.Object, a2aRecorder eventing.A2aRecorder, options A2AExecutionOptions) (*A2AResponse, error) {
 	var message protocol.Message
 	if contextID != "" {
 		message = protocol.NewMessageWithContext(protocol.MessageRoleUser, []protocol.Part{
@@ -140,19 +177,98 @@ func executeA2AAgentMessage(ctx context.Context, k8sClient client.Client, a2aCli
 		})
 	}
 
+	updateMode := options.updateMode()
+	var pushConfig *protocol.PushNotificationConfig
+	if options.PushNotifications != nil {
+		pushConfig = options.PushNotifications.Config(namespace, a2aServerName(obj))
+	}
+
+	// Streamed tasks are recorded as their events arrive, as nothing polls them
+	recorded := map[string]bool{}
+	onUpdate := func(task *protocol.Task, text string, record bool) {
+		if text != "" && options.OnText != nil {
+			options.OnText(text)
+		}
+		if !record || task.ID == "" {
+			return
+		}
+		if !recorded[task.ID] {
+			if err := handleA2ATaskResponse(ctx, k8sClient, task, agentName, namespace, queryName, obj, updateMode); err != nil {
+				logf.FromContext(ctx).Error(err, "failed to create A2ATask resource", "taskId", task.ID, "agent", agentName)
+				return
+			}
+			recorded[task.ID] = true
+			return
+		}
+		if err := updateA2ATaskStatus(ctx, k8sClient, namespace, task); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to update A2ATask status", "taskId", task.ID, "agent", agentName)
+		}
+	}
+
+	var inputUsage arkv1alpha1.TokenUsage
+	for {
+		params := newA2AMessageParams(message, pushConfig)
+		var (
+			result *protocol.MessageResult
+			err    error
+		)
+		if options.Streaming {
+			result, err = streamA2AMessage(ctx, a2aClient, params, onUpdate, a2aRecorder)
+		} else {
+			result, err = sendA2AMessage(ctx, a2aClient, params, a2aRecorder)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		task, ok := result.Result.(*protocol.Task)
+		if ok && task.Status.State == TaskStateInputRequired {
+			if err := handleA2ATaskResponse(ctx, k8sClient, task, agentName, namespace, queryName, obj, updateMode); err != nil {
+				return nil, fmt.Errorf("failed to handle A2A task response: %w", err)
+			}
+			answer, err := requestInput(ctx, InputRequest{Agent: agentName, TaskID: task.ID, Question: a2aInputQuestion(task)})
+			if err != nil {
+				return nil, err
+			}
+			inputUsage = addA2ATokenUsage(inputUsage, a2aTokenUsage(task.Metadata))
+			message = protocol.NewMessageWithContext(protocol.MessageRoleUser, []protocol.Part{
+				protocol.NewTextPart(answer),
+			}, &task.ID, &task.ContextID)
+			continue
+		}
+
+		response, err := extractResponseFromMessageResult(ctx, k8sClient, result, agentName, namespace, queryName, obj, updateMode)
+		if err != nil {
+			if a2aRecorder != nil {
+				a2aRecorder.A2AResponseParseError(ctx, fmt.Sprintf("Failed to parse A2A response: %v", err))
+			}
+			return nil, err
+		}
+		response.TokenUsage = addA2ATokenUsage(inputUsage, response.TokenUsage)
+		return response, nil
+	}
+}
+
+// newA2AMessageParams returns the parameters to send a message with, asking for the task updates
+// to be pushed when pushConfig is set
+func newA2AMessageParams(message protocol.Message, pushConfig *protocol.PushNotificationConfig) protocol.SendMessageParams {
 	blocking := true
-	params := protocol.SendMessageParams{
+	return protocol.SendMessageParams{
 		RPCID:   protocol.GenerateRPCID(),
 		Message: message,
 		// Blocking: true causes the A2A server to wait for task completion before responding.
 		// When false, the server returns immediately with a Task in "submitted" state, requiring
 		// the client to poll for updates. Ark currently only supports blocking mode, expecting
-		// Tasks to be in terminal state ("completed" or "failed") when returned.
+		// Tasks to be in terminal state ("completed" or "failed") or waiting for input when returned.
+		// Streamed messages ignore it, as the stream stays open until the task finishes.
 		Configuration: &protocol.SendMessageConfiguration{
-			Blocking: &blocking,
+			Blocking:               &blocking,
+			PushNotificationConfig: pushConfig,
 		},
 	}
+}
 
+func sendA2AMessage(ctx context.Context, a2aClient *a2aclient.A2AClient, params protocol.SendMessageParams, a2aRecorder eventing.A2aRecorder) (*protocol.MessageResult, error) {
 	result, err := a2aClient.SendMessage(ctx, params)
 	if err != nil {
 		if a2aRecorder != nil {
@@ -160,16 +276,28 @@ func executeA2AAgentMessage(ctx context.Context, k8sClient client.Client, a2aCli
 		}
 		return nil, fmt.Errorf("A2A server call failed: %w", err)
 	}
+	if result == nil {
+		return nil, fmt.Errorf("result is nil")
+	}
+	return result, nil
+}
 
-	response, err := extractResponseFromMessageResult(ctx, k8sClient, result, agentName, namespace, queryName, obj)
-	if err != nil {
-		if a2aRecorder != nil {
-			a2aRecorder.A2AResponseParseError(ctx, fmt.Sprintf("Failed to parse A2A response: %v", err))
+// a2aInputQuestion returns the text of the status message of a task waiting for input
+func a2aInputQuestion(task *protocol.Task) string {
+	if task.Status.Message != nil {
+		if question := extractTextFromParts(task.Status.Message.Parts); question != "" {
+			return question
 		}
-		return nil, err
 	}
+	return "The agent needs more input to continue"
+}
 
-	return response, nil
+func addA2ATokenUsage(a, b arkv1alpha1.TokenUsage) arkv1alpha1.TokenUsage {
+	return arkv1alpha1.TokenUsage{
+		PromptTokens:     a.PromptTokens + b.PromptTokens,
+		CompletionTokens: a.CompletionTokens + b.CompletionTokens,
+		TotalTokens:      a.TotalTokens + b.TotalTokens,
+	}
 }
 
 // customA2ARequestHandler handles adding custom headers and OTEL tracing to A2A requests
@@ -196,7 +324,7 @@ func (h *customA2ARequestHandler) Handle(ctx context.Context, httpClient *http.C
 }
 
 // extractResponseFromMessageResult extracts response from MessageResult and handles both messages and tasks
-func extractResponseFromMessageResult(ctx context.Context, k8sClient client.Client, result *protocol.MessageResult, agentName, namespace, queryName string, obj client.Object) (*A2AResponse, error) {
+func extractResponseFromMessageResult(ctx context.Context, k8sClient client.Client, result *protocol.MessageResult, agentName, namespace, queryName string, obj client.Object, updateMode string) (*A2AResponse, error) {
 	log := logf.FromContext(ctx)
 	if result == nil {
 		return nil, fmt.Errorf("result is nil")
@@ -206,7 +334,8 @@ func extractResponseFromMessageResult(ctx context.Context, k8sClient client.Clie
 	case *protocol.Message:
 		text := extractTextFromParts(r.Parts)
 		response := &A2AResponse{
-			Content: text,
+			Content:    text,
+			TokenUsage: a2aTokenUsage(r.Metadata),
 		}
 		if r.ContextID != nil && *r.ContextID != "" {
 			response.ContextID = *r.ContextID
@@ -219,16 +348,17 @@ func extractResponseFromMessageResult(ctx context.Context, k8sClient client.Clie
 			return nil, err
 		}
 
-		err = handleA2ATaskResponse(ctx, k8sClient, r, agentName, namespace, queryName, obj)
+		err = handleA2ATaskResponse(ctx, k8sClient, r, agentName, namespace, queryName, obj, updateMode)
 		if err != nil {
 			log.Error(err, "failed to create A2ATask resource", "taskId", r.ID, "agent", agentName)
 			return nil, fmt.Errorf("failed to handle A2A task response: %w", err)
 		}
 
 		response := &A2AResponse{
-			Content:   text,
-			ContextID: r.ContextID,
-			TaskID:    r.ID,
+			Content:    text,
+			ContextID:  r.ContextID,
+			TaskID:     r.ID,
+			TokenUsage: a2aTokenUsage(r.Metadata),
 		}
 		return response, nil
 	default:
@@ -237,6 +367,27 @@ func extractResponseFromMessageResult(ctx context.Context, k8sClient client.Clie
 	}
 }
 
+// a2aTokenUsage reads the usage an agent reports in the metadata of its response, in the same
+// format as execution engines: {"usage": {"prompt_tokens": 10, "completion_tokens": 5, "total_tokens": 15}}
+func a2aTokenUsage(metadata map[string]any) arkv1alpha1.TokenUsage {
+	value, ok := metadata["usage"]
+	if !ok {
+		return arkv1alpha1.TokenUsage{}
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return arkv1alpha1.TokenUsage{}
+	}
+	var usage TokenUsage
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return arkv1alpha1.TokenUsage{}
+	}
+	if usage.TotalTokens == 0 {
+		usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	}
+	return usage.usage()
+}
+
 // extractTextFromTask extracts text from a completed or failed Task
 func extractTextFromTask(task *protocol.Task) (string, error) {
 	if task.Status.State == "" {
@@ -259,6 +410,13 @@ func extractTextFromTask(task *protocol.Task) (string, error) {
 			}
 		}
 
+		// Agents that only produce artifacts answer with the artifact text
+		if text.Len() == 0 {
+			for _, artifact := range task.Artifacts {
+				text.WriteString(extractTextFromParts(artifact.Parts))
+			}
+		}
+
 		return text.String(), nil
 
 	case TaskStateFailed:
@@ -379,22 +537,18 @@ func resolveA2AHeaders(ctx context.Context, k8sClient client.Client, headers []a
 	return resolvedHeaders, nil
 }
 
-// handleA2ATaskResponse handles A2A task responses by creating A2ATask resources
-func handleA2ATaskResponse(ctx context.Context, k8sClient client.Client, task *protocol.Task, agentName, namespace, queryName string, obj client.Object) error {
+// handleA2ATaskResponse handles A2A task responses by creating A2ATask resources, whose status
+// the A2ATask controller keeps up to date with the given update mode
+func handleA2ATaskResponse(ctx context.Context, k8sClient client.Client, task *protocol.Task, agentName, namespace, queryName string, obj client.Object, updateMode string) error {
 	log := logf.FromContext(ctx)
 
 	if queryName == "" {
 		return fmt.Errorf("unable to determine A2A Task originating query")
 	}
 
-	var a2aServerName string
-	if a2aServer, ok := obj.(*arkv1prealpha1.A2AServer); ok {
-		a2aServerName = a2aServer.Name
-	}
-
 	a2aTask := &arkv1alpha1.A2ATask{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("a2a-task-%s", task.ID),
+			Name:      a2aTaskName(task.ID),
 			Namespace: namespace,
 		},
 		Spec: arkv1alpha1.A2ATaskSpec{
@@ -405,13 +559,14 @@ func handleA2ATaskResponse(ctx context.Context, k8sClient client.Client, task *p
 				Namespace: namespace,
 			},
 			A2AServerRef: arkv1alpha1.A2AServerRef{
-				Name:      a2aServerName,
+				Name:      a2aServerName(obj),
 				Namespace: namespace,
 			},
 			AgentRef: arkv1alpha1.AgentRef{
 				Name:      agentName,
 				Namespace: namespace,
 			},
+			UpdateMode: updateMode,
 		},
 		Status: arkv1alpha1.A2ATaskStatus{
 			Phase: ConvertA2AStateToPhase(string(task.Status.State)),
@@ -425,11 +580,52 @@ func handleA2ATaskResponse(ctx context.Context, k8sClient client.Client, task *p
 	now := metav1.NewTime(time.Now())
 	a2aTask.Status.StartTime = &now
 
-	// Create the resource
-	if err := k8sClient.Create(ctx, a2aTask); err != nil {
+	// Create the resource. A task that asked for input already has one, which is updated.
+	if err := k8sClient.Create(ctx, a2aTask); err != nil && !apierrors.IsAlreadyExists(err) {
 		log.Error(err, "failed to create A2ATask resource", "taskId", task.ID)
 		return fmt.Errorf("failed to create A2ATask resource: %w", err)
 	}
 
+	// The status is not part of the creation, and tasks that are not polled need it now. The
+	// A2ATask controller still polls them eventually when this fails.
+	if err := updateA2ATaskStatus(ctx, k8sClient, namespace, task); err != nil {
+		log.Error(err, "failed to update A2ATask status", "taskId", task.ID)
+	}
 	return nil
 }
+
+// updateA2ATaskStatus records a task update received from the A2A server on its A2ATask. Updates
+// without a timestamp are stamped with the time they were received, which the A2ATask controller
+// uses to tell whether updates are still arriving. Tasks that already finished keep their phase,
+// so that a late update cannot revive a task Ark cancelled.
+func updateA2ATaskStatus(ctx context.Context, k8sClient client.Client, namespace string, task *protocol.Task) error {
+	if task.Status.Timestamp == "" {
+		stamped := *task
+		stamped.Status.Timestamp = time.Now().UTC().Format(time.RFC3339)
+		task = &stamped
+	}
+	key := client.ObjectKey{Name: a2aTaskName(task.ID), Namespace: namespace}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var a2aTask arkv1alpha1.A2ATask
+		if err := k8sClient.Get(ctx, key, &a2aTask); err != nil {
+			return err
+		}
+		if IsTerminalPhase(a2aTask.Status.Phase) {
+			return nil
+		}
+		UpdateA2ATaskStatus(&a2aTask.Status, task)
+		return k8sClient.Status().Update(ctx, &a2aTask)
+	})
+}
+
+// a2aServerName returns the name of the A2AServer the agent is executed on, if known
+func a2aServerName(obj client.Object) string {
+	if a2aServer, ok := obj.(*arkv1prealpha1.A2AServer); ok {
+		return a2aServer.Name
+	}
+	return ""
+}
+
+func a2aTaskName(taskID string) string {
+	return fmt.Sprintf("a2a-task-%s", taskID)
+}
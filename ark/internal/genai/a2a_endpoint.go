@@ -0,0 +1,643 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+	"trpc.group/trpc-go/trpc-a2a-go/taskmanager"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	arkann "mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/labels"
+)
+
+// A2AEndpointPath is the path agents and teams are served under, followed by
+// /<namespace>/agents/<name> or /<namespace>/teams/<name>
+const A2AEndpointPath = "/a2a"
+
+// a2aEndpointPollInterval is how often the query of a task is checked while a caller waits for it
+const a2aEndpointPollInterval = time.Second
+
+// serviceAccountUsernamePrefix prefixes the user names of service accounts, followed by
+// <namespace>:<name>
+const serviceAccountUsernamePrefix = "system:serviceaccount:"
+
+// a2aEndpointSecurityScheme is the name of the bearer token scheme in agent cards
+const a2aEndpointSecurityScheme = "kubernetesServiceAccount"
+
+// a2aEndpointTargets maps the path segment of served resources to their query target type
+var a2aEndpointTargets = map[string]string{
+	"agents": "agent",
+	"teams":  "team",
+}
+
+// A2AEndpoint serves the agents and teams annotated with ark.mckinsey.com/a2a-expose over the A2A
+// protocol. Callers authenticate with a token of a service account of the namespace issued for the
+// endpoint's audience, and each message starts a Query run as that service account, whose name is the task ID and whose
+// conversation is derived from the caller and the A2A context.
+type A2AEndpoint struct {
+	bindAddress string
+	url         string
+	audience    string
+	client      client.Client
+}
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// NewA2AEndpoint creates an endpoint listening on bindAddress, which A2A clients reach at url with
+// tokens issued for audience
+func NewA2AEndpoint(bindAddress, url, audience string, k8sClient client.Client) *A2AEndpoint {
+	return &A2AEndpoint{
+		bindAddress: bindAddress,
+		url:         strings.TrimSuffix(url, "/"),
+		audience:    audience,
+		client:      k8sClient,
+	}
+}
+
+// Start serves the endpoint until the context is done. It implements manager.Runnable.
+func (e *A2AEndpoint) Start(ctx context.Context) error {
+	logf.FromContext(ctx).Info("starting A2A endpoint", "address", e.bindAddress, "url", e.url)
+	return serveHTTP(ctx, e.bindAddress, e.Handler())
+}
+
+// NeedLeaderElection returns false, so that every replica serves the endpoint
+func (e *A2AEndpoint) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the HTTP handler of the endpoint
+func (e *A2AEndpoint) Handler() http.Handler {
+	base := A2AEndpointPath + "/{namespace}/{kind}/{name}"
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+base+AgentCardPathVersion3, e.handleAgentCard)
+	mux.HandleFunc("GET "+base+AgentCardPathVersion2, e.handleAgentCard)
+	mux.HandleFunc("POST "+base, e.handleJSONRPC)
+	mux.HandleFunc("POST "+base+"/", e.handleJSONRPC)
+	return mux
+}
+
+func (e *A2AEndpoint) handleAgentCard(w http.ResponseWriter, r *http.Request) {
+	card, err := e.agentCard(r.Context(), r.PathValue("namespace"), r.PathValue("kind"), r.PathValue("name"))
+	if err != nil {
+		writeA2AEndpointError(w, r, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(card); err != nil {
+		logf.FromContext(r.Context()).Error(err, "failed to write agent card")
+	}
+}
+
+func (e *A2AEndpoint) handleJSONRPC(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	caller, err := e.authenticate(r.Context(), r, namespace)
+	if err != nil {
+		writeA2AEndpointError(w, r, err)
+		return
+	}
+	target, _, err := e.exposedTarget(r.Context(), namespace, r.PathValue("kind"), r.PathValue("name"))
+	if err != nil {
+		writeA2AEndpointError(w, r, err)
+		return
+	}
+
+	tasks := &a2aQueryTaskManager{client: e.client, namespace: namespace, target: target, caller: caller}
+	a2aServer, err := server.NewA2AServer(server.AgentCard{Name: target.Name}, tasks, server.WithCORSEnabled(false))
+	if err != nil {
+		writeA2AEndpointError(w, r, err)
+		return
+	}
+
+	// The A2A server serves JSON-RPC at the root, whatever the path of the resource
+	request := r.Clone(r.Context())
+	request.URL.Path = protocol.DefaultJSONRPCPath
+	a2aServer.Handler().ServeHTTP(w, request)
+}
+
+func writeA2AEndpointError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case apierrors.IsNotFound(err):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case apierrors.IsUnauthorized(err):
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case apierrors.IsForbidden(err):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+	logf.FromContext(r.Context()).Error(err, "A2A endpoint request failed", "path", r.URL.Path)
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
+
+// a2aCaller is the service account a request to the endpoint was authenticated as
+type a2aCaller struct {
+	// username is the user name of the service account, system:serviceaccount:<namespace>:<name>
+	username       string
+	serviceAccount string
+}
+
+// authenticate reviews the bearer token of the request. Callers must be service accounts of the
+// namespace that are allowed to create queries in it, as the queries they start run as them
+// rather than with the permissions of the controller.
+func (e *A2AEndpoint) authenticate(ctx context.Context, r *http.Request, namespace string) (*a2aCaller, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return nil, apierrors.NewUnauthorized("a bearer token is required")
+	}
+
+	// Tokens issued for other audiences, such as the API server, must not be replayed here. The
+	// returned audiences are checked too, as authenticators that ignore audiences omit them.
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: []string{e.audience}}}
+	if err := e.client.Create(ctx, review); err != nil {
+		return nil, fmt.Errorf("failed to review token: %w", err)
+	}
+	if !review.Status.Authenticated {
+		return nil, apierrors.NewUnauthorized("the bearer token is not valid")
+	}
+	if !slices.Contains(review.Status.Audiences, e.audience) {
+		return nil, apierrors.NewUnauthorized(fmt.Sprintf("the bearer token is not valid for audience %s", e.audience))
+	}
+
+	user := review.Status.User
+	queries := arkv1alpha1.GroupVersion.WithResource("queries").GroupResource()
+	serviceAccount, ok := strings.CutPrefix(user.Username, serviceAccountUsernamePrefix+namespace+":")
+	if !ok || serviceAccount == "" || strings.Contains(serviceAccount, ":") {
+		return nil, apierrors.NewForbidden(queries, "", fmt.Errorf("%s is not a service account of namespace %s", user.Username, namespace))
+	}
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	access := &authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+		User:   user.Username,
+		UID:    user.UID,
+		Groups: user.Groups,
+		Extra:  extra,
+		ResourceAttributes: &authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "create",
+			Group:     queries.Group,
+			Resource:  queries.Resource,
+		},
+	}}
+	if err := e.client.Create(ctx, access); err != nil {
+		return nil, fmt.Errorf("failed to review access: %w", err)
+	}
+	if !access.Status.Allowed {
+		return nil, apierrors.NewForbidden(queries, "", fmt.Errorf("%s cannot create queries in namespace %s", user.Username, namespace))
+	}
+	return &a2aCaller{username: user.Username, serviceAccount: serviceAccount}, nil
+}
+
+// exposedTarget returns the query target of the agent or team at the path, which is only found
+// when it is annotated to be exposed
+func (e *A2AEndpoint) exposedTarget(ctx context.Context, namespace, kind, name string) (arkv1alpha1.QueryTarget, client.Object, error) {
+	targetType, ok := a2aEndpointTargets[kind]
+	if !ok {
+		return arkv1alpha1.QueryTarget{}, nil, apierrors.NewNotFound(arkv1alpha1.GroupVersion.WithResource(kind).GroupResource(), name)
+	}
+
+	var obj client.Object = &arkv1alpha1.Agent{}
+	if targetType == "team" {
+		obj = &arkv1alpha1.Team{}
+	}
+	if err := e.client.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, obj); err != nil {
+		return arkv1alpha1.QueryTarget{}, nil, err
+	}
+	if obj.GetAnnotations()[arkann.A2AExpose] != "true" {
+		return arkv1alpha1.QueryTarget{}, nil, apierrors.NewNotFound(arkv1alpha1.GroupVersion.WithResource(kind).GroupResource(), name)
+	}
+	return arkv1alpha1.QueryTarget{Type: targetType, Name: name}, obj, nil
+}
+
+// agentCard generates the card of an agent or team from its description, and its tools or members
+func (e *A2AEndpoint) agentCard(ctx context.Context, namespace, kind, name string) (*server.AgentCard, error) {
+	_, obj, err := e.exposedTarget(ctx, namespace, kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var description string
+	var skills []server.AgentSkill
+	switch resource := obj.(type) {
+	case *arkv1alpha1.Agent:
+		description = resource.Spec.Description
+		skills = agentSkills(resource)
+	case *arkv1alpha1.Team:
+		description = resource.Spec.Description
+		skills = teamSkills(resource)
+	}
+	if description == "" {
+		description = fmt.Sprintf("Ark %s %s", strings.TrimSuffix(kind, "s"), name)
+	}
+
+	streaming := true
+	pushNotifications := false
+	scheme := "bearer"
+	schemeDescription := fmt.Sprintf("Token of a service account of namespace %s that can create queries, issued for audience %s", namespace, e.audience)
+	return &server.AgentCard{
+		Name:        name,
+		Description: description,
+		URL:         fmt.Sprintf("%s%s/%s/%s/%s", e.url, A2AEndpointPath, namespace, kind, name),
+		Version:     strconv.FormatInt(obj.GetGeneration(), 10),
+		Capabilities: server.AgentCapabilities{
+			Streaming:         &streaming,
+			PushNotifications: &pushNotifications,
+		},
+		DefaultInputModes:  []string{"text"},
+		DefaultOutputModes: []string{"text"},
+		Skills:             skills,
+		SecuritySchemes: map[string]server.SecurityScheme{
+			a2aEndpointSecurityScheme: {Type: server.SecuritySchemeTypeHTTP, Scheme: &scheme, Description: &schemeDescription},
+		},
+		Security: []map[string][]string{{a2aEndpointSecurityScheme: {}}},
+	}, nil
+}
+
+// agentSkills describes the tools of an agent as skills. Agents of A2A servers keep the skills
+// of their own agent card.
+func agentSkills(agent *arkv1alpha1.Agent) []server.AgentSkill {
+	var skills []server.AgentSkill
+	if annotation := agent.Annotations[arkann.A2AServerSkills]; annotation != "" {
+		if err := json.Unmarshal([]byte(annotation), &skills); err == nil && len(skills) > 0 {
+			return skills
+		}
+	}
+
+	for _, tool := range agent.Spec.Tools {
+		name := tool.Name
+		if name == "" {
+			name = tool.Type
+		}
+		skill := server.AgentSkill{ID: name, Name: name, Tags: []string{tool.Type}}
+		if tool.Description != "" {
+			skill.Description = &tool.Description
+		}
+		skills = append(skills, skill)
+	}
+	if len(skills) == 0 {
+		skills = append(skills, server.AgentSkill{ID: agent.Name, Name: agent.Name, Tags: []string{"agent"}})
+		if agent.Spec.Description != "" {
+			skills[0].Description = &agent.Spec.Description
+		}
+	}
+	return skills
+}
+
+// teamSkills describes the members of a team as skills
+func teamSkills(team *arkv1alpha1.Team) []server.AgentSkill {
+	skills := make([]server.AgentSkill, 0, len(team.Spec.Members))
+	for _, member := range team.Spec.Members {
+		skills = append(skills, server.AgentSkill{ID: member.Name, Name: member.Name, Tags: []string{member.Type}})
+	}
+	return skills
+}
+
+// a2aQueryTaskManager runs the tasks of one agent or team for a caller as Queries. Only the
+// queries the caller created are found as its tasks.
+type a2aQueryTaskManager struct {
+	client    client.Client
+	namespace string
+	target    arkv1alpha1.QueryTarget
+	caller    *a2aCaller
+}
+
+// OnSendMessage starts a task, or answers the question of a task waiting for input. The task is
+// returned once it finishes or needs input when the caller blocks, and right away otherwise.
+func (m *a2aQueryTaskManager) OnSendMessage(ctx context.Context, request protocol.SendMessageParams) (*protocol.MessageResult, error) {
+	query, err := m.startTask(ctx, request.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Configuration != nil && request.Configuration.Blocking != nil && *request.Configuration.Blocking {
+		if query, err = m.waitForQuery(ctx, query, nil); err != nil {
+			return nil, err
+		}
+	}
+	return &protocol.MessageResult{Result: a2aTaskFromQuery(query)}, nil
+}
+
+// OnSendMessageStream starts a task like OnSendMessage, streaming its updates until it finishes or
+// needs input
+func (m *a2aQueryTaskManager) OnSendMessageStream(ctx context.Context, request protocol.SendMessageParams) (<-chan protocol.StreamingMessageEvent, error) {
+	query, err := m.startTask(ctx, request.Message)
+	if err != nil {
+		return nil, err
+	}
+	return m.streamQuery(ctx, query), nil
+}
+
+// OnGetTask returns the task of the query
+func (m *a2aQueryTaskManager) OnGetTask(ctx context.Context, params protocol.TaskQueryParams) (*protocol.Task, error) {
+	query, err := m.getQuery(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	return a2aTaskFromQuery(query), nil
+}
+
+// OnCancelTask cancels the query of the task
+func (m *a2aQueryTaskManager) OnCancelTask(ctx context.Context, params protocol.TaskIDParams) (*protocol.Task, error) {
+	query, err := m.getQuery(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	if task := a2aTaskFromQuery(query); isA2ATaskFinished(task.Status.State) {
+		return nil, taskmanager.ErrTaskNotCancelable(params.ID, task.Status.State)
+	}
+
+	if err := m.updateQuery(ctx, query, func(query *arkv1alpha1.Query) { query.Spec.Cancel = true }); err != nil {
+		return nil, err
+	}
+	task := a2aTaskFromQuery(query)
+	task.Status = protocol.TaskStatus{State: protocol.TaskStateCanceled, Timestamp: time.Now().UTC().Format(time.RFC3339)}
+	return task, nil
+}
+
+// OnPushNotificationSet is not supported, callers stream or poll the tasks
+func (m *a2aQueryTaskManager) OnPushNotificationSet(context.Context, protocol.TaskPushNotificationConfig) (*protocol.TaskPushNotificationConfig, error) {
+	return nil, taskmanager.ErrPushNotificationNotSupported()
+}
+
+// OnPushNotificationGet is not supported, callers stream or poll the tasks
+func (m *a2aQueryTaskManager) OnPushNotificationGet(context.Context, protocol.TaskIDParams) (*protocol.TaskPushNotificationConfig, error) {
+	return nil, taskmanager.ErrPushNotificationNotSupported()
+}
+
+// OnResubscribe streams the updates of a task from its current state
+func (m *a2aQueryTaskManager) OnResubscribe(ctx context.Context, params protocol.TaskIDParams) (<-chan protocol.StreamingMessageEvent, error) {
+	query, err := m.getQuery(ctx, params.ID)
+	if err != nil {
+		return nil, err
+	}
+	return m.streamQuery(ctx, query), nil
+}
+
+// startTask creates the query of a new task. Messages for an existing task answer the question
+// of its query.
+func (m *a2aQueryTaskManager) startTask(ctx context.Context, message protocol.Message) (*arkv1alpha1.Query, error) {
+	text := extractTextFromParts(message.Parts)
+	if text == "" {
+		return nil, fmt.Errorf("message has no text")
+	}
+	if message.TaskID != nil && *message.TaskID != "" {
+		return m.answerInput(ctx, *message.TaskID, text)
+	}
+
+	input, err := json.Marshal(text)
+	if err != nil {
+		return nil, err
+	}
+	contextID := string(uuid.NewUUID())
+	if message.ContextID != nil && *message.ContextID != "" {
+		contextID = *message.ContextID
+	}
+	target := m.target
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: m.target.Name + "-a2a-",
+			Namespace:    m.namespace,
+			Labels:       map[string]string{labels.A2AEndpointLabel: "true"},
+			Annotations: map[string]string{
+				arkann.A2ACaller:          m.caller.username,
+				arkann.A2ACallerContextID: contextID,
+			},
+		},
+		Spec: arkv1alpha1.QuerySpec{
+			Input:          runtime.RawExtension{Raw: input},
+			Target:         &target,
+			ServiceAccount: m.caller.serviceAccount,
+			ConversationId: a2aConversationID(m.caller.username, contextID),
+		},
+	}
+	if err := m.client.Create(ctx, query); err != nil {
+		return nil, fmt.Errorf("failed to create query: %w", err)
+	}
+	return query, nil
+}
+
+// answerInput answers the first question of a query waiting for input
+func (m *a2aQueryTaskManager) answerInput(ctx context.Context, taskID, answer string) (*arkv1alpha1.Query, error) {
+	query, err := m.getQuery(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if query.Status.Phase != arkv1alpha1.QueryPhaseInputRequired || len(query.Status.PendingInputs) == 0 {
+		return nil, fmt.Errorf("task %s is not waiting for input", taskID)
+	}
+
+	requestID := query.Status.PendingInputs[0].ID
+	err = m.updateQuery(ctx, query, func(query *arkv1alpha1.Query) {
+		query.Spec.InputResponses = append(query.Spec.InputResponses, arkv1alpha1.InputResponse{RequestID: requestID, Answer: answer})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return query, nil
+}
+
+// getQuery returns the query of a task the caller sent to the target. Queries created by others
+// are not found, whether they were created through the endpoint or not.
+func (m *a2aQueryTaskManager) getQuery(ctx context.Context, taskID string) (*arkv1alpha1.Query, error) {
+	query := &arkv1alpha1.Query{}
+	if err := m.client.Get(ctx, client.ObjectKey{Name: taskID, Namespace: m.namespace}, query); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, taskmanager.ErrTaskNotFound(taskID)
+		}
+		return nil, err
+	}
+	if query.Spec.Target == nil || *query.Spec.Target != m.target {
+		return nil, taskmanager.ErrTaskNotFound(taskID)
+	}
+	if query.Labels[labels.A2AEndpointLabel] != "true" || query.Annotations[arkann.A2ACaller] != m.caller.username {
+		return nil, taskmanager.ErrTaskNotFound(taskID)
+	}
+	return query, nil
+}
+
+// a2aConversationID derives the conversation of the A2A context of a caller, so that callers
+// cannot join each other's conversations by sending the same context ID
+func a2aConversationID(caller, contextID string) string {
+	sum := sha256.Sum256([]byte(caller + "\n" + contextID))
+	return "a2a-" + hex.EncodeToString(sum[:16])
+}
+
+func (m *a2aQueryTaskManager) updateQuery(ctx context.Context, query *arkv1alpha1.Query, update func(*arkv1alpha1.Query)) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := m.client.Get(ctx, client.ObjectKeyFromObject(query), query); err != nil {
+			return err
+		}
+		update(query)
+		return m.client.Update(ctx, query)
+	})
+}
+
+// waitForQuery polls the query until its task finishes or needs input, passing the query to
+// onChange each time the task changes state
+func (m *a2aQueryTaskManager) waitForQuery(ctx context.Context, query *arkv1alpha1.Query, onChange func(*arkv1alpha1.Query)) (*arkv1alpha1.Query, error) {
+	ticker := time.NewTicker(a2aEndpointPollInterval)
+	defer ticker.Stop()
+
+	state := a2aTaskFromQuery(query).Status.State
+	for !isA2ATaskSettled(state) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		current := &arkv1alpha1.Query{}
+		if err := m.client.Get(ctx, client.ObjectKeyFromObject(query), current); err != nil {
+			return nil, err
+		}
+		query = current
+		if current := a2aTaskFromQuery(query).Status.State; current != state {
+			state = current
+			if onChange != nil {
+				onChange(query)
+			}
+		}
+	}
+	return query, nil
+}
+
+// streamQuery sends the task of the query, then its status updates, and finally its response as
+// an artifact
+func (m *a2aQueryTaskManager) streamQuery(ctx context.Context, query *arkv1alpha1.Query) <-chan protocol.StreamingMessageEvent {
+	events := make(chan protocol.StreamingMessageEvent, 1)
+	send := func(result protocol.StreamingMessageResult) bool {
+		select {
+		case events <- protocol.StreamingMessageEvent{Result: result}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(events)
+		if !send(a2aTaskFromQuery(query)) {
+			return
+		}
+
+		sendStatus := func(query *arkv1alpha1.Query) {
+			task := a2aTaskFromQuery(query)
+			for _, artifact := range task.Artifacts {
+				if !send(&protocol.TaskArtifactUpdateEvent{Kind: protocol.KindTaskArtifactUpdate, TaskID: task.ID, ContextID: task.ContextID, Artifact: artifact}) {
+					return
+				}
+			}
+			event := protocol.NewTaskStatusUpdateEvent(task.ID, task.ContextID, task.Status, isA2ATaskSettled(task.Status.State))
+			event.Metadata = task.Metadata
+			send(&event)
+		}
+		if _, err := m.waitForQuery(ctx, query, sendStatus); err != nil && ctx.Err() == nil {
+			logf.FromContext(ctx).Error(err, "failed to stream A2A task", "taskId", query.Name)
+		}
+	}()
+	return events
+}
+
+// a2aTaskFromQuery describes a query as an A2A task. The response of a finished query is its
+// artifact, and the error of a failed one or the question of one waiting for input its status
+// message. A query awaiting a tool approval is working, as the approval is made on the query
+// rather than by the caller.
+func a2aTaskFromQuery(query *arkv1alpha1.Query) *protocol.Task {
+	contextID := query.Annotations[arkann.A2ACallerContextID]
+	if contextID == "" {
+		contextID = query.Name
+	}
+
+	task := protocol.NewTask(query.Name, contextID)
+	var content string
+	if query.Status.Response != nil {
+		content = query.Status.Response.Content
+	}
+
+	switch query.Status.Phase {
+	case arkv1alpha1.QueryPhaseRunning, arkv1alpha1.QueryPhaseAwaitingApproval:
+		task.Status.State = protocol.TaskStateWorking
+	case arkv1alpha1.QueryPhaseInputRequired:
+		// The query keeps waiting for input until the controller picks up the answer
+		if len(query.Status.PendingInputs) == 0 || isInputAnswered(query, query.Status.PendingInputs[0].ID) {
+			task.Status.State = protocol.TaskStateWorking
+			break
+		}
+		task.Status.State = protocol.TaskStateInputRequired
+		task.Status.Message = a2aAgentMessage(task, query.Status.PendingInputs[0].Question)
+	case arkv1alpha1.QueryPhaseDone:
+		task.Status.State = protocol.TaskStateCompleted
+		task.Artifacts = []protocol.Artifact{{
+			ArtifactID: query.Name + "-response",
+			Parts:      []protocol.Part{protocol.NewTextPart(content)},
+		}}
+	case arkv1alpha1.QueryPhaseError, arkv1alpha1.QueryPhaseBudgetExceeded:
+		task.Status.State = protocol.TaskStateFailed
+		task.Status.Message = a2aAgentMessage(task, content)
+	case arkv1alpha1.QueryPhaseCanceled:
+		task.Status.State = protocol.TaskStateCanceled
+	default:
+		task.Status.State = protocol.TaskStateSubmitted
+	}
+
+	usage := query.Status.TokenUsage
+	if usage != (arkv1alpha1.TokenUsage{}) {
+		task.Metadata["usage"] = TokenUsage{
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+			TotalTokens:      usage.TotalTokens,
+		}
+	}
+	return task
+}
+
+func isInputAnswered(query *arkv1alpha1.Query, requestID string) bool {
+	return slices.ContainsFunc(query.Spec.InputResponses, func(response arkv1alpha1.InputResponse) bool {
+		return response.RequestID == requestID
+	})
+}
+
+func a2aAgentMessage(task *protocol.Task, text string) *protocol.Message {
+	if text == "" {
+		return nil
+	}
+	message := protocol.NewMessageWithContext(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart(text)}, &task.ID, &task.ContextID)
+	return &message
+}
+
+// isA2ATaskFinished returns whether the task reached a terminal state
+func isA2ATaskFinished(state protocol.TaskState) bool {
+	return state == protocol.TaskStateCompleted || state == protocol.TaskStateFailed || state == protocol.TaskStateCanceled
+}
+
+// isA2ATaskSettled returns whether the task finished or waits for the caller
+func isA2ATaskSettled(state protocol.TaskState) bool {
+	return isA2ATaskFinished(state) || state == protocol.TaskStateInputRequired
+}
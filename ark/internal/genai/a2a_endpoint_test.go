@@ -0,0 +1,380 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	arkann "mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/labels"
+)
+
+// a2aEndpointTestUsers are the users the API server authenticates the test tokens as. Only the
+// service accounts of the default namespace other than weather-viewer can create queries.
+var a2aEndpointTestUsers = map[string]string{
+	"weather-client-token": "system:serviceaccount:default:weather-client",
+	"travel-client-token":  "system:serviceaccount:default:travel-client",
+	"viewer-token":         "system:serviceaccount:default:weather-viewer",
+	"team-b-token":         "system:serviceaccount:team-b:weather-client",
+	"user-token":           "jane@example.com",
+	"api-server-token":     "system:serviceaccount:default:weather-client",
+}
+
+// a2aEndpointTestAudiences are the audiences the test tokens are issued for, ark-a2a unless listed
+var a2aEndpointTestAudiences = map[string]string{
+	"api-server-token": "https://kubernetes.default.svc",
+}
+
+// reviewA2AEndpointTestTokens answers token and access reviews as an API server would whose
+// authenticator does not reject tokens of other audiences, but leaves them out of the status
+func reviewA2AEndpointTestTokens(ctx context.Context, k8sClient client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+	switch review := obj.(type) {
+	case *authenticationv1.TokenReview:
+		username, ok := a2aEndpointTestUsers[review.Spec.Token]
+		review.Status = authenticationv1.TokenReviewStatus{Authenticated: ok, User: authenticationv1.UserInfo{Username: username}}
+		audience, issued := a2aEndpointTestAudiences[review.Spec.Token]
+		if !issued {
+			audience = "ark-a2a"
+		}
+		if slices.Contains(review.Spec.Audiences, audience) {
+			review.Status.Audiences = []string{audience}
+		}
+		return nil
+	case *authorizationv1.SubjectAccessReview:
+		user := review.Spec.User
+		review.Status.Allowed = strings.HasPrefix(user, "system:serviceaccount:default:") && user != "system:serviceaccount:default:weather-viewer"
+		return nil
+	}
+	return k8sClient.Create(ctx, obj, opts...)
+}
+
+// a2aEndpointQuery is a query the weather-client service account sent to weather-agent
+func a2aEndpointQuery(name string, status arkv1alpha1.QueryStatus) *arkv1alpha1.Query {
+	return &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Labels:      map[string]string{labels.A2AEndpointLabel: "true"},
+			Annotations: map[string]string{arkann.A2ACaller: "system:serviceaccount:default:weather-client"},
+		},
+		Spec:   arkv1alpha1.QuerySpec{Target: &arkv1alpha1.QueryTarget{Type: "agent", Name: "weather-agent"}},
+		Status: status,
+	}
+}
+
+func newA2AEndpointTestClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	require.NoError(t, authenticationv1.AddToScheme(scheme))
+	require.NoError(t, authorizationv1.AddToScheme(scheme))
+	exposed := map[string]string{arkann.A2AExpose: "true"}
+	objects = append(objects,
+		&arkv1alpha1.Agent{
+			ObjectMeta: metav1.ObjectMeta{Name: "weather-agent", Namespace: "default", Annotations: exposed, Generation: 3},
+			Spec: arkv1alpha1.AgentSpec{
+				Description: "Forecasts the weather",
+				Tools:       []arkv1alpha1.AgentTool{{Type: "custom", Name: "get-forecast", Description: "Gets the forecast of a city"}},
+			},
+		},
+		&arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "internal-agent", Namespace: "default"}},
+		&arkv1alpha1.Team{
+			ObjectMeta: metav1.ObjectMeta{Name: "travel-team", Namespace: "default", Annotations: exposed},
+			Spec:       arkv1alpha1.TeamSpec{Members: []arkv1alpha1.TeamMember{{Name: "weather-agent", Type: "agent"}}},
+		},
+	)
+	return fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&arkv1alpha1.Query{}).WithObjects(objects...).
+		WithInterceptorFuncs(interceptor.Funcs{Create: reviewA2AEndpointTestTokens}).Build()
+}
+
+func newA2AEndpointTestServer(t *testing.T, k8sClient client.Client) *httptest.Server {
+	endpoint := NewA2AEndpoint(":0", "http://ark-a2a.example.com/", "ark-a2a", k8sClient)
+	testServer := httptest.NewServer(endpoint.Handler())
+	t.Cleanup(testServer.Close)
+	return testServer
+}
+
+// newA2AEndpointTestA2AClient creates an A2A client of the agent or team at the path, which
+// authenticates with the token
+func newA2AEndpointTestA2AClient(t *testing.T, testServer *httptest.Server, path, token string) *a2aclient.A2AClient {
+	a2aClient, err := a2aclient.NewA2AClient(testServer.URL+path, a2aclient.WithHTTPReqHandler(&customA2ARequestHandler{
+		headers: map[string]string{"Authorization": "Bearer " + token},
+	}))
+	require.NoError(t, err)
+	return a2aClient
+}
+
+// completeQueries answers the unfinished queries of the test with the response, as the query
+// controller would
+func completeQueries(t *testing.T, k8sClient client.Client, response string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		var queries arkv1alpha1.QueryList
+		require.NoError(t, k8sClient.List(context.Background(), &queries))
+		completed := 0
+		for i := range queries.Items {
+			query := &queries.Items[i]
+			if query.Status.Phase == arkv1alpha1.QueryPhaseDone {
+				continue
+			}
+			completed++
+			query.Status.Phase = arkv1alpha1.QueryPhaseDone
+			query.Status.Response = &arkv1alpha1.Response{Content: response}
+			query.Status.TokenUsage = arkv1alpha1.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}
+			require.NoError(t, k8sClient.Status().Update(context.Background(), query))
+		}
+		return completed > 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestA2AEndpointServesAgentCards(t *testing.T) {
+	testServer := newA2AEndpointTestServer(t, newA2AEndpointTestClient(t))
+
+	get := func(path string) (*http.Response, server.AgentCard) {
+		resp, err := http.Get(testServer.URL + path)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		var card server.AgentCard
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&card))
+		}
+		return resp, card
+	}
+
+	resp, card := get("/a2a/default/agents/weather-agent/.well-known/agent-card.json")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "weather-agent", card.Name)
+	assert.Equal(t, "Forecasts the weather", card.Description)
+	assert.Equal(t, "http://ark-a2a.example.com/a2a/default/agents/weather-agent", card.URL)
+	assert.Equal(t, "3", card.Version)
+	assert.True(t, *card.Capabilities.Streaming)
+	require.Len(t, card.Skills, 1)
+	assert.Equal(t, "get-forecast", card.Skills[0].ID)
+	assert.Equal(t, "Gets the forecast of a city", *card.Skills[0].Description)
+
+	resp, card = get("/a2a/default/teams/travel-team/.well-known/agent.json")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "Ark team travel-team", card.Description)
+	require.Len(t, card.Skills, 1)
+	assert.Equal(t, "weather-agent", card.Skills[0].Name)
+
+	resp, _ = get("/a2a/default/agents/internal-agent/.well-known/agent-card.json")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "agents are only served when exposed")
+	resp, _ = get("/a2a/default/models/gpt-4o/.well-known/agent-card.json")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestA2AEndpointRunsMessagesAsQueries(t *testing.T) {
+	k8sClient := newA2AEndpointTestClient(t)
+	testServer := newA2AEndpointTestServer(t, k8sClient)
+	a2aClient := newA2AEndpointTestA2AClient(t, testServer, "/a2a/default/agents/weather-agent", "weather-client-token")
+
+	contextID := "conversation-1"
+	message := protocol.NewMessageWithContext(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart("Weather in Paris?")}, nil, &contextID)
+	result, err := a2aClient.SendMessage(context.Background(), protocol.SendMessageParams{Message: message})
+	require.NoError(t, err)
+	task, ok := result.Result.(*protocol.Task)
+	require.True(t, ok)
+	assert.Equal(t, protocol.TaskStateSubmitted, task.Status.State, "messages do not wait for the query unless blocking")
+	assert.Equal(t, contextID, task.ContextID)
+
+	var query arkv1alpha1.Query
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: task.ID, Namespace: "default"}, &query))
+	assert.Equal(t, &arkv1alpha1.QueryTarget{Type: "agent", Name: "weather-agent"}, query.Spec.Target)
+	assert.JSONEq(t, `"Weather in Paris?"`, string(query.Spec.Input.Raw))
+	assert.Equal(t, "weather-client", query.Spec.ServiceAccount, "queries run as their caller")
+	assert.Equal(t, "true", query.Labels[labels.A2AEndpointLabel])
+	assert.Equal(t, "system:serviceaccount:default:weather-client", query.Annotations[arkann.A2ACaller])
+	assert.Equal(t, a2aConversationID("system:serviceaccount:default:weather-client", contextID), query.Spec.ConversationId)
+
+	completeQueries(t, k8sClient, "Sunny")
+	task, err = a2aClient.GetTasks(context.Background(), protocol.TaskQueryParams{ID: task.ID})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCompleted, task.Status.State)
+	text, err := extractTextFromTask(task)
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny", text)
+
+	_, err = a2aClient.CancelTasks(context.Background(), protocol.TaskIDParams{ID: task.ID})
+	assert.ErrorContains(t, err, "cannot be canceled")
+	_, err = a2aClient.GetTasks(context.Background(), protocol.TaskQueryParams{ID: "other-query"})
+	assert.ErrorContains(t, err, "not found")
+
+	teamClient := newA2AEndpointTestA2AClient(t, testServer, "/a2a/default/teams/travel-team/", "weather-client-token")
+	_, err = teamClient.GetTasks(context.Background(), protocol.TaskQueryParams{ID: task.ID})
+	assert.ErrorContains(t, err, "not found", "tasks are only found for the agent or team they were sent to")
+}
+
+func TestA2AEndpointAnswersArkA2AClients(t *testing.T) {
+	k8sClient := newA2AEndpointTestClient(t)
+	testServer := newA2AEndpointTestServer(t, k8sClient)
+	a2aClient := newA2AEndpointTestA2AClient(t, testServer, "/a2a/default/agents/weather-agent", "weather-client-token")
+
+	go completeQueries(t, k8sClient, "Sunny in Paris")
+	response, err := executeA2AAgentMessage(context.Background(), newA2ATaskTestClient(t), a2aClient, "Weather in Paris?", "weather-agent", "default", "caller-query", "", nil, nil, A2AExecutionOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny in Paris", response.Content)
+	assert.Equal(t, int64(15), response.TokenUsage.TotalTokens)
+
+	go completeQueries(t, k8sClient, "Sunny in Paris")
+	var texts []string
+	options := A2AExecutionOptions{Streaming: true, OnText: func(text string) { texts = append(texts, text) }}
+	response, err = executeA2AAgentMessage(context.Background(), newA2ATaskTestClient(t), a2aClient, "Weather in Paris?", "weather-agent", "default", "caller-query", "", nil, nil, options)
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny in Paris", response.Content)
+	assert.Equal(t, []string{"Sunny in Paris"}, texts)
+}
+
+func TestA2AEndpointAnswersInputRequests(t *testing.T) {
+	query := a2aEndpointQuery("weather-agent-a2a-x1", arkv1alpha1.QueryStatus{
+		Phase:         arkv1alpha1.QueryPhaseInputRequired,
+		PendingInputs: []arkv1alpha1.PendingInput{{ID: "input-1", Question: "Which city?"}},
+	})
+	k8sClient := newA2AEndpointTestClient(t, query)
+	testServer := newA2AEndpointTestServer(t, k8sClient)
+	a2aClient := newA2AEndpointTestA2AClient(t, testServer, "/a2a/default/agents/weather-agent", "weather-client-token")
+
+	task, err := a2aClient.GetTasks(context.Background(), protocol.TaskQueryParams{ID: query.Name})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateInputRequired, task.Status.State)
+	assert.Equal(t, "Which city?", a2aInputQuestion(task))
+
+	message := protocol.NewMessageWithContext(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart("Paris")}, &task.ID, &task.ContextID)
+	result, err := a2aClient.SendMessage(context.Background(), protocol.SendMessageParams{Message: message})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateWorking, result.Result.(*protocol.Task).Status.State, "answered tasks work until the query picks up the answer")
+
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(query), query))
+	assert.Equal(t, []arkv1alpha1.InputResponse{{RequestID: "input-1", Answer: "Paris"}}, query.Spec.InputResponses)
+
+	task, err = a2aClient.CancelTasks(context.Background(), protocol.TaskIDParams{ID: query.Name})
+	require.NoError(t, err)
+	assert.Equal(t, protocol.TaskStateCanceled, task.Status.State)
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKeyFromObject(query), query))
+	assert.True(t, query.Spec.Cancel)
+}
+
+func TestA2AEndpointAuthenticatesCallers(t *testing.T) {
+	query := a2aEndpointQuery("weather-agent-a2a-x1", arkv1alpha1.QueryStatus{Phase: arkv1alpha1.QueryPhaseRunning})
+	testServer := newA2AEndpointTestServer(t, newA2AEndpointTestClient(t, query))
+
+	tests := []struct {
+		name   string
+		token  string
+		status int
+	}{
+		{name: "no token", status: http.StatusUnauthorized},
+		{name: "invalid token", token: "expired-token", status: http.StatusUnauthorized},
+		{name: "token of another audience", token: "api-server-token", status: http.StatusUnauthorized},
+		{name: "service account of another namespace", token: "team-b-token", status: http.StatusForbidden},
+		{name: "user", token: "user-token", status: http.StatusForbidden},
+		{name: "service account that cannot create queries", token: "viewer-token", status: http.StatusForbidden},
+		{name: "service account that can create queries", token: "weather-client-token", status: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"jsonrpc":"2.0","id":1,"method":"tasks/get","params":{"id":"weather-agent-a2a-x1"}}`
+			request, err := http.NewRequest(http.MethodPost, testServer.URL+"/a2a/default/agents/weather-agent", strings.NewReader(body))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/json")
+			if tt.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			resp, err := http.DefaultClient.Do(request)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			assert.Equal(t, tt.status, resp.StatusCode)
+		})
+	}
+}
+
+func TestA2AEndpointScopesTasksToTheirCaller(t *testing.T) {
+	query := a2aEndpointQuery("weather-agent-a2a-x1", arkv1alpha1.QueryStatus{
+		Phase:         arkv1alpha1.QueryPhaseInputRequired,
+		PendingInputs: []arkv1alpha1.PendingInput{{ID: "input-1", Question: "Which city?"}},
+	})
+	direct := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather-query", Namespace: "default"},
+		Spec:       arkv1alpha1.QuerySpec{Target: &arkv1alpha1.QueryTarget{Type: "agent", Name: "weather-agent"}},
+	}
+	k8sClient := newA2AEndpointTestClient(t, query, direct)
+	testServer := newA2AEndpointTestServer(t, k8sClient)
+	a2aClient := newA2AEndpointTestA2AClient(t, testServer, "/a2a/default/agents/weather-agent", "travel-client-token")
+	ctx := context.Background()
+
+	for _, taskID := range []string{query.Name, direct.Name} {
+		_, err := a2aClient.GetTasks(ctx, protocol.TaskQueryParams{ID: taskID})
+		assert.ErrorContains(t, err, "not found")
+		_, err = a2aClient.CancelTasks(ctx, protocol.TaskIDParams{ID: taskID})
+		assert.ErrorContains(t, err, "not found")
+		_, err = a2aClient.ResubscribeTask(ctx, protocol.TaskIDParams{ID: taskID})
+		assert.ErrorContains(t, err, "not found")
+		message := protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart("Paris")})
+		message.TaskID = &taskID
+		_, err = a2aClient.SendMessage(ctx, protocol.SendMessageParams{Message: message})
+		assert.ErrorContains(t, err, "not found")
+	}
+
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(query), query))
+	assert.False(t, query.Spec.Cancel)
+	assert.Empty(t, query.Spec.InputResponses)
+
+	contextID := "conversation-1"
+	message := protocol.NewMessageWithContext(protocol.MessageRoleUser, []protocol.Part{protocol.NewTextPart("Weather in Paris?")}, nil, &contextID)
+	result, err := a2aClient.SendMessage(ctx, protocol.SendMessageParams{Message: message})
+	require.NoError(t, err)
+	task := result.Result.(*protocol.Task)
+	assert.Equal(t, contextID, task.ContextID)
+	var created arkv1alpha1.Query
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{Name: task.ID, Namespace: "default"}, &created))
+	assert.NotEqual(t, a2aConversationID("system:serviceaccount:default:weather-client", contextID), created.Spec.ConversationId,
+		"callers sending the same context ID do not share a conversation")
+}
+
+func TestA2ATaskFromQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  arkv1alpha1.QueryStatus
+		state   protocol.TaskState
+		message string
+	}{
+		{name: "pending", state: protocol.TaskStateSubmitted},
+		{name: "running", status: arkv1alpha1.QueryStatus{Phase: arkv1alpha1.QueryPhaseRunning}, state: protocol.TaskStateWorking},
+		{name: "awaiting approval", status: arkv1alpha1.QueryStatus{Phase: arkv1alpha1.QueryPhaseAwaitingApproval}, state: protocol.TaskStateWorking},
+		{name: "error", status: arkv1alpha1.QueryStatus{Phase: arkv1alpha1.QueryPhaseError, Response: &arkv1alpha1.Response{Content: "model unavailable"}}, state: protocol.TaskStateFailed, message: "model unavailable"},
+		{name: "budget exceeded", status: arkv1alpha1.QueryStatus{Phase: arkv1alpha1.QueryPhaseBudgetExceeded}, state: protocol.TaskStateFailed},
+		{name: "canceled", status: arkv1alpha1.QueryStatus{Phase: arkv1alpha1.QueryPhaseCanceled}, state: protocol.TaskStateCanceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := a2aTaskFromQuery(&arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "q"}, Status: tt.status})
+			assert.Equal(t, tt.state, task.Status.State)
+			assert.Equal(t, "q", task.ContextID, "queries without a conversation are their own context")
+			if tt.message != "" {
+				assert.Equal(t, tt.message, extractTextFromParts(task.Status.Message.Parts))
+			}
+		})
+	}
+}
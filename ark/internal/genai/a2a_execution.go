@@ -4,12 +4,13 @@ package genai
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
-	"github.com/openai/openai-go"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"trpc.group/trpc-go/trpc-a2a-go/server"
 
 	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
 	arkann "mckinsey.com/ark/internal/annotations"
@@ -20,6 +21,7 @@ import (
 type A2AExecutionEngine struct {
 	client           client.Client
 	eventingRecorder eventing.A2aRecorder
+	headers          map[string]string
 }
 
 // NewA2AExecutionEngine creates a new A2A execution engine
@@ -30,6 +32,11 @@ func NewA2AExecutionEngine(k8sClient client.Client, eventingRecorder eventing.A2
 	}
 }
 
+// SetHeaders sets the headers sent to the A2A server on top of its own, such as those set by overrides
+func (e *A2AExecutionEngine) SetHeaders(headers map[string]string) {
+	e.headers = headers
+}
+
 // Execute executes a query against an A2A agent
 func (e *A2AExecutionEngine) Execute(ctx context.Context, agentName, namespace string, agentAnnotations map[string]string, contextID string, userInput Message, eventStream EventStreamInterface) (*ExecutionResult, error) {
 	log := logf.FromContext(ctx)
@@ -79,11 +86,26 @@ func (e *A2AExecutionEngine) Execute(ctx context.Context, agentName, namespace s
 		content = userInput.OfUser.Content.OfString.Value
 	}
 
+	// Use "agent/name" format as per OpenAI-compatible endpoints
+	modelID := fmt.Sprintf("agent/%s", agentName)
+
+	// Forward the text of streamed updates as they arrive
+	options := a2aExecutionOptions(ctx, agentAnnotations)
+	options.Headers = e.headers
+	streamed := false
+	if options.Streaming && eventStream != nil {
+		options.OnText = func(text string) {
+			streamed = true
+			if err := eventStream.StreamChunk(ctx, a2aChunk(ctx, modelID, text, "")); err != nil {
+				log.Error(err, "failed to send A2A update chunk to event stream")
+			}
+		}
+	}
+
 	// Execute A2A agent
 	queryName := getQueryName(ctx)
-	a2aResponse, err := ExecuteA2AAgent(ctx, e.client, a2aAddress, a2aServer.Spec.Headers, namespace, content, agentName, queryName, contextID, e.eventingRecorder, &a2aServer)
+	a2aResponse, err := ExecuteA2AAgent(ctx, e.client, a2aAddress, a2aServer.Spec.Headers, namespace, content, agentName, queryName, contextID, e.eventingRecorder, &a2aServer, options)
 	if err != nil {
-		modelID := fmt.Sprintf("agent/%s", agentName)
 		StreamError(ctx, eventStream, err, "a2a_execution_failed", modelID)
 		e.eventingRecorder.Fail(ctx, "A2AExecution", fmt.Sprintf("A2A execution failed: %v", err), err, operationData)
 		return nil, err
@@ -92,33 +114,14 @@ func (e *A2AExecutionEngine) Execute(ctx context.Context, agentName, namespace s
 	// Convert response to genai.Message format
 	responseMessage := NewAssistantMessage(a2aResponse.Content)
 
-	// The A2A execution engine does not yet support streaming responses - if streaming
-	// was requested then the final response must be sent as a single chunk, as per the spec.
+	// Agents that do not stream, or streamed no text, send the final response as a single
+	// chunk, as per the spec. Streamed responses only need to be finished.
 	if eventStream != nil {
-		// Use query ID as completion ID (all chunks for a query share the same ID)
-		completionID := getQueryID(ctx)
-		// Use "agent/name" format as per OpenAI-compatible endpoints
-		modelID := fmt.Sprintf("agent/%s", agentName)
-
-		chunk := &openai.ChatCompletionChunk{
-			ID:      completionID,
-			Object:  "chat.completion.chunk",
-			Created: time.Now().Unix(),
-			Model:   modelID,
-			Choices: []openai.ChatCompletionChunkChoice{
-				{
-					Index: 0,
-					Delta: openai.ChatCompletionChunkChoiceDelta{
-						Content: a2aResponse.Content,
-						Role:    "assistant",
-					},
-					FinishReason: "stop",
-				},
-			},
+		finalContent := a2aResponse.Content
+		if streamed {
+			finalContent = ""
 		}
-
-		chunkWithMeta := WrapChunkWithMetadata(ctx, chunk, modelID, nil)
-		if err := eventStream.StreamChunk(ctx, chunkWithMeta); err != nil {
+		if err := eventStream.StreamChunk(ctx, a2aChunk(ctx, modelID, finalContent, "stop")); err != nil {
 			log.Error(err, "failed to send A2A response chunk to event stream")
 		}
 	}
@@ -130,3 +133,31 @@ func (e *A2AExecutionEngine) Execute(ctx context.Context, agentName, namespace s
 		A2AResponse: a2aResponse,
 	}, nil
 }
+
+// a2aExecutionOptions enables the streaming and push notification capabilities the agent card
+// advertised when the agent was discovered. Push notifications also need the controller to
+// serve the push notification endpoint.
+func a2aExecutionOptions(ctx context.Context, agentAnnotations map[string]string) A2AExecutionOptions {
+	var capabilities server.AgentCapabilities
+	if value, ok := agentAnnotations[arkann.A2AServerCapabilities]; ok {
+		if err := json.Unmarshal([]byte(value), &capabilities); err != nil {
+			logf.FromContext(ctx).Error(err, "ignoring invalid A2A capabilities annotation")
+		}
+	}
+
+	var options A2AExecutionOptions
+	options.Streaming = capabilities.Streaming != nil && *capabilities.Streaming
+	if capabilities.PushNotifications != nil && *capabilities.PushNotifications {
+		options.PushNotifications = GetA2APushNotificationServer(ctx)
+	}
+	return options
+}
+
+// a2aChunk returns a completion chunk with the content of an A2A response. All chunks of a query
+// use the query ID as completion ID.
+func a2aChunk(ctx context.Context, modelID, content, finishReason string) interface{} {
+	chunk := NewContentChunk(getQueryID(ctx), modelID, content)
+	chunk.Choices[0].Delta.Role = RoleAssistant
+	chunk.Choices[0].FinishReason = finishReason
+	return WrapChunkWithMetadata(ctx, chunk, modelID, nil)
+}
@@ -0,0 +1,158 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// A2APushNotificationPath is the path of the endpoint A2A servers send task updates to,
+// followed by the namespace of the task and the name of the A2AServer
+const A2APushNotificationPath = "/v1/a2a/notifications"
+
+// a2aNotificationTokenHeader carries the token of a push notification config, as per the A2A
+// protocol
+const a2aNotificationTokenHeader = "X-A2A-Notification-Token"
+
+const (
+	// minA2APushNotificationKeySize is the length of the shortest key tokens are derived from
+	minA2APushNotificationKeySize = 16
+
+	// maxA2APushNotificationSize bounds the tasks A2A servers push, which carry their artifacts
+	maxA2APushNotificationSize = 4 << 20
+)
+
+type a2aPushNotificationServerKeyType struct{}
+
+var a2aPushNotificationServerKey = a2aPushNotificationServerKeyType{}
+
+// A2APushNotificationServer receives the task updates pushed by A2A servers and records them on
+// the A2ATask of each task, so that the servers do not have to be polled
+type A2APushNotificationServer struct {
+	bindAddress string
+	url         string
+	client      client.Client
+	key         []byte
+}
+
+// NewA2APushNotificationServer creates a server listening on bindAddress, which A2A servers
+// reach at url. Tokens are derived from key, which every replica of the controller shares so
+// that tokens stay valid across replicas and restarts.
+func NewA2APushNotificationServer(bindAddress, url string, key []byte, k8sClient client.Client) (*A2APushNotificationServer, error) {
+	if len(key) < minA2APushNotificationKeySize {
+		return nil, fmt.Errorf("push notification key must be at least %d bytes long", minA2APushNotificationKeySize)
+	}
+	return &A2APushNotificationServer{
+		bindAddress: bindAddress,
+		url:         strings.TrimSuffix(url, "/"),
+		client:      k8sClient,
+		key:         key,
+	}, nil
+}
+
+// WithA2APushNotificationServer makes the server available to the A2A agents executed with the
+// context
+func WithA2APushNotificationServer(ctx context.Context, server *A2APushNotificationServer) context.Context {
+	return context.WithValue(ctx, a2aPushNotificationServerKey, server)
+}
+
+// GetA2APushNotificationServer returns the server of the context, if any
+func GetA2APushNotificationServer(ctx context.Context) *A2APushNotificationServer {
+	server, _ := ctx.Value(a2aPushNotificationServerKey).(*A2APushNotificationServer)
+	return server
+}
+
+// Start receives push notifications until the context is done. It implements manager.Runnable.
+func (s *A2APushNotificationServer) Start(ctx context.Context) error {
+	logf.FromContext(ctx).Info("starting A2A push notification server", "address", s.bindAddress, "url", s.url)
+	return serveHTTP(ctx, s.bindAddress, s.Handler())
+}
+
+// NeedLeaderElection returns false, so that the server is ready as soon as the manager starts
+func (s *A2APushNotificationServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the HTTP handler of the push notification endpoint
+func (s *A2APushNotificationServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+A2APushNotificationPath+"/{namespace}/{server}", s.handleNotification)
+	return mux
+}
+
+// Config returns the push notification config the A2AServer uses to report the tasks it runs
+// for the namespace. The token is only accepted for the tasks of that A2AServer in that
+// namespace, as the ID of a task is not known before the server creates it.
+func (s *A2APushNotificationServer) Config(namespace, a2aServer string) *protocol.PushNotificationConfig {
+	return &protocol.PushNotificationConfig{
+		URL:   s.url + A2APushNotificationPath + "/" + namespace + "/" + a2aServer,
+		Token: s.token(namespace, a2aServer),
+	}
+}
+
+func (s *A2APushNotificationServer) token(namespace, a2aServer string) string {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write([]byte(namespace + "/" + a2aServer))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *A2APushNotificationServer) handleNotification(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	a2aServer := r.PathValue("server")
+	token := r.Header.Get(a2aNotificationTokenHeader)
+	if token == "" || !hmac.Equal([]byte(token), []byte(s.token(namespace, a2aServer))) {
+		http.Error(w, "invalid notification token", http.StatusUnauthorized)
+		return
+	}
+
+	var task protocol.Task
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxA2APushNotificationSize)).Decode(&task); err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, fmt.Sprintf("invalid task: %v", err), status)
+		return
+	}
+	if task.ID == "" {
+		http.Error(w, "task id is required", http.StatusBadRequest)
+		return
+	}
+
+	// Servers may only report their own tasks, which are not told apart from unknown ones
+	var a2aTask arkv1alpha1.A2ATask
+	err := s.client.Get(r.Context(), client.ObjectKey{Name: a2aTaskName(task.ID), Namespace: namespace}, &a2aTask)
+	if err == nil && a2aTask.Spec.A2AServerRef.Name != a2aServer {
+		http.Error(w, fmt.Sprintf("unknown task %s", task.ID), http.StatusNotFound)
+		return
+	}
+	if err == nil {
+		err = updateA2ATaskStatus(r.Context(), s.client, namespace, &task)
+	}
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("unknown task %s", task.ID), http.StatusNotFound)
+			return
+		}
+		logf.FromContext(r.Context()).Error(err, "failed to record A2A push notification", "taskId", task.ID, "namespace", namespace)
+		http.Error(w, "failed to record task update", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,128 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// testPushNotificationKey is the key shared by the controller replicas in the tests
+var testPushNotificationKey = []byte("0123456789abcdef0123456789abcdef")
+
+func postPushNotification(t *testing.T, handler http.Handler, namespace, a2aServer, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, A2APushNotificationPath+"/"+namespace+"/"+a2aServer, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("X-A2A-Notification-Token", token)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestA2APushNotificationServer_RecordsTaskUpdates(t *testing.T) {
+	k8sClient := newA2ATaskTestClient(t)
+	require.NoError(t, k8sClient.Create(context.Background(), &arkv1alpha1.A2ATask{
+		ObjectMeta: metav1.ObjectMeta{Name: "a2a-task-task-1", Namespace: "team-a"},
+		Spec: arkv1alpha1.A2ATaskSpec{
+			TaskID:       "task-1",
+			A2AServerRef: arkv1alpha1.A2AServerRef{Name: "weather-server", Namespace: "team-a"},
+			UpdateMode:   A2ATaskUpdateModePush,
+		},
+		Status: arkv1alpha1.A2ATaskStatus{Phase: PhaseRunning},
+	}))
+
+	server, err := NewA2APushNotificationServer(":0", "http://localhost:8083/", testPushNotificationKey, k8sClient)
+	require.NoError(t, err)
+	config := server.Config("team-a", "weather-server")
+	assert.Equal(t, "http://localhost:8083/v1/a2a/notifications/team-a/weather-server", config.URL)
+
+	body := `{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":"completed","timestamp":"2025-01-15T10:35:00Z"},
+		"artifacts":[{"artifactId":"a1","parts":[{"kind":"text","text":"Sunny in Paris"}]}]}`
+	recorder := postPushNotification(t, server.Handler(), "team-a", "weather-server", config.Token, body)
+	require.Equal(t, http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	var a2aTask arkv1alpha1.A2ATask
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "a2a-task-task-1", Namespace: "team-a"}, &a2aTask))
+	assert.Equal(t, PhaseCompleted, a2aTask.Status.Phase)
+	assert.Equal(t, "2025-01-15T10:35:00Z", a2aTask.Status.LastStatusTimestamp)
+	require.Len(t, a2aTask.Status.Artifacts, 1)
+	assert.Equal(t, "Sunny in Paris", a2aTask.Status.Artifacts[0].Parts[0].Text)
+
+	recorder = postPushNotification(t, server.Handler(), "team-a", "weather-server", config.Token, `{"kind":"task","id":"task-2","status":{"state":"working"}}`)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	recorder = postPushNotification(t, server.Handler(), "team-a", "weather-server", config.Token, `{"kind":"task","status":{"state":"working"}}`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	oversized := `{"kind":"task","id":"task-1","status":{"state":"working"},"metadata":{"padding":"` + strings.Repeat("a", maxA2APushNotificationSize) + `"}}`
+	recorder = postPushNotification(t, server.Handler(), "team-a", "weather-server", config.Token, oversized)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+
+	// Another server of the namespace cannot report the task
+	other := server.Config("team-a", "travel-server")
+	recorder = postPushNotification(t, server.Handler(), "team-a", "travel-server", other.Token, `{"kind":"task","id":"task-1","status":{"state":"failed"}}`)
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "a2a-task-task-1", Namespace: "team-a"}, &a2aTask))
+	assert.Equal(t, PhaseCompleted, a2aTask.Status.Phase)
+}
+
+func TestA2APushNotificationServer_KeepsCancelledTasks(t *testing.T) {
+	k8sClient := newA2ATaskTestClient(t)
+	require.NoError(t, k8sClient.Create(context.Background(), &arkv1alpha1.A2ATask{
+		ObjectMeta: metav1.ObjectMeta{Name: "a2a-task-task-1", Namespace: "team-a"},
+		Spec: arkv1alpha1.A2ATaskSpec{
+			TaskID:       "task-1",
+			A2AServerRef: arkv1alpha1.A2AServerRef{Name: "weather-server", Namespace: "team-a"},
+			UpdateMode:   A2ATaskUpdateModePush,
+		},
+		Status: arkv1alpha1.A2ATaskStatus{Phase: PhaseCancelled, LastStatusTimestamp: "2025-01-15T10:30:00Z"},
+	}))
+
+	server, err := NewA2APushNotificationServer(":0", "http://localhost:8083/", testPushNotificationKey, k8sClient)
+	require.NoError(t, err)
+	config := server.Config("team-a", "weather-server")
+
+	// The server keeps working on the task after Ark cancelled it
+	body := `{"kind":"task","id":"task-1","contextId":"ctx-1","status":{"state":"working","timestamp":"2025-01-15T10:35:00Z"}}`
+	recorder := postPushNotification(t, server.Handler(), "team-a", "weather-server", config.Token, body)
+	require.Equal(t, http.StatusNoContent, recorder.Code, recorder.Body.String())
+
+	var a2aTask arkv1alpha1.A2ATask
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "a2a-task-task-1", Namespace: "team-a"}, &a2aTask))
+	assert.Equal(t, PhaseCancelled, a2aTask.Status.Phase)
+	assert.Equal(t, "2025-01-15T10:30:00Z", a2aTask.Status.LastStatusTimestamp)
+}
+
+func TestA2APushNotificationServer_RejectsInvalidTokens(t *testing.T) {
+	server, err := NewA2APushNotificationServer(":0", "http://localhost:8083", testPushNotificationKey, newA2ATaskTestClient(t))
+	require.NoError(t, err)
+	replica, err := NewA2APushNotificationServer(":0", "http://localhost:8083", testPushNotificationKey, nil)
+	require.NoError(t, err)
+	other, err := NewA2APushNotificationServer(":0", "http://localhost:8083", []byte("fedcba9876543210fedcba9876543210"), nil)
+	require.NoError(t, err)
+
+	body := `{"kind":"task","id":"task-1","status":{"state":"completed"}}`
+	assert.Equal(t, http.StatusUnauthorized, postPushNotification(t, server.Handler(), "team-a", "weather-server", "", body).Code)
+	assert.Equal(t, http.StatusUnauthorized, postPushNotification(t, server.Handler(), "team-a", "weather-server", server.Config("team-b", "weather-server").Token, body).Code,
+		"tokens are only valid for their namespace")
+	assert.Equal(t, http.StatusUnauthorized, postPushNotification(t, server.Handler(), "team-a", "weather-server", server.Config("team-a", "travel-server").Token, body).Code,
+		"tokens are only valid for their A2AServer")
+	assert.Equal(t, http.StatusUnauthorized, postPushNotification(t, server.Handler(), "team-a", "weather-server", other.Config("team-a", "weather-server").Token, body).Code,
+		"tokens are only valid for servers sharing the key")
+	assert.Equal(t, replica.Config("team-a", "weather-server").Token, server.Config("team-a", "weather-server").Token,
+		"replicas sharing the key accept each other's tokens")
+
+	_, err = NewA2APushNotificationServer(":0", "http://localhost:8083", []byte("short"), nil)
+	assert.ErrorContains(t, err, "at least 16 bytes")
+}
@@ -0,0 +1,199 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	"mckinsey.com/ark/internal/eventing"
+)
+
+// maxA2AStreamResubscribes is how often a task whose stream drops is resubscribed to before it
+// is polled instead
+const maxA2AStreamResubscribes = 3
+
+// maxA2ATaskPollErrors is how many consecutive tasks/get calls may fail before polling a task
+// gives up
+const maxA2ATaskPollErrors = 5
+
+// a2aStreamPollInterval is how often a task is polled with tasks/get once its stream dropped and
+// the server cannot resubscribe
+var a2aStreamPollInterval = 5 * time.Second
+
+// a2aStreamUpdate is called for each task event received on a message/stream connection, with
+// the task as known so far and the agent text the event carried. Record is false for artifact
+// chunks that are followed by more of the same artifact.
+type a2aStreamUpdate func(task *protocol.Task, text string, record bool)
+
+// streamA2AMessage sends a message with message/stream and folds the events received into the
+// result message/send would have returned: the agent's message, or the task once it finishes or
+// needs input
+func streamA2AMessage(ctx context.Context, a2aClient *a2aclient.A2AClient, params protocol.SendMessageParams, onUpdate a2aStreamUpdate, a2aRecorder eventing.A2aRecorder) (*protocol.MessageResult, error) {
+	events, err := a2aClient.StreamMessage(ctx, params)
+	if err != nil {
+		if a2aRecorder != nil {
+			a2aRecorder.A2AMessageFailed(ctx, fmt.Sprintf("A2A StreamMessage failed: %v", err))
+		}
+		return nil, fmt.Errorf("A2A server call failed: %w", err)
+	}
+
+	result, task, err := foldA2AStream(ctx, events, nil, onUpdate)
+	if result != nil || err != nil {
+		return result, err
+	}
+	if task == nil {
+		return nil, fmt.Errorf("A2A stream ended before the task was created")
+	}
+	return resumeA2AStream(ctx, a2aClient, task, onUpdate)
+}
+
+// foldA2AStream applies the events of a stream to the task until the stream ends. It returns
+// the result once the agent answered or the task ended, and otherwise the task as known when
+// the stream dropped.
+func foldA2AStream(ctx context.Context, events <-chan protocol.StreamingMessageEvent, task *protocol.Task, onUpdate a2aStreamUpdate) (*protocol.MessageResult, *protocol.Task, error) {
+	for event := range events {
+		switch e := event.Result.(type) {
+		case *protocol.Message:
+			return &protocol.MessageResult{Result: e}, task, nil
+		case *protocol.Task:
+			task = e
+			onUpdate(task, "", true)
+		case *protocol.TaskStatusUpdateEvent:
+			task = applyA2AStatusUpdate(task, e)
+			onUpdate(task, a2aStatusText(e.Status), true)
+			if e.Final {
+				return &protocol.MessageResult{Result: task}, task, nil
+			}
+		case *protocol.TaskArtifactUpdateEvent:
+			task = applyA2AArtifactUpdate(task, e)
+			onUpdate(task, extractTextFromParts(e.Artifact.Parts), e.IsFinal() || e.LastChunk == nil)
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, task, fmt.Errorf("A2A stream interrupted: %w", err)
+	}
+	// Some servers close the stream after the last event without flagging it as final
+	if task != nil && isA2AStreamEnd(task.Status.State) {
+		return &protocol.MessageResult{Result: task}, task, nil
+	}
+	return nil, task, nil
+}
+
+// resumeA2AStream follows a task whose stream dropped before it finished. The task is
+// resubscribed to with tasks/resubscribe, and polled with tasks/get when the server cannot
+// resubscribe or the streams keep dropping.
+func resumeA2AStream(ctx context.Context, a2aClient *a2aclient.A2AClient, task *protocol.Task, onUpdate a2aStreamUpdate) (*protocol.MessageResult, error) {
+	log := logf.FromContext(ctx)
+
+	for attempt := 1; attempt <= maxA2AStreamResubscribes; attempt++ {
+		log.Info("A2A stream ended before the task finished, resubscribing", "taskId", task.ID, "attempt", attempt)
+		events, err := a2aClient.ResubscribeTask(ctx, protocol.TaskIDParams{ID: task.ID})
+		if err != nil {
+			log.Info("failed to resubscribe to A2A task, polling it", "taskId", task.ID, "error", err.Error())
+			break
+		}
+		result, latest, err := foldA2AStream(ctx, events, task, onUpdate)
+		if result != nil || err != nil {
+			return result, err
+		}
+		task = latest
+	}
+	return pollA2ATask(ctx, a2aClient, task, onUpdate)
+}
+
+// pollA2ATask gets the task with tasks/get until it ends or needs input. Failed calls are
+// retried, until maxA2ATaskPollErrors of them fail in a row.
+func pollA2ATask(ctx context.Context, a2aClient *a2aclient.A2AClient, task *protocol.Task, onUpdate a2aStreamUpdate) (*protocol.MessageResult, error) {
+	log := logf.FromContext(ctx)
+	ticker := time.NewTicker(a2aStreamPollInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	for {
+		latest, err := a2aClient.GetTasks(ctx, protocol.TaskQueryParams{ID: task.ID})
+		switch {
+		case err != nil && ctx.Err() == nil:
+			failures++
+			if failures >= maxA2ATaskPollErrors {
+				return nil, fmt.Errorf("failed to get A2A task %s after its stream ended: %w", task.ID, err)
+			}
+			log.Info("failed to get A2A task, retrying", "taskId", task.ID, "failures", failures, "error", err.Error())
+		case err == nil:
+			failures = 0
+			onUpdate(latest, "", true)
+			if isA2AStreamEnd(latest.Status.State) {
+				return &protocol.MessageResult{Result: latest}, nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("A2A stream interrupted: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// applyA2AStatusUpdate records a status update on the task. Agent status messages are added to
+// the history, where the response of a completed task is read from.
+func applyA2AStatusUpdate(task *protocol.Task, event *protocol.TaskStatusUpdateEvent) *protocol.Task {
+	if task == nil {
+		task = &protocol.Task{ID: event.TaskID, ContextID: event.ContextID}
+	}
+	task.Status = event.Status
+	if a2aStatusText(event.Status) != "" {
+		task.History = append(task.History, *event.Status.Message)
+	}
+	if len(event.Metadata) > 0 {
+		task.Metadata = event.Metadata
+	}
+	return task
+}
+
+// applyA2AArtifactUpdate adds an artifact to the task, or a chunk to one being streamed
+func applyA2AArtifactUpdate(task *protocol.Task, event *protocol.TaskArtifactUpdateEvent) *protocol.Task {
+	if task == nil {
+		task = &protocol.Task{ID: event.TaskID, ContextID: event.ContextID}
+	}
+	for i := range task.Artifacts {
+		if task.Artifacts[i].ArtifactID != event.Artifact.ArtifactID {
+			continue
+		}
+		if event.Append != nil && *event.Append {
+			task.Artifacts[i].Parts = append(task.Artifacts[i].Parts, event.Artifact.Parts...)
+		} else {
+			task.Artifacts[i] = event.Artifact
+		}
+		return task
+	}
+	task.Artifacts = append(task.Artifacts, event.Artifact)
+	return task
+}
+
+// a2aStatusText returns the text of an agent status message that is part of its response,
+// rather than a question for the user
+func a2aStatusText(status protocol.TaskStatus) string {
+	message := status.Message
+	if message == nil || message.Role != protocol.MessageRoleAgent || status.State == TaskStateInputRequired {
+		return ""
+	}
+	return extractTextFromParts(message.Parts)
+}
+
+// isA2AStreamEnd reports whether a task in the state sends no more events until it is sent
+// another message
+func isA2AStreamEnd(state protocol.TaskState) bool {
+	switch state {
+	case TaskStateCompleted, TaskStateFailed, TaskStateCanceled, TaskStateRejected, TaskStateInputRequired, TaskStateAuthRequired:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,278 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	arkann "mckinsey.com/ark/internal/annotations"
+)
+
+// streamingA2AServer answers message/stream requests with the events, recording the requests
+func streamingA2AServer(t *testing.T, events []string, requests *[]map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		*requests = append(*requests, request)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			_, _ = fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%q,\"result\":%s}\n\n", request["id"], event)
+			w.(http.Flusher).Flush()
+		}
+	}))
+}
+
+func newA2ATaskTestClient(t *testing.T) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&arkv1alpha1.A2ATask{}).Build()
+}
+
+func TestExecuteA2AAgentMessageStreamsUpdates(t *testing.T) {
+	var requests []map[string]any
+	server := streamingA2AServer(t, []string{
+		`{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","final":false,
+			"status":{"state":"working","message":{"kind":"message","messageId":"m1","role":"agent","parts":[{"kind":"text","text":"Checking the forecast. "}]}}}`,
+		`{"kind":"artifact-update","taskId":"task-1","contextId":"ctx-1","lastChunk":false,
+			"artifact":{"artifactId":"a1","parts":[{"kind":"text","text":"Sunny "}]}}`,
+		`{"kind":"artifact-update","taskId":"task-1","contextId":"ctx-1","append":true,"lastChunk":true,
+			"artifact":{"artifactId":"a1","parts":[{"kind":"text","text":"in Paris"}]}}`,
+		`{"kind":"status-update","taskId":"task-1","contextId":"ctx-1","final":true,"status":{"state":"completed"}}`,
+	}, &requests)
+	defer server.Close()
+
+	k8sClient := newA2ATaskTestClient(t)
+	a2aClient, err := a2aclient.NewA2AClient(server.URL)
+	require.NoError(t, err)
+
+	var texts []string
+	options := A2AExecutionOptions{Streaming: true, OnText: func(text string) { texts = append(texts, text) }}
+	response, err := executeA2AAgentMessage(context.Background(), k8sClient, a2aClient, "What is the weather?", "weather-agent", "default", "weather-query", "", nil, nil, options)
+	require.NoError(t, err)
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, protocol.MethodMessageStream, requests[0]["method"])
+	assert.Equal(t, []string{"Checking the forecast. ", "Sunny ", "in Paris"}, texts)
+	assert.Equal(t, "Checking the forecast. ", response.Content, "the agent's messages are the response, as for message/send")
+	assert.Equal(t, "task-1", response.TaskID)
+
+	var a2aTask arkv1alpha1.A2ATask
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "a2a-task-task-1", Namespace: "default"}, &a2aTask))
+	assert.Equal(t, A2ATaskUpdateModeStream, a2aTask.Spec.UpdateMode)
+	assert.Equal(t, PhaseCompleted, a2aTask.Status.Phase)
+	require.Len(t, a2aTask.Status.Artifacts, 1)
+	assert.Equal(t, "Sunny ", a2aTask.Status.Artifacts[0].Parts[0].Text)
+	assert.Equal(t, "in Paris", a2aTask.Status.Artifacts[0].Parts[1].Text)
+	assert.NotEmpty(t, a2aTask.Status.LastStatusTimestamp, "updates are stamped when they are received")
+}
+
+func TestExecuteA2AAgentMessageAnswersWithStreamedArtifacts(t *testing.T) {
+	var requests []map[string]any
+	server := streamingA2AServer(t, []string{
+		`{"kind":"task","id":"task-2","contextId":"ctx-2","status":{"state":"submitted"}}`,
+		`{"kind":"artifact-update","taskId":"task-2","contextId":"ctx-2","artifact":{"artifactId":"a1","parts":[{"kind":"text","text":"42"}]}}`,
+		`{"kind":"status-update","taskId":"task-2","contextId":"ctx-2","status":{"state":"completed"}}`,
+	}, &requests)
+	defer server.Close()
+
+	a2aClient, err := a2aclient.NewA2AClient(server.URL)
+	require.NoError(t, err)
+	push, err := NewA2APushNotificationServer(":0", "http://ark-a2a-push-notification-service.ark-system.svc:8083", testPushNotificationKey, nil)
+	require.NoError(t, err)
+
+	options := A2AExecutionOptions{Streaming: true, PushNotifications: push}
+	a2aServer := &arkv1prealpha1.A2AServer{ObjectMeta: metav1.ObjectMeta{Name: "oracle-server", Namespace: "team-a"}}
+	response, err := executeA2AAgentMessage(context.Background(), newA2ATaskTestClient(t), a2aClient, "Answer?", "oracle", "team-a", "oracle-query", "", a2aServer, nil, options)
+	require.NoError(t, err, "a stream closed after a terminal state ends the task, even without a final event")
+	assert.Equal(t, "42", response.Content)
+
+	configuration := requests[0]["params"].(map[string]any)["configuration"].(map[string]any)
+	pushConfig := configuration["pushNotificationConfig"].(map[string]any)
+	assert.Equal(t, "http://ark-a2a-push-notification-service.ark-system.svc:8083/v1/a2a/notifications/team-a/oracle-server", pushConfig["url"])
+	assert.Equal(t, push.Config("team-a", "oracle-server").Token, pushConfig["token"])
+}
+
+// droppingA2AServer ends message/stream after the task started working. tasks/resubscribe streams
+// the resubscribed events, or fails when there are none, and tasks/get returns the next of the
+// polled tasks, or fails for an empty one.
+func droppingA2AServer(t *testing.T, resubscribed []string, polled []string, methods *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		method := request["method"].(string)
+		*methods = append(*methods, method)
+
+		var events []string
+		switch method {
+		case "message/stream":
+			events = []string{`{"kind":"status-update","taskId":"task-3","contextId":"ctx-3","status":{"state":"working"}}`}
+		case "tasks/resubscribe":
+			events = resubscribed
+		case "tasks/get":
+			next := polled[0]
+			polled = polled[1:]
+			if next == "" {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%q,"result":%s}`, request["id"], next)
+			return
+		}
+		if len(events) == 0 {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%q,"error":{"code":-32004,"message":"unsupported operation"}}`, request["id"])
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			_, _ = fmt.Fprintf(w, "data: {\"jsonrpc\":\"2.0\",\"id\":%q,\"result\":%s}\n\n", request["id"], event)
+			w.(http.Flusher).Flush()
+		}
+	}))
+}
+
+func TestExecuteA2AAgentMessageResubscribesWhenStreamDrops(t *testing.T) {
+	var methods []string
+	server := droppingA2AServer(t, []string{
+		`{"kind":"artifact-update","taskId":"task-3","contextId":"ctx-3","artifact":{"artifactId":"a1","parts":[{"kind":"text","text":"42"}]}}`,
+		`{"kind":"status-update","taskId":"task-3","contextId":"ctx-3","final":true,"status":{"state":"completed"}}`,
+	}, nil, &methods)
+	defer server.Close()
+
+	a2aClient, err := a2aclient.NewA2AClient(server.URL)
+	require.NoError(t, err)
+
+	response, err := executeA2AAgentMessage(context.Background(), newA2ATaskTestClient(t), a2aClient, "Answer?", "oracle", "default", "oracle-query", "", nil, nil, A2AExecutionOptions{Streaming: true})
+	require.NoError(t, err)
+	assert.Equal(t, "42", response.Content)
+	assert.Equal(t, []string{"message/stream", "tasks/resubscribe"}, methods)
+}
+
+func TestExecuteA2AAgentMessagePollsWhenStreamDropsAndResubscribeFails(t *testing.T) {
+	previous := a2aStreamPollInterval
+	a2aStreamPollInterval = 10 * time.Millisecond
+	defer func() { a2aStreamPollInterval = previous }()
+
+	var methods []string
+	server := droppingA2AServer(t, nil, []string{
+		`{"kind":"task","id":"task-3","contextId":"ctx-3","status":{"state":"working"}}`,
+		`{"kind":"task","id":"task-3","contextId":"ctx-3","status":{"state":"completed"},
+			"artifacts":[{"artifactId":"a1","parts":[{"kind":"text","text":"42"}]}]}`,
+	}, &methods)
+	defer server.Close()
+
+	a2aClient, err := a2aclient.NewA2AClient(server.URL)
+	require.NoError(t, err)
+
+	k8sClient := newA2ATaskTestClient(t)
+	response, err := executeA2AAgentMessage(context.Background(), k8sClient, a2aClient, "Answer?", "oracle", "default", "oracle-query", "", nil, nil, A2AExecutionOptions{Streaming: true})
+	require.NoError(t, err)
+	assert.Equal(t, "42", response.Content)
+	assert.Equal(t, []string{"message/stream", "tasks/resubscribe", "tasks/get", "tasks/get"}, methods)
+
+	var a2aTask arkv1alpha1.A2ATask
+	require.NoError(t, k8sClient.Get(context.Background(), client.ObjectKey{Name: "a2a-task-task-3", Namespace: "default"}, &a2aTask))
+	assert.Equal(t, PhaseCompleted, a2aTask.Status.Phase)
+}
+
+func TestExecuteA2AAgentMessageKeepsPollingAfterFailedPolls(t *testing.T) {
+	previous := a2aStreamPollInterval
+	a2aStreamPollInterval = 10 * time.Millisecond
+	defer func() { a2aStreamPollInterval = previous }()
+
+	var methods []string
+	server := droppingA2AServer(t, nil, []string{
+		"",
+		`{"kind":"task","id":"task-3","contextId":"ctx-3","status":{"state":"working"}}`,
+		"",
+		"",
+		`{"kind":"task","id":"task-3","contextId":"ctx-3","status":{"state":"completed"},
+			"artifacts":[{"artifactId":"a1","parts":[{"kind":"text","text":"42"}]}]}`,
+	}, &methods)
+	defer server.Close()
+
+	a2aClient, err := a2aclient.NewA2AClient(server.URL)
+	require.NoError(t, err)
+
+	response, err := executeA2AAgentMessage(context.Background(), newA2ATaskTestClient(t), a2aClient, "Answer?", "oracle", "default", "oracle-query", "", nil, nil, A2AExecutionOptions{Streaming: true})
+	require.NoError(t, err)
+	assert.Equal(t, "42", response.Content)
+	assert.Len(t, methods, 7)
+}
+
+func TestExecuteA2AAgentMessageStopsPollingAfterConsecutiveFailures(t *testing.T) {
+	previous := a2aStreamPollInterval
+	a2aStreamPollInterval = 10 * time.Millisecond
+	defer func() { a2aStreamPollInterval = previous }()
+
+	var methods []string
+	server := droppingA2AServer(t, nil, make([]string, maxA2ATaskPollErrors), &methods)
+	defer server.Close()
+
+	a2aClient, err := a2aclient.NewA2AClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = executeA2AAgentMessage(context.Background(), newA2ATaskTestClient(t), a2aClient, "Answer?", "oracle", "default", "oracle-query", "", nil, nil, A2AExecutionOptions{Streaming: true})
+	assert.ErrorContains(t, err, "failed to get A2A task task-3 after its stream ended")
+	assert.Len(t, methods, 2+maxA2ATaskPollErrors)
+}
+
+func TestExecuteA2AAgentMessageFailsWhenStreamDropsBeforeTheTaskIsCreated(t *testing.T) {
+	var requests []map[string]any
+	server := streamingA2AServer(t, nil, &requests)
+	defer server.Close()
+
+	a2aClient, err := a2aclient.NewA2AClient(server.URL)
+	require.NoError(t, err)
+
+	_, err = executeA2AAgentMessage(context.Background(), newA2ATaskTestClient(t), a2aClient, "Answer?", "oracle", "default", "oracle-query", "", nil, nil, A2AExecutionOptions{Streaming: true})
+	assert.ErrorContains(t, err, "A2A stream ended before the task was created")
+}
+
+func TestA2AExecutionOptions(t *testing.T) {
+	push, err := NewA2APushNotificationServer(":0", "http://localhost:8083", testPushNotificationKey, nil)
+	require.NoError(t, err)
+	ctx := WithA2APushNotificationServer(context.Background(), push)
+
+	tests := []struct {
+		name         string
+		capabilities string
+		ctx          context.Context
+		want         A2AExecutionOptions
+	}{
+		{name: "no annotation", ctx: ctx, want: A2AExecutionOptions{}},
+		{name: "streaming", capabilities: `{"streaming":true}`, ctx: ctx, want: A2AExecutionOptions{Streaming: true}},
+		{name: "push notifications", capabilities: `{"streaming":false,"pushNotifications":true}`, ctx: ctx, want: A2AExecutionOptions{PushNotifications: push}},
+		{name: "push notifications disabled", capabilities: `{"pushNotifications":true}`, ctx: context.Background(), want: A2AExecutionOptions{}},
+		{name: "invalid annotation", capabilities: `streaming`, ctx: ctx, want: A2AExecutionOptions{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{}
+			if tt.capabilities != "" {
+				annotations[arkann.A2AServerCapabilities] = tt.capabilities
+			}
+			assert.Equal(t, tt.want, a2aExecutionOptions(tt.ctx, annotations))
+		})
+	}
+}
@@ -1,10 +1,18 @@
 package genai
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
 )
 
 func TestExtractTextFromTask(t *testing.T) {
@@ -249,3 +257,43 @@ func TestExtractTextFromParts(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractResponseFromMessageResultReadsUsage(t *testing.T) {
+	message := protocol.NewMessage(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart("Hello")})
+	message.Metadata = map[string]any{
+		"usage": map[string]any{"prompt_tokens": 12, "completion_tokens": 8},
+	}
+
+	response, err := extractResponseFromMessageResult(context.Background(), nil, &protocol.MessageResult{Result: &message}, "agent", "default", "query", nil, A2ATaskUpdateModePoll)
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", response.Content)
+	assert.Equal(t, arkv1alpha1.TokenUsage{PromptTokens: 12, CompletionTokens: 8, TotalTokens: 20}, response.TokenUsage)
+
+	assert.Equal(t, arkv1alpha1.TokenUsage{}, a2aTokenUsage(nil))
+	assert.Equal(t, arkv1alpha1.TokenUsage{}, a2aTokenUsage(map[string]any{"usage": "unknown"}))
+}
+
+func TestExecuteA2AAgentSendsOverrideHeaders(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		var request struct {
+			ID any `json:"id"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		message := protocol.NewMessage(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart("hello")})
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": message})
+	}))
+	defer server.Close()
+
+	serverHeaders := []arkv1prealpha1.Header{
+		{Name: "Authorization", Value: arkv1alpha1.HeaderValue{Value: "Bearer service"}},
+		{Name: "X-Server", Value: arkv1alpha1.HeaderValue{Value: "weather"}},
+	}
+	options := A2AExecutionOptions{Headers: map[string]string{"Authorization": "Bearer end-user"}}
+	response, err := ExecuteA2AAgent(context.Background(), newA2ATaskTestClient(t), server.URL, serverHeaders, "default", "hi", "weather-agent", "query", "", nil, nil, options)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", response.Content)
+	assert.Equal(t, "Bearer end-user", received.Get("Authorization"), "override headers replace the A2A server's")
+	assert.Equal(t, "weather", received.Get("X-Server"))
+}
@@ -25,3 +25,10 @@ const (
 type (
 	A2AAgentCard = server.AgentCard
 )
+
+// A2ATask update modes, telling the A2ATask controller how task status updates arrive
+const (
+	A2ATaskUpdateModePoll   = "poll"
+	A2ATaskUpdateModeStream = "stream"
+	A2ATaskUpdateModePush   = "push"
+)
@@ -3,6 +3,9 @@ package genai
 import (
 	"context"
 	"fmt"
+	"maps"
+	"strings"
+	"sync"
 
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/packages/param"
@@ -13,6 +16,7 @@ import (
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	arkann "mckinsey.com/ark/internal/annotations"
 	"mckinsey.com/ark/internal/eventing"
 	"mckinsey.com/ark/internal/telemetry"
 )
@@ -31,7 +35,12 @@ type Agent struct {
 	ExecutionEngine   *arkv1alpha1.ExecutionEngineRef
 	Annotations       map[string]string
 	OutputSchema      *runtime.RawExtension
-	client            client.Client
+	// MaxConcurrentToolCalls limits how many parallel-safe tool calls run at once; values below 2 run calls sequentially
+	MaxConcurrentToolCalls int
+	Limits                 *arkv1alpha1.AgentLimits
+	client                 client.Client
+	// engineHeaders are sent to the execution engine or A2A server on top of its own, as set by overrides
+	engineHeaders map[string]string
 }
 
 // FullName returns the namespace/name format for the agent
@@ -44,6 +53,13 @@ func (a *Agent) Execute(ctx context.Context, userInput Message, history []Messag
 	ctx, span := a.telemetryRecorder.StartAgentExecution(ctx, a.Name, a.Namespace)
 	defer span.End()
 
+	ctx, err := enterDelegation(ctx, MemberTypeAgent, a.FullName())
+	if err != nil {
+		a.telemetryRecorder.RecordError(span, err)
+		return nil, err
+	}
+	a.telemetryRecorder.RecordDelegationChain(span, delegationChain(ctx))
+
 	operationData := map[string]string{
 		"agent": a.FullName(),
 	}
@@ -64,15 +80,17 @@ func (a *Agent) Execute(ctx context.Context, userInput Message, history []Messag
 }
 
 func (a *Agent) executeAgent(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) (*ExecutionResult, error) {
+	ctx, checkpointer := claimCheckpointer(ctx)
+
 	if a.ExecutionEngine != nil {
 		return a.executeWithExecutionEngineRouter(ctx, userInput, history, eventStream)
 	}
 
-	messages, err := a.executeLocally(ctx, userInput, history, memory, eventStream)
+	messages, err := a.executeLocally(ctx, userInput, history, memory, eventStream, checkpointer)
 	if err != nil {
 		return nil, err
 	}
-	return &ExecutionResult{Messages: messages}, nil
+	return &ExecutionResult{Messages: messages, Model: a.Model.UsedModelName()}, nil
 }
 
 func (a *Agent) executeWithExecutionEngineRouter(ctx context.Context, userInput Message, history []Message, eventStream EventStreamInterface) (*ExecutionResult, error) {
@@ -80,15 +98,16 @@ func (a *Agent) executeWithExecutionEngineRouter(ctx context.Context, userInput
 		return a.executeWithA2AExecutionEngine(ctx, userInput, eventStream)
 	}
 
-	messages, err := a.executeWithExecutionEngine(ctx, userInput, history)
+	messages, err := a.executeWithExecutionEngine(ctx, userInput, history, eventStream)
 	if err != nil {
 		return nil, err
 	}
-	return &ExecutionResult{Messages: messages}, nil
+	return &ExecutionResult{Messages: messages, Model: a.Model.Name}, nil
 }
 
-func (a *Agent) executeWithExecutionEngine(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
+func (a *Agent) executeWithExecutionEngine(ctx context.Context, userInput Message, history []Message, eventStream EventStreamInterface) ([]Message, error) {
 	engineClient := NewExecutionEngineClient(a.client, a.eventing.ExecutionEngineRecorder())
+	engineClient.SetHeaders(a.engineHeaders)
 
 	agentConfig, err := buildAgentConfig(a)
 	if err != nil {
@@ -103,13 +122,53 @@ func (a *Agent) executeWithExecutionEngine(ctx context.Context, userInput Messag
 
 	toolDefinitions := buildToolDefinitions(a.Tools)
 
-	return engineClient.Execute(ctx, a.ExecutionEngine, agentConfig, userInput, history, toolDefinitions)
+	var toolCallback *ToolCallback
+	if server := GetToolCallbackServer(ctx); server != nil && len(toolDefinitions) > 0 {
+		callback, unregister, err := server.Register(ctx, a.Tools)
+		if err != nil {
+			return nil, err
+		}
+		defer unregister()
+		toolCallback = callback
+	}
+
+	messages, usage, err := engineClient.Execute(ctx, a.ExecutionEngine, agentConfig, userInput, history, toolDefinitions, toolCallback, eventStream)
+	if err != nil {
+		return nil, err
+	}
+	a.recordEngineUsage(ctx, usage)
+	return messages, nil
 }
 
 func (a *Agent) executeWithA2AExecutionEngine(ctx context.Context, userInput Message, eventStream EventStreamInterface) (*ExecutionResult, error) {
 	a2aEngine := NewA2AExecutionEngine(a.client, a.eventing.A2aRecorder())
+	a2aEngine.SetHeaders(a.engineHeaders)
 	contextID := GetA2AContextID(ctx)
-	return a2aEngine.Execute(ctx, a.Name, a.Namespace, a.Annotations, contextID, userInput, eventStream)
+	result, err := a2aEngine.Execute(ctx, a.Name, a.Namespace, a.Annotations, contextID, userInput, eventStream)
+	if err != nil {
+		return nil, err
+	}
+	if result.A2AResponse != nil {
+		a.recordEngineUsage(ctx, result.A2AResponse.TokenUsage)
+	}
+	return result, nil
+}
+
+// recordEngineUsage adds the tokens an external engine reports to the usage and budget of the
+// query, as model calls made by Ark do for themselves
+func (a *Agent) recordEngineUsage(ctx context.Context, usage arkv1alpha1.TokenUsage) {
+	if usage == (arkv1alpha1.TokenUsage{}) {
+		return
+	}
+	a.eventing.ModelRecorder().AddTokenUsage(ctx, usage)
+
+	if tracker := GetBudgetTracker(ctx); tracker != nil {
+		var pricing *arkv1alpha1.ModelPricing
+		if a.Model != nil {
+			pricing = a.Model.Pricing
+		}
+		tracker.Record(usage, pricing)
+	}
 }
 
 func (a *Agent) prepareMessages(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
@@ -165,24 +224,95 @@ func (a *Agent) executeToolCall(ctx context.Context, toolCall openai.ChatComplet
 }
 
 func (a *Agent) executeToolCalls(ctx context.Context, toolCalls []openai.ChatCompletionMessageToolCall, agentMessages, newMessages *[]Message) error {
-	for _, tc := range toolCalls {
+	for start := 0; start < len(toolCalls); {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 
-		toolMessage, err := a.executeToolCall(ctx, tc)
-		*agentMessages = append(*agentMessages, toolMessage)
-		*newMessages = append(*newMessages, toolMessage)
+		// Consecutive parallel-safe calls form a batch; any other call runs on its own so
+		// that calls with side effects keep the order chosen by the model.
+		end := start + 1
+		if a.MaxConcurrentToolCalls > 1 && a.Tools.IsParallelSafe(toolCalls[start].Function.Name) {
+			for end < len(toolCalls) && a.Tools.IsParallelSafe(toolCalls[end].Function.Name) {
+				end++
+			}
+		}
+
+		toolMessages, err := a.executeToolCallBatch(ctx, toolCalls[start:end])
+		*agentMessages = append(*agentMessages, toolMessages...)
+		*newMessages = append(*newMessages, toolMessages...)
 
 		if err != nil {
 			return err
 		}
+		start = end
 	}
 	return nil
 }
 
-// executeLocally executes the agent using the built-in OpenAI-compatible engine
-func (a *Agent) executeLocally(ctx context.Context, userInput Message, history []Message, _ MemoryInterface, eventStream EventStreamInterface) ([]Message, error) {
+// executeToolCallBatch runs the tool calls concurrently, bounded by MaxConcurrentToolCalls,
+// and returns their messages in the order of the calls. The first failure cancels the
+// calls still running; messages after the failed call are dropped, as in sequential execution.
+func (a *Agent) executeToolCallBatch(ctx context.Context, toolCalls []openai.ChatCompletionMessageToolCall) ([]Message, error) {
+	if len(toolCalls) == 1 {
+		toolMessage, err := a.executeToolCall(ctx, toolCalls[0])
+		return []Message{toolMessage}, err
+	}
+
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	toolMessages := make([]Message, len(toolCalls))
+	semaphore := make(chan struct{}, a.MaxConcurrentToolCalls)
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failed   = -1
+		firstErr error
+	)
+
+	for i, tc := range toolCalls {
+		wg.Add(1)
+		go func(i int, tc openai.ChatCompletionMessageToolCall) {
+			defer wg.Done()
+
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+			case <-batchCtx.Done():
+				toolMessages[i] = ToolMessage("", tc.ID)
+				return
+			}
+
+			toolMessage, err := a.executeToolCall(batchCtx, tc)
+			toolMessages[i] = toolMessage
+			if err == nil {
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if firstErr == nil {
+				failed, firstErr = i, err
+				cancel()
+			}
+		}(i, tc)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return toolMessages, ctx.Err()
+	}
+	if firstErr != nil {
+		return toolMessages[:failed+1], firstErr
+	}
+	return toolMessages, nil
+}
+
+// executeLocally executes the agent using the built-in OpenAI-compatible engine.
+// When a checkpointer is provided, messages from an interrupted attempt are replayed
+// and progress is recorded after every completed round of tool calls.
+func (a *Agent) executeLocally(ctx context.Context, userInput Message, history []Message, _ MemoryInterface, eventStream EventStreamInterface, checkpointer ExecutionCheckpointer) ([]Message, error) {
 	var tools []openai.ChatCompletionToolParam
 	if a.Tools != nil {
 		tools = a.Tools.ToOpenAITools()
@@ -198,16 +328,31 @@ func (a *Agent) executeLocally(ctx context.Context, userInput Message, history [
 	}
 
 	newMessages := []Message{}
+	usage := agentLoopUsage{}
+
+	if checkpointer != nil {
+		resumed := checkpointer.Resume()
+		agentMessages = append(agentMessages, resumed.Messages...)
+		newMessages = append(newMessages, resumed.Messages...)
+		usage.iterations = resumed.Turns
+		usage.toolCalls = countToolMessages(resumed.Messages)
+		usage.tokens = resumed.Tokens
+	}
 
 	for {
 		if ctx.Err() != nil {
 			return newMessages, ctx.Err()
 		}
 
+		if exceeded := a.exceededLimit(usage); exceeded != "" {
+			return a.handleLimitExceeded(ctx, exceeded, agentMessages, newMessages, eventStream)
+		}
+
 		response, err := a.executeModelCall(ctx, agentMessages, tools, eventStream)
 		if err != nil {
 			return nil, err
 		}
+		usage.tokens += response.Usage.TotalTokens
 
 		choice := response.Choices[0]
 		assistantMessage := a.processAssistantMessage(choice)
@@ -219,6 +364,17 @@ func (a *Agent) executeLocally(ctx context.Context, userInput Message, history [
 			return newMessages, nil
 		}
 
+		if a.wouldExceedToolCalls(usage, len(choice.Message.ToolCalls)) {
+			// Every tool call needs a response before the model is called again
+			for _, tc := range choice.Message.ToolCalls {
+				skipped := ToolMessage("Tool call skipped: the agent reached its tool call limit", tc.ID)
+				agentMessages = append(agentMessages, skipped)
+				newMessages = append(newMessages, skipped)
+			}
+			usage.toolCallsRejected = true
+			continue
+		}
+
 		if err := a.executeToolCalls(ctx, choice.Message.ToolCalls, &agentMessages, &newMessages); err != nil {
 			logger := logf.FromContext(ctx)
 			if !IsTerminateTeam(err) {
@@ -226,7 +382,110 @@ func (a *Agent) executeLocally(ctx context.Context, userInput Message, history [
 			}
 			return newMessages, err
 		}
+
+		usage.iterations++
+		usage.toolCalls += len(choice.Message.ToolCalls)
+		if checkpointer != nil {
+			checkpointer.Checkpoint(ctx, ExecutionProgress{Messages: newMessages, Turns: usage.iterations, Tokens: usage.tokens})
+		}
+	}
+}
+
+// agentLoopUsage tracks the work done by one agent execution against its limits
+type agentLoopUsage struct {
+	iterations        int
+	toolCalls         int
+	tokens            int64
+	toolCallsRejected bool
+}
+
+func countToolMessages(messages []Message) int {
+	count := 0
+	for _, msg := range messages {
+		if msg.OfTool != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// exceededLimit returns a description of the first limit reached, or an empty string
+func (a *Agent) exceededLimit(usage agentLoopUsage) string {
+	if a.Limits == nil {
+		return ""
+	}
+	if a.Limits.MaxToolIterations != nil && usage.iterations >= *a.Limits.MaxToolIterations {
+		return fmt.Sprintf("maximum of %d tool iterations reached", *a.Limits.MaxToolIterations)
+	}
+	if usage.toolCallsRejected || (a.Limits.MaxToolCalls != nil && usage.toolCalls >= *a.Limits.MaxToolCalls) {
+		return fmt.Sprintf("maximum of %d tool calls reached", *a.Limits.MaxToolCalls)
+	}
+	if a.Limits.MaxTokens != nil && usage.tokens >= *a.Limits.MaxTokens {
+		return fmt.Sprintf("maximum of %d tokens reached", *a.Limits.MaxTokens)
+	}
+	return ""
+}
+
+func (a *Agent) wouldExceedToolCalls(usage agentLoopUsage, requested int) bool {
+	return a.Limits != nil && a.Limits.MaxToolCalls != nil && usage.toolCalls+requested > *a.Limits.MaxToolCalls
+}
+
+// handleLimitExceeded either fails the execution or makes one last model call without
+// tools, so that the model answers with what it has gathered so far. Tool turns are sent as
+// text, since providers such as Bedrock and Anthropic reject tool use without tool definitions.
+func (a *Agent) handleLimitExceeded(ctx context.Context, exceeded string, agentMessages, newMessages []Message, eventStream EventStreamInterface) ([]Message, error) {
+	if a.Limits.OnLimitExceeded == arkv1alpha1.AgentLimitActionFail {
+		return newMessages, &ExecutionLimitError{
+			Reason:  ReasonAgentLimitExceeded,
+			Message: fmt.Sprintf("agent %s stopped: %s", a.FullName(), exceeded),
+		}
+	}
+
+	logf.FromContext(ctx).Info("agent limit reached, requesting final answer", "agent", a.FullName(), "limit", exceeded)
+	agentMessages = append(agentMessages, NewUserMessage(fmt.Sprintf(
+		"You cannot call any more tools (%s). Answer now using the information gathered so far.", exceeded)))
+
+	response, err := a.executeModelCall(ctx, toolTurnsAsText(agentMessages), nil, eventStream)
+	if err != nil {
+		return nil, err
+	}
+	return append(newMessages, a.processAssistantMessage(response.Choices[0])), nil
+}
+
+// toolTurnsAsText rewrites tool calls and tool results as assistant and user text messages
+func toolTurnsAsText(messages []Message) []Message {
+	toolNames := map[string]string{}
+	result := make([]Message, 0, len(messages))
+	for _, msg := range messages {
+		param := openai.ChatCompletionMessageParamUnion(msg)
+		switch {
+		case param.OfAssistant != nil && len(param.OfAssistant.ToolCalls) > 0:
+			var lines []string
+			if text := param.OfAssistant.Content.OfString.Value; text != "" {
+				lines = append(lines, text)
+			}
+			for _, part := range param.OfAssistant.Content.OfArrayOfContentParts {
+				if part.OfText != nil && part.OfText.Text != "" {
+					lines = append(lines, part.OfText.Text)
+				}
+			}
+			for _, toolCall := range param.OfAssistant.ToolCalls {
+				toolNames[toolCall.ID] = toolCall.Function.Name
+				lines = append(lines, fmt.Sprintf("Called tool %s with arguments %s", toolCall.Function.Name, toolCall.Function.Arguments))
+			}
+			result = append(result, NewAssistantMessage(strings.Join(lines, "\n")))
+		case param.OfTool != nil:
+			name := toolNames[param.OfTool.ToolCallID]
+			if name == "" {
+				name = param.OfTool.ToolCallID
+			}
+			content := joinTextParts(param.OfTool.Content.OfString.Value, param.OfTool.Content.OfArrayOfContentParts)
+			result = append(result, NewUserMessage(fmt.Sprintf("Result of tool %s:\n%s", name, content)))
+		default:
+			result = append(result, msg)
+		}
 	}
+	return result
 }
 
 func (a *Agent) GetName() string {
@@ -241,6 +500,13 @@ func (a *Agent) GetDescription() string {
 	return a.Description
 }
 
+func maxConcurrentToolCalls(crd *arkv1alpha1.Agent) int {
+	if crd.Spec.MaxConcurrentToolCalls == nil {
+		return 1
+	}
+	return *crd.Spec.MaxConcurrentToolCalls
+}
+
 // ValidateExecutionEngine checks if the specified ExecutionEngine resource exists
 func ValidateExecutionEngine(ctx context.Context, k8sClient client.Client, executionEngine *arkv1alpha1.ExecutionEngineRef, defaultNamespace string) error {
 	// Resolve execution engine name and namespace
@@ -265,34 +531,49 @@ func ValidateExecutionEngine(ctx context.Context, k8sClient client.Client, execu
 	return nil
 }
 
-func resolveModelHeadersForAgent(ctx context.Context, k8sClient client.Client, agentCRD *arkv1alpha1.Agent, queryCRD *arkv1alpha1.Query) (map[string]string, error) {
-	agentHeadersMap, err := ResolveHeadersFromOverrides(ctx, k8sClient, agentCRD.Spec.Overrides, agentCRD.Namespace, OverrideTypeModel)
+// resolveModelHeadersForAgent returns the headers for the agent's model and fallback models, keyed by model name
+func resolveModelHeadersForAgent(ctx context.Context, k8sClient client.Client, agentCRD *arkv1alpha1.Agent, queryCRD *arkv1alpha1.Query) (map[string]map[string]string, error) {
+	headersMap, err := resolveHeadersFromAgentOverrides(ctx, k8sClient, agentCRD, queryCRD, OverrideTypeModel)
 	if err != nil {
-		return nil, fmt.Errorf("failed to resolve model headers for agent %s/%s: %w", agentCRD.Namespace, agentCRD.Name, err)
-	}
-
-	queryHeadersMap, err := ResolveHeadersFromOverrides(ctx, k8sClient, queryCRD.Spec.Overrides, queryCRD.Namespace, OverrideTypeModel)
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve model headers from query %s/%s: %w", queryCRD.Namespace, queryCRD.Name, err)
+		return nil, err
 	}
 
-	var modelHeaders map[string]string
+	var modelNames []string
 	if agentCRD.Spec.ModelRef != nil {
-		agentHeaders := agentHeadersMap[agentCRD.Spec.ModelRef.Name]
-		queryHeaders := queryHeadersMap[agentCRD.Spec.ModelRef.Name]
+		modelNames = append(modelNames, agentCRD.Spec.ModelRef.Name)
+	}
+	for _, fallback := range agentCRD.Spec.FallbackModels {
+		modelNames = append(modelNames, fallback.Name)
+	}
 
-		modelHeaders = make(map[string]string)
-		for k, v := range agentHeaders {
-			modelHeaders[k] = v
-		}
-		for k, v := range queryHeaders {
-			modelHeaders[k] = v
-		}
+	modelHeaders := make(map[string]map[string]string, len(modelNames))
+	for _, modelName := range modelNames {
+		headers := make(map[string]string)
+		maps.Copy(headers, headersMap[modelName])
+		modelHeaders[modelName] = headers
 	}
 
 	return modelHeaders, nil
 }
 
+// resolveEngineHeadersForAgent returns the headers overrides set for the execution engine or A2A
+// server the agent runs on
+func resolveEngineHeadersForAgent(ctx context.Context, k8sClient client.Client, agentCRD *arkv1alpha1.Agent, queryCRD *arkv1alpha1.Query) (map[string]string, error) {
+	if agentCRD.Spec.ExecutionEngine == nil {
+		return nil, nil
+	}
+
+	overrideType, name := OverrideTypeExecutionEngine, agentCRD.Spec.ExecutionEngine.Name
+	if name == ExecutionEngineA2A {
+		overrideType, name = OverrideTypeA2AServer, agentCRD.Annotations[arkann.A2AServerName]
+	}
+	headersMap, err := resolveHeadersFromAgentOverrides(ctx, k8sClient, agentCRD, queryCRD, overrideType)
+	if err != nil {
+		return nil, err
+	}
+	return headersMap[name], nil
+}
+
 func resolveMCPSettingsForAgent(ctx context.Context, k8sClient client.Client, agentCRD *arkv1alpha1.Agent, queryCRD *arkv1alpha1.Query, queryMCPSettings map[string]MCPSettings) (map[string]MCPSettings, error) {
 	agentHeadersMap, err := ResolveHeadersFromOverrides(ctx, k8sClient, agentCRD.Spec.Overrides, agentCRD.Namespace, OverrideTypeMCPServer)
 	if err != nil {
@@ -339,6 +620,16 @@ func MakeAgent(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Ag
 		return nil, fmt.Errorf("missing query context for agent %s/%s", crd.Namespace, crd.Name)
 	}
 
+	overrideModelRef, overrideProperties, err := resolveModelOverrideForAgent(crd, queryCrd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve model overrides for agent %s/%s: %w", crd.Namespace, crd.Name, err)
+	}
+	if overrideModelRef != nil {
+		// Work on a copy so that model headers and loading follow the substituted model
+		crd = crd.DeepCopy()
+		crd.Spec.ModelRef = overrideModelRef
+	}
+
 	modelHeaders, err := resolveModelHeadersForAgent(ctx, k8sClient, crd, queryCrd)
 	if err != nil {
 		return nil, err
@@ -349,10 +640,11 @@ func MakeAgent(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Ag
 	// A2A agents don't need models - they delegate to external A2A servers
 	if crd.Spec.ExecutionEngine == nil || crd.Spec.ExecutionEngine.Name != ExecutionEngineA2A {
 		var err error
-		resolvedModel, err = LoadModel(ctx, k8sClient, crd.Spec.ModelRef, crd.Namespace, modelHeaders, telemetryProvider.ModelRecorder(), eventingProvider.ModelRecorder())
+		resolvedModel, err = LoadModelWithFallbacks(ctx, k8sClient, crd.Spec.ModelRef, crd.Spec.FallbackModels, crd.Namespace, modelHeaders, telemetryProvider.ModelRecorder(), eventingProvider.ModelRecorder())
 		if err != nil {
 			return nil, fmt.Errorf("failed to load model for agent %s/%s: %w", crd.Namespace, crd.Name, err)
 		}
+		applyModelPropertyOverrides(resolvedModel, overrideProperties)
 	}
 
 	if crd.Spec.ExecutionEngine != nil {
@@ -373,26 +665,41 @@ func MakeAgent(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Ag
 		return nil, err
 	}
 
+	toolHeaders, err := resolveHeadersFromAgentOverrides(ctx, k8sClient, crd, queryCrd, OverrideTypeTool)
+	if err != nil {
+		return nil, err
+	}
+
+	engineHeaders, err := resolveEngineHeadersForAgent(ctx, k8sClient, crd, queryCrd)
+	if err != nil {
+		return nil, err
+	}
+
 	tools := NewToolRegistry(mcpSettings, telemetryProvider.ToolRecorder(), eventingProvider.ToolRecorder())
+	tools.SetApprovalPolicy(crd.Name, crd.Spec.ToolApproval)
+	tools.SetHTTPHeaders(toolHeaders)
 
 	if err := tools.registerTools(ctx, k8sClient, crd, telemetryProvider, eventingProvider); err != nil {
 		return nil, err
 	}
 
 	return &Agent{
-		Name:              crd.Name,
-		Namespace:         crd.Namespace,
-		Prompt:            crd.Spec.Prompt,
-		Description:       crd.Spec.Description,
-		Parameters:        crd.Spec.Parameters,
-		Model:             resolvedModel,
-		Tools:             tools,
-		telemetryRecorder: telemetryProvider.AgentRecorder(),
-		eventingRecorder:  eventingProvider.AgentRecorder(),
-		eventing:          eventingProvider,
-		ExecutionEngine:   crd.Spec.ExecutionEngine,
-		Annotations:       crd.Annotations,
-		OutputSchema:      crd.Spec.OutputSchema,
-		client:            k8sClient,
+		Name:                   crd.Name,
+		Namespace:              crd.Namespace,
+		Prompt:                 crd.Spec.Prompt,
+		Description:            crd.Spec.Description,
+		Parameters:             crd.Spec.Parameters,
+		Model:                  resolvedModel,
+		Tools:                  tools,
+		telemetryRecorder:      telemetryProvider.AgentRecorder(),
+		eventingRecorder:       eventingProvider.AgentRecorder(),
+		eventing:               eventingProvider,
+		ExecutionEngine:        crd.Spec.ExecutionEngine,
+		engineHeaders:          engineHeaders,
+		Annotations:            crd.Annotations,
+		OutputSchema:           crd.Spec.OutputSchema,
+		client:                 k8sClient,
+		MaxConcurrentToolCalls: maxConcurrentToolCalls(crd),
+		Limits:                 crd.Spec.Limits,
 	}, nil
 }
@@ -0,0 +1,244 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// concurrencyTrackingExecutor records how many calls run at the same time
+type concurrencyTrackingExecutor struct {
+	mu       sync.Mutex
+	inFlight int
+	peak     int
+	delay    time.Duration
+	fail     string
+}
+
+func (e *concurrencyTrackingExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
+	e.mu.Lock()
+	e.inFlight++
+	if e.inFlight > e.peak {
+		e.peak = e.inFlight
+	}
+	e.mu.Unlock()
+
+	defer func() {
+		e.mu.Lock()
+		e.inFlight--
+		e.mu.Unlock()
+	}()
+
+	if call.ID == e.fail {
+		return ToolResult{ID: call.ID, Name: call.Function.Name}, errors.New("lookup failed")
+	}
+
+	select {
+	case <-time.After(e.delay):
+	case <-ctx.Done():
+		return ToolResult{ID: call.ID, Name: call.Function.Name}, ctx.Err()
+	}
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: "result " + call.ID}, nil
+}
+
+func newParallelToolTestAgent(maxConcurrent int, executor ToolExecutor, annotations *arkv1alpha1.ToolAnnotations) *Agent {
+	telemetryProvider := noop.NewProvider()
+	eventingProvider := eventnoop.NewProvider()
+	registry := NewToolRegistry(nil, telemetryProvider.ToolRecorder(), eventingProvider.ToolRecorder())
+	registry.RegisterTool(ToolDefinition{Name: "lookup"}, executor)
+	registry.SetToolAnnotations("lookup", annotations)
+
+	return &Agent{
+		Name:                   "test-agent",
+		Namespace:              "default",
+		Tools:                  registry,
+		MaxConcurrentToolCalls: maxConcurrent,
+	}
+}
+
+func lookupToolCalls(count int) []openai.ChatCompletionMessageToolCall {
+	calls := make([]openai.ChatCompletionMessageToolCall, 0, count)
+	for i := range count {
+		calls = append(calls, openai.ChatCompletionMessageToolCall{
+			ID:       fmt.Sprintf("call-%d", i),
+			Function: openai.ChatCompletionMessageToolCallFunction{Name: "lookup", Arguments: "{}"},
+		})
+	}
+	return calls
+}
+
+func TestExecuteToolCallsRunsParallelSafeToolsConcurrently(t *testing.T) {
+	executor := &concurrencyTrackingExecutor{delay: 20 * time.Millisecond}
+	agent := newParallelToolTestAgent(3, executor, &arkv1alpha1.ToolAnnotations{ReadOnlyHint: true})
+
+	var agentMessages, newMessages []Message
+	err := agent.executeToolCalls(context.Background(), lookupToolCalls(6), &agentMessages, &newMessages)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, executor.peak, "concurrency should be bounded by the agent limit")
+	require.Len(t, newMessages, 6)
+	for i, msg := range newMessages {
+		assert.Equal(t, fmt.Sprintf("call-%d", i), msg.OfTool.ToolCallID, "results must keep the tool call order")
+		assert.Equal(t, fmt.Sprintf("result call-%d", i), msg.OfTool.Content.OfString.Value)
+	}
+	assert.Len(t, agentMessages, 6)
+}
+
+func TestExecuteToolCallsRunsUnannotatedToolsSequentially(t *testing.T) {
+	executor := &concurrencyTrackingExecutor{delay: time.Millisecond}
+	agent := newParallelToolTestAgent(4, executor, nil)
+
+	var agentMessages, newMessages []Message
+	err := agent.executeToolCalls(context.Background(), lookupToolCalls(3), &agentMessages, &newMessages)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, executor.peak)
+	assert.Len(t, newMessages, 3)
+}
+
+func TestExecuteToolCallsStopsBatchOnFailure(t *testing.T) {
+	executor := &concurrencyTrackingExecutor{delay: time.Second, fail: "call-1"}
+	agent := newParallelToolTestAgent(4, executor, &arkv1alpha1.ToolAnnotations{IdempotentHint: true})
+
+	var agentMessages, newMessages []Message
+	start := time.Now()
+	err := agent.executeToolCalls(context.Background(), lookupToolCalls(4), &agentMessages, &newMessages)
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "lookup failed")
+	assert.Less(t, time.Since(start), 500*time.Millisecond, "remaining calls should be cancelled")
+	require.Len(t, newMessages, 2, "messages after the failed call are dropped")
+	assert.Equal(t, "call-1", newMessages[1].OfTool.ToolCallID)
+}
+
+// toolLoopProvider requests a lookup on every call until it is called without tools
+type toolLoopProvider struct {
+	calls                int
+	toolCallsPerResponse int
+	finalCall            bool
+	finalMessages        []Message
+}
+
+func (p *toolLoopProvider) ChatCompletion(_ context.Context, messages []Message, _ int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	p.calls++
+	message := openai.ChatCompletionMessage{Role: "assistant"}
+	if len(tools) == 0 || len(tools[0]) == 0 {
+		p.finalCall = true
+		p.finalMessages = messages
+		message.Content = "final answer"
+	} else {
+		for i := range max(p.toolCallsPerResponse, 1) {
+			message.ToolCalls = append(message.ToolCalls, openai.ChatCompletionMessageToolCall{
+				ID:       fmt.Sprintf("call-%d-%d", p.calls, i),
+				Type:     "function",
+				Function: openai.ChatCompletionMessageToolCallFunction{Name: "lookup", Arguments: "{}"},
+			})
+		}
+	}
+	return &openai.ChatCompletion{
+		Choices: []openai.ChatCompletionChoice{{Message: message}},
+		Usage:   openai.CompletionUsage{PromptTokens: 80, CompletionTokens: 20, TotalTokens: 100},
+	}, nil
+}
+
+func (p *toolLoopProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, _ func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return p.ChatCompletion(ctx, messages, n, tools...)
+}
+
+func (p *toolLoopProvider) SetOutputSchema(_ *runtime.RawExtension, _ string) {}
+
+func newLimitedTestAgent(provider *toolLoopProvider, limits *arkv1alpha1.AgentLimits) *Agent {
+	agent := newParallelToolTestAgent(1, &concurrencyTrackingExecutor{}, nil)
+	telemetryProvider := noop.NewProvider()
+	eventingProvider := eventnoop.NewProvider()
+	agent.Model = &Model{
+		Model:             "test-model",
+		Provider:          provider,
+		telemetryRecorder: telemetryProvider.ModelRecorder(),
+		eventingRecorder:  eventingProvider.ModelRecorder(),
+	}
+	agent.Limits = limits
+	return agent
+}
+
+func TestExecuteLocallyRequestsFinalAnswerAtIterationLimit(t *testing.T) {
+	provider := &toolLoopProvider{}
+	maxIterations := 2
+	agent := newLimitedTestAgent(provider, &arkv1alpha1.AgentLimits{MaxToolIterations: &maxIterations})
+
+	messages, err := agent.executeLocally(context.Background(), NewUserMessage("go"), nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, provider.calls, "two tool iterations followed by one final call")
+	assert.True(t, provider.finalCall)
+	last := messages[len(messages)-1]
+	assert.Equal(t, "final answer", last.OfAssistant.Content.OfString.Value)
+
+	for _, msg := range provider.finalMessages {
+		assert.Nil(t, msg.OfTool, "the final call is made without tools and must not carry tool results")
+		if msg.OfAssistant != nil {
+			assert.Empty(t, msg.OfAssistant.ToolCalls)
+		}
+	}
+	assert.Contains(t, provider.finalMessages[2].OfAssistant.Content.OfString.Value, "Called tool lookup")
+	assert.Contains(t, provider.finalMessages[3].OfUser.Content.OfString.Value, "Result of tool lookup")
+}
+
+func TestExecuteLocallyFailsAtToolCallLimit(t *testing.T) {
+	provider := &toolLoopProvider{toolCallsPerResponse: 2}
+	maxToolCalls := 3
+	agent := newLimitedTestAgent(provider, &arkv1alpha1.AgentLimits{
+		MaxToolCalls:    &maxToolCalls,
+		OnLimitExceeded: arkv1alpha1.AgentLimitActionFail,
+	})
+
+	messages, err := agent.executeLocally(context.Background(), NewUserMessage("go"), nil, nil, nil, nil)
+	require.Error(t, err)
+
+	assert.Equal(t, ReasonAgentLimitExceeded, GetExecutionLimitReason(err))
+	assert.Equal(t, 2, provider.calls, "the second response would exceed the limit and is not executed")
+	assert.False(t, provider.finalCall)
+	last := messages[len(messages)-1]
+	require.NotNil(t, last.OfTool, "the rejected call must still receive a tool response")
+	assert.Contains(t, last.OfTool.Content.OfString.Value, "skipped")
+}
+
+func TestExecuteLocallyStopsAtTokenLimit(t *testing.T) {
+	provider := &toolLoopProvider{}
+	maxTokens := int64(250)
+	agent := newLimitedTestAgent(provider, &arkv1alpha1.AgentLimits{MaxTokens: &maxTokens})
+
+	_, err := agent.executeLocally(context.Background(), NewUserMessage("go"), nil, nil, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, provider.calls, "three calls reach 300 tokens, then one final call")
+	assert.True(t, provider.finalCall)
+}
+
+func TestExecuteLocallyKeepsTokenUsageAcrossResume(t *testing.T) {
+	provider := &toolLoopProvider{}
+	maxTokens := int64(250)
+	agent := newLimitedTestAgent(provider, &arkv1alpha1.AgentLimits{MaxTokens: &maxTokens})
+	checkpointer := &recordingCheckpointer{resume: ExecutionProgress{Turns: 2, Tokens: 200}}
+
+	_, err := agent.executeLocally(context.Background(), NewUserMessage("go"), nil, nil, nil, checkpointer)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, provider.calls, "one call reaches 300 tokens with the resumed usage, then one final call")
+	require.NotEmpty(t, checkpointer.checkpoints)
+	assert.Equal(t, int64(300), checkpointer.checkpoints[0].Tokens)
+}
@@ -13,6 +13,7 @@ import (
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/eventing"
 	"mckinsey.com/ark/internal/telemetry"
+	"mckinsey.com/ark/internal/telemetry/metrics"
 )
 
 // Add MCP client pool to ToolRegistry
@@ -43,6 +44,7 @@ func (p *MCPClientPool) GetOrCreateClient(ctx context.Context, serverName, serve
 	}
 
 	p.clients[key] = mcpClient
+	metrics.MCPClients.Inc()
 	return mcpClient, nil
 }
 
@@ -56,6 +58,7 @@ func (p *MCPClientPool) Close() error {
 			}
 		}
 		delete(p.clients, key)
+		metrics.MCPClients.Dec()
 	}
 	return lastErr
 }
@@ -134,6 +137,8 @@ func createBuiltinExecutor(tool *arkv1alpha1.Tool) (ToolExecutor, error) {
 		return &NoopExecutor{}, nil
 	case BuiltinToolTerminate:
 		return &TerminateExecutor{}, nil
+	case BuiltinToolAskUser:
+		return &AskUserExecutor{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported builtin tool %s", tool.Name)
 	}
@@ -235,6 +240,9 @@ func (r *ToolRegistry) registerTool(ctx context.Context, k8sClient client.Client
 	if err != nil {
 		return fmt.Errorf("failed to create executor for tool %s: %w", toolDef.Name, err)
 	}
+	if httpExecutor, ok := executor.(*HTTPExecutor); ok {
+		httpExecutor.Headers = r.httpHeaders[tool.Name]
+	}
 
 	// Override description if provided at the agent tool level
 	if agentTool.Description != "" {
@@ -266,6 +274,8 @@ func (r *ToolRegistry) registerTool(ctx context.Context, k8sClient client.Client
 	}
 
 	r.RegisterTool(toolDef, executor)
+	r.SetToolAnnotations(toolDef.Name, tool.Spec.Annotations)
+	r.SetRequiresApproval(toolDef.Name, tool.Spec.RequiresApproval)
 	return nil
 }
 
@@ -3,6 +3,8 @@ package genai
 import (
 	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/openai/openai-go"
@@ -569,3 +571,44 @@ func TestTeamToolExecutor_Execute(t *testing.T) {
 		t.Skip("Requires full setup with models and agents - better suited for integration tests")
 	})
 }
+
+func TestRegisterToolSendsHTTPOverrideHeaders(t *testing.T) {
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	tool := &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "get-orders", Namespace: "default"},
+		Spec: arkv1alpha1.ToolSpec{
+			Type: ToolTypeHTTP,
+			HTTP: &arkv1alpha1.HTTPSpec{
+				URL: server.URL,
+				Headers: []arkv1alpha1.Header{
+					{Name: "Authorization", Value: arkv1alpha1.HeaderValue{Value: "Bearer service"}},
+					{Name: "X-Service", Value: arkv1alpha1.HeaderValue{Value: "orders"}},
+				},
+			},
+		},
+	}
+	k8sClient := setupTestClientForTools([]client.Object{tool})
+
+	telemetryProvider := noop.NewProvider()
+	eventingProvider := eventnoop.NewProvider()
+	registry := NewToolRegistry(nil, telemetryProvider.ToolRecorder(), eventingProvider.ToolRecorder())
+	registry.SetHTTPHeaders(map[string]map[string]string{"get-orders": {"Authorization": "Bearer end-user"}})
+	agentTool := arkv1alpha1.AgentTool{Type: "custom", Name: "get-orders"}
+	require.NoError(t, registry.registerTool(context.Background(), k8sClient, agentTool, "default", telemetryProvider, eventingProvider))
+
+	result, err := registry.ExecuteTool(context.Background(), ToolCall{
+		ID:       "call-1",
+		Function: openai.ChatCompletionMessageToolCallFunction{Name: "get-orders", Arguments: "{}"},
+		Type:     "function",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ok", result.Content)
+	require.Equal(t, "Bearer end-user", received.Get("Authorization"), "override headers replace the tool's")
+	require.Equal(t, "orders", received.Get("X-Service"))
+}
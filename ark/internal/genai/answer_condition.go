@@ -0,0 +1,88 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// answerCondition matches the answer of a team member by the text it contains, a regex, or a
+// JSONPath over the answer parsed as JSON
+type answerCondition struct {
+	text     string
+	regex    *regexp.Regexp
+	jsonPath *jsonpath.JSONPath
+	value    string
+}
+
+// compileAnswerCondition prepares a condition with at most one of text, regex and jsonPath,
+// returning nil when none is set
+func compileAnswerCondition(text, regex, path, value string) (*answerCondition, error) {
+	set := 0
+	for _, field := range []string{text, regex, path} {
+		if field != "" {
+			set++
+		}
+	}
+	switch {
+	case set == 0:
+		return nil, nil
+	case set > 1:
+		return nil, fmt.Errorf("condition must set only one of text, regex and jsonPath")
+	}
+
+	switch {
+	case text != "":
+		return &answerCondition{text: text}, nil
+	case regex != "":
+		compiled, err := regexp.Compile(regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid condition regex: %w", err)
+		}
+		return &answerCondition{regex: compiled}, nil
+	}
+
+	if !strings.HasPrefix(path, "{") {
+		path = "{" + path + "}"
+	}
+	jsonPath := jsonpath.New("condition").AllowMissingKeys(true)
+	if err := jsonPath.Parse(path); err != nil {
+		return nil, fmt.Errorf("invalid condition jsonPath: %w", err)
+	}
+	return &answerCondition{jsonPath: jsonPath, value: value}, nil
+}
+
+// matches evaluates the condition against an answer. Answers that are not JSON never match a
+// JSONPath condition.
+func (c *answerCondition) matches(answer string) bool {
+	switch {
+	case c.text != "":
+		return strings.Contains(answer, c.text)
+	case c.regex != nil:
+		return c.regex.MatchString(answer)
+	}
+
+	var data any
+	if err := json.Unmarshal([]byte(answer), &data); err != nil {
+		return false
+	}
+	var result bytes.Buffer
+	if err := c.jsonPath.Execute(&result, data); err != nil {
+		return false
+	}
+	if c.value != "" {
+		return result.String() == c.value
+	}
+	switch result.String() {
+	case "", "false", "null":
+		return false
+	default:
+		return true
+	}
+}
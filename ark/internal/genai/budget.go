@@ -0,0 +1,162 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const costDecimalPlaces = 6
+
+type budgetTrackerKeyType struct{}
+
+var budgetTrackerKey = budgetTrackerKeyType{}
+
+var tokensPerMillion = big.NewRat(1_000_000, 1)
+
+// BudgetTracker accumulates the token usage and cost of a query as model calls complete
+// and cancels the query execution as soon as its budget is crossed.
+type BudgetTracker struct {
+	mu       sync.Mutex
+	budget   *arkv1alpha1.QueryBudget
+	maxCost  *big.Rat
+	usage    arkv1alpha1.TokenUsage
+	cost     *big.Rat
+	cancel   context.CancelCauseFunc
+	exceeded *ExecutionLimitError
+}
+
+// NewBudgetTracker creates a tracker starting from the usage and cost of earlier attempts.
+// cancel is called with an ExecutionLimitError once the budget is crossed.
+func NewBudgetTracker(budget *arkv1alpha1.QueryBudget, priorUsage arkv1alpha1.TokenUsage, priorCost string, cancel context.CancelCauseFunc) (*BudgetTracker, error) {
+	tracker := &BudgetTracker{
+		budget: budget,
+		usage:  priorUsage,
+		cost:   new(big.Rat),
+		cancel: cancel,
+	}
+
+	if priorCost != "" {
+		if _, ok := tracker.cost.SetString(priorCost); !ok {
+			return nil, fmt.Errorf("invalid recorded cost %q", priorCost)
+		}
+	}
+
+	if budget != nil && budget.MaxCost != "" {
+		maxCost, ok := new(big.Rat).SetString(budget.MaxCost)
+		if !ok {
+			return nil, fmt.Errorf("invalid budget maxCost %q", budget.MaxCost)
+		}
+		tracker.maxCost = maxCost
+	}
+
+	return tracker, nil
+}
+
+// WithBudgetTracker stores the tracker in the context for model calls to report to
+func WithBudgetTracker(ctx context.Context, tracker *BudgetTracker) context.Context {
+	return context.WithValue(ctx, budgetTrackerKey, tracker)
+}
+
+// GetBudgetTracker returns the tracker of the current query, if any
+func GetBudgetTracker(ctx context.Context) *BudgetTracker {
+	tracker, _ := ctx.Value(budgetTrackerKey).(*BudgetTracker)
+	return tracker
+}
+
+// Record adds the usage of a model call, priced with the pricing of the model that served it
+func (t *BudgetTracker) Record(usage arkv1alpha1.TokenUsage, pricing *arkv1alpha1.ModelPricing) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.usage.PromptTokens += usage.PromptTokens
+	t.usage.CompletionTokens += usage.CompletionTokens
+	t.usage.TotalTokens += usage.TotalTokens
+	t.cost.Add(t.cost, callCost(usage, pricing))
+
+	if t.exceeded != nil {
+		return
+	}
+	if exceeded := t.exceededLimit(); exceeded != "" {
+		t.exceeded = &ExecutionLimitError{
+			Reason:  ReasonBudgetExceeded,
+			Message: fmt.Sprintf("query budget exceeded: %s", exceeded),
+		}
+		if t.cancel != nil {
+			t.cancel(t.exceeded)
+		}
+	}
+}
+
+// Usage returns the token usage recorded so far, including earlier attempts
+func (t *BudgetTracker) Usage() arkv1alpha1.TokenUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.usage
+}
+
+// Cost returns the cost recorded so far as a decimal string, or an empty string when
+// none of the models used has pricing
+func (t *BudgetTracker) Cost() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.cost.Sign() == 0 {
+		return ""
+	}
+	return formatCost(t.cost)
+}
+
+// Exceeded returns the error the execution was cancelled with, or nil
+func (t *BudgetTracker) Exceeded() *ExecutionLimitError {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.exceeded
+}
+
+func (t *BudgetTracker) exceededLimit() string {
+	if t.budget == nil {
+		return ""
+	}
+	if t.budget.MaxPromptTokens != nil && t.usage.PromptTokens > *t.budget.MaxPromptTokens {
+		return fmt.Sprintf("%d prompt tokens used, limit is %d", t.usage.PromptTokens, *t.budget.MaxPromptTokens)
+	}
+	if t.budget.MaxCompletionTokens != nil && t.usage.CompletionTokens > *t.budget.MaxCompletionTokens {
+		return fmt.Sprintf("%d completion tokens used, limit is %d", t.usage.CompletionTokens, *t.budget.MaxCompletionTokens)
+	}
+	if t.budget.MaxTotalTokens != nil && t.usage.TotalTokens > *t.budget.MaxTotalTokens {
+		return fmt.Sprintf("%d total tokens used, limit is %d", t.usage.TotalTokens, *t.budget.MaxTotalTokens)
+	}
+	if t.maxCost != nil && t.cost.Cmp(t.maxCost) > 0 {
+		return fmt.Sprintf("cost %s, limit is %s", formatCost(t.cost), t.budget.MaxCost)
+	}
+	return ""
+}
+
+// callCost prices a model call. Models without pricing are free as far as budgets are concerned.
+func callCost(usage arkv1alpha1.TokenUsage, pricing *arkv1alpha1.ModelPricing) *big.Rat {
+	cost := new(big.Rat)
+	if pricing == nil {
+		return cost
+	}
+	cost.Add(cost, tokenCost(usage.PromptTokens, pricing.InputPerMillionTokens))
+	cost.Add(cost, tokenCost(usage.CompletionTokens, pricing.OutputPerMillionTokens))
+	return cost
+}
+
+func tokenCost(tokens int64, pricePerMillion string) *big.Rat {
+	price, ok := new(big.Rat).SetString(pricePerMillion)
+	if pricePerMillion == "" || !ok {
+		return new(big.Rat)
+	}
+	cost := new(big.Rat).Mul(price, big.NewRat(tokens, 1))
+	return cost.Quo(cost, tokensPerMillion)
+}
+
+func formatCost(cost *big.Rat) string {
+	return cost.FloatString(costDecimalPlaces)
+}
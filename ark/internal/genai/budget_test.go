@@ -0,0 +1,76 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestBudgetTrackerComputesCostFromModelPricing(t *testing.T) {
+	tracker, err := NewBudgetTracker(nil, arkv1alpha1.TokenUsage{}, "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, tracker.Cost(), "no cost is reported before priced usage")
+
+	pricing := &arkv1alpha1.ModelPricing{InputPerMillionTokens: "2.50", OutputPerMillionTokens: "10"}
+	tracker.Record(arkv1alpha1.TokenUsage{PromptTokens: 1000, CompletionTokens: 200, TotalTokens: 1200}, pricing)
+	tracker.Record(arkv1alpha1.TokenUsage{PromptTokens: 500, CompletionTokens: 0, TotalTokens: 500}, nil)
+
+	assert.Equal(t, "0.004500", tracker.Cost())
+	assert.Equal(t, arkv1alpha1.TokenUsage{PromptTokens: 1500, CompletionTokens: 200, TotalTokens: 1700}, tracker.Usage())
+	assert.Nil(t, tracker.Exceeded())
+}
+
+func TestBudgetTrackerCancelsWhenTokenBudgetIsCrossed(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	budget := &arkv1alpha1.QueryBudget{MaxTotalTokens: int64Ptr(1000)}
+	tracker, err := NewBudgetTracker(budget, arkv1alpha1.TokenUsage{TotalTokens: 600}, "", cancel)
+	require.NoError(t, err)
+
+	tracker.Record(arkv1alpha1.TokenUsage{TotalTokens: 400}, nil)
+	assert.NoError(t, ctx.Err(), "reaching the budget exactly is allowed")
+
+	tracker.Record(arkv1alpha1.TokenUsage{TotalTokens: 1}, nil)
+	require.Error(t, ctx.Err())
+
+	var limitErr *ExecutionLimitError
+	require.True(t, errors.As(context.Cause(ctx), &limitErr))
+	assert.Equal(t, ReasonBudgetExceeded, limitErr.Reason)
+	assert.Same(t, limitErr, tracker.Exceeded())
+}
+
+func TestBudgetTrackerEnforcesCostIncludingEarlierAttempts(t *testing.T) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	defer cancel(nil)
+
+	budget := &arkv1alpha1.QueryBudget{MaxCost: "0.01"}
+	tracker, err := NewBudgetTracker(budget, arkv1alpha1.TokenUsage{}, "0.009", cancel)
+	require.NoError(t, err)
+
+	pricing := &arkv1alpha1.ModelPricing{InputPerMillionTokens: "1"}
+	tracker.Record(arkv1alpha1.TokenUsage{PromptTokens: 2000}, pricing)
+
+	require.Error(t, ctx.Err())
+	assert.Equal(t, ReasonBudgetExceeded, GetExecutionLimitReason(context.Cause(ctx)))
+	assert.Equal(t, "0.011000", tracker.Cost())
+}
+
+func TestNewBudgetTrackerRejectsInvalidAmounts(t *testing.T) {
+	_, err := NewBudgetTracker(&arkv1alpha1.QueryBudget{MaxCost: "ten"}, arkv1alpha1.TokenUsage{}, "", nil)
+	assert.Error(t, err)
+
+	_, err = NewBudgetTracker(nil, arkv1alpha1.TokenUsage{}, "not-a-number", nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/openai/openai-go"
+)
+
+type checkpointerKeyType struct{}
+
+var checkpointerKey = checkpointerKeyType{}
+
+// ExecutionProgress describes the work completed by the top-level target of a query.
+type ExecutionProgress struct {
+	// Messages produced by completed turns, in order
+	Messages []Message
+	// Turns is the number of completed turns (agent model calls or team member turns)
+	Turns int
+	// NextMember is the team member that runs the next turn, when it cannot be derived from the
+	// number of completed turns
+	NextMember string
+	// Tokens used by the completed turns of an agent, counted towards its token limit
+	Tokens int64
+}
+
+// ExecutionCheckpointer persists the progress of a running query so that an
+// interrupted execution can be resumed by another controller instance.
+type ExecutionCheckpointer interface {
+	// Resume returns the progress recorded by a previous, interrupted attempt
+	Resume() ExecutionProgress
+	// Checkpoint records the progress made so far by the current attempt
+	Checkpoint(ctx context.Context, progress ExecutionProgress)
+}
+
+// WithCheckpointer stores a checkpointer in the context for the query target to claim
+func WithCheckpointer(ctx context.Context, checkpointer ExecutionCheckpointer) context.Context {
+	return context.WithValue(ctx, checkpointerKey, checkpointer)
+}
+
+// claimCheckpointer returns the checkpointer stored in the context, if any, together
+// with a context that no longer carries it. Only the top-level target records
+// progress, so nested agents and teams must not see the checkpointer.
+func claimCheckpointer(ctx context.Context) (context.Context, ExecutionCheckpointer) {
+	checkpointer, ok := ctx.Value(checkpointerKey).(ExecutionCheckpointer)
+	if !ok || checkpointer == nil {
+		return ctx, nil
+	}
+	return context.WithValue(ctx, checkpointerKey, nil), checkpointer
+}
+
+// MarshalMessages serializes messages into a JSON array
+func MarshalMessages(messages []Message) (string, error) {
+	raw := make([]json.RawMessage, 0, len(messages))
+	for i, msg := range messages {
+		data, err := json.Marshal(openai.ChatCompletionMessageParamUnion(msg))
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal message at index %d: %w", i, err)
+		}
+		raw = append(raw, data)
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal messages: %w", err)
+	}
+	return string(data), nil
+}
+
+// UnmarshalMessages parses a JSON array produced by MarshalMessages
+func UnmarshalMessages(data string) ([]Message, error) {
+	if data == "" {
+		return nil, nil
+	}
+
+	var raw []json.RawMessage
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse messages: %w", err)
+	}
+
+	messages := make([]Message, 0, len(raw))
+	for i, record := range raw {
+		msg, err := unmarshalMessageRobust(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal message at index %d: %w", i, err)
+		}
+		messages = append(messages, Message(msg))
+	}
+	return messages, nil
+}
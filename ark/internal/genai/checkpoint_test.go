@@ -0,0 +1,109 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingCheckpointer struct {
+	resume      ExecutionProgress
+	checkpoints []ExecutionProgress
+}
+
+func (c *recordingCheckpointer) Resume() ExecutionProgress {
+	return c.resume
+}
+
+func (c *recordingCheckpointer) Checkpoint(_ context.Context, progress ExecutionProgress) {
+	c.checkpoints = append(c.checkpoints, progress)
+}
+
+func TestMarshalMessagesRoundTrip(t *testing.T) {
+	messages := []Message{
+		NewUserMessage("hello"),
+		NewAssistantMessage("hi there"),
+		ToolMessage("result", "call-1"),
+	}
+
+	raw, err := MarshalMessages(messages)
+	require.NoError(t, err)
+
+	restored, err := UnmarshalMessages(raw)
+	require.NoError(t, err)
+	require.Len(t, restored, 3)
+	assert.Equal(t, "hello", restored[0].OfUser.Content.OfString.Value)
+	assert.Equal(t, "hi there", restored[1].OfAssistant.Content.OfString.Value)
+	assert.Equal(t, "call-1", restored[2].OfTool.ToolCallID)
+
+	empty, err := UnmarshalMessages("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+}
+
+func TestClaimCheckpointerHidesItFromNestedExecutions(t *testing.T) {
+	checkpointer := &recordingCheckpointer{}
+	ctx := WithCheckpointer(context.Background(), checkpointer)
+
+	nestedCtx, claimed := claimCheckpointer(ctx)
+	assert.Same(t, checkpointer, claimed)
+
+	_, nested := claimCheckpointer(nestedCtx)
+	assert.Nil(t, nested)
+}
+
+func TestTeamSequentialResumesFromCheckpoint(t *testing.T) {
+	first := &mockTeamMember{name: "first", replies: []string{"reply from first"}}
+	second := &mockTeamMember{name: "second", replies: []string{"reply from second"}}
+	team := newTestTeam("sequential", first, second)
+
+	checkpointer := &recordingCheckpointer{
+		resume: ExecutionProgress{
+			Messages: []Message{NewAssistantMessage("reply from first")},
+			Turns:    1,
+		},
+	}
+	ctx := WithCheckpointer(context.Background(), checkpointer)
+
+	result, err := team.Execute(ctx, NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, first.calls, "completed turn should not be executed again")
+	assert.Equal(t, 1, second.calls)
+	_, memberCheckpointer := claimCheckpointer(second.ctx)
+	assert.Nil(t, memberCheckpointer, "members must not see the team checkpointer")
+	require.Len(t, result.Messages, 2)
+	require.Len(t, checkpointer.checkpoints, 1)
+	assert.Equal(t, 2, checkpointer.checkpoints[0].Turns)
+	assert.Len(t, checkpointer.checkpoints[0].Messages, 2)
+}
+
+func TestTeamRoundRobinResumesWithNextMember(t *testing.T) {
+	first := &mockTeamMember{name: "first", replies: []string{"reply from first"}}
+	second := &mockTeamMember{name: "second", replies: []string{"reply from second"}}
+	team := newTestTeam("round-robin", first, second)
+	maxTurns := 3
+	team.MaxTurns = &maxTurns
+
+	checkpointer := &recordingCheckpointer{
+		resume: ExecutionProgress{
+			Messages: []Message{NewAssistantMessage("reply from first")},
+			Turns:    1,
+		},
+	}
+	ctx := WithCheckpointer(context.Background(), checkpointer)
+
+	result, err := team.Execute(ctx, NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, first.calls)
+	assert.Equal(t, 1, second.calls)
+	require.Len(t, result.Messages, 3)
+	assert.Equal(t, "reply from second", result.Messages[1].OfAssistant.Content.OfString.Value)
+	require.Len(t, checkpointer.checkpoints, 2)
+	assert.Equal(t, 3, checkpointer.checkpoints[1].Turns)
+}
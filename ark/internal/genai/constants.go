@@ -7,9 +7,11 @@ const (
 
 // Provider constants - specifies which AI provider client to use.
 const (
-	ProviderAzure   = "azure"
-	ProviderOpenAI  = "openai"
-	ProviderBedrock = "bedrock"
+	ProviderAzure     = "azure"
+	ProviderOpenAI    = "openai"
+	ProviderBedrock   = "bedrock"
+	ProviderAnthropic = "anthropic"
+	ProviderGemini    = "gemini"
 )
 
 // Model type constants - specifies the API capability of the model.
@@ -59,10 +61,12 @@ const (
 // Team member type constants
 const (
 	MemberTypeAgent = "agent"
+	MemberTypeTeam  = "team"
 )
 
 // Built-in tool name constants
 const (
 	BuiltinToolNoop      = "noop"
 	BuiltinToolTerminate = "terminate"
+	BuiltinToolAskUser   = "ask-user"
 )
@@ -0,0 +1,33 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxDelegationDepth limits how many agents and teams can be nested in a single execution,
+// through agent and team tools or nested team members. It stops delegation cycles that
+// admission could not catch, such as resources changed after they were admitted.
+const MaxDelegationDepth = 10
+
+type delegationChainKey struct{}
+
+// enterDelegation adds an agent or team to the delegation chain carried in the context
+func enterDelegation(ctx context.Context, kind, fullName string) (context.Context, error) {
+	chain := append(slices.Clone(delegationChain(ctx)), kind+"/"+fullName)
+	if len(chain) > MaxDelegationDepth {
+		return ctx, fmt.Errorf("delegation depth limit of %d exceeded: %s", MaxDelegationDepth, strings.Join(chain, " -> "))
+	}
+	return context.WithValue(ctx, delegationChainKey{}, chain), nil
+}
+
+// delegationChain returns the agents and teams that led to the current execution, outermost
+// first
+func delegationChain(ctx context.Context) []string {
+	chain, _ := ctx.Value(delegationChainKey{}).([]string)
+	return chain
+}
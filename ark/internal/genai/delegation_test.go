@@ -0,0 +1,56 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func TestTeamExecutionCarriesDelegationChain(t *testing.T) {
+	member := &mockTeamMember{name: "writer", replies: []string{"done"}}
+	inner := newTestTeam("sequential", member)
+	inner.Name = "inner"
+	outer := newTestTeam("sequential", inner)
+	outer.Name = "outer"
+
+	_, err := outer.Execute(context.Background(), NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team/default/outer", "team/default/inner"}, delegationChain(member.ctx))
+}
+
+func TestTeamExecutionStopsAtDelegationDepth(t *testing.T) {
+	team := newTestTeam("sequential")
+	team.Members = []TeamMember{team}
+
+	_, err := team.Execute(context.Background(), NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "delegation depth limit of 10 exceeded: team/default/test-team -> team/default/test-team")
+}
+
+func TestMakeTeamStopsOnTeamCycle(t *testing.T) {
+	newTeam := func(name, member string) *arkv1alpha1.Team {
+		return &arkv1alpha1.Team{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: arkv1alpha1.TeamSpec{
+				Strategy: "sequential",
+				Members:  []arkv1alpha1.TeamMember{{Name: member, Type: MemberTypeTeam}},
+			},
+		}
+	}
+	first, second := newTeam("first", "second"), newTeam("second", "first")
+	k8sClient := setupTestClientForTools([]client.Object{first, second})
+
+	_, err := MakeTeam(context.Background(), k8sClient, first, noop.NewProvider(), eventnoop.NewProvider())
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "team/default/first -> team/default/second -> team/default/first")
+}
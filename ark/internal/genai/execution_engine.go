@@ -1,13 +1,19 @@
 package genai
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/openai/openai-go"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,8 +31,13 @@ type ExecutionEngineMessage struct {
 	Name    string `json:"name,omitempty"`
 }
 
+// executionEngineCancelTimeout bounds the request telling an engine to stop an execution
+const executionEngineCancelTimeout = 10 * time.Second
+
 // ExecutionEngineRequest represents the data sent to an external execution engine
 type ExecutionEngineRequest struct {
+	// Identifies the execution, for the engine's cancel endpoint
+	ExecutionID string `json:"executionId,omitempty"`
 	// Agent configuration
 	Agent AgentConfig `json:"agent"`
 	// Current message to process
@@ -35,6 +46,17 @@ type ExecutionEngineRequest struct {
 	History []ExecutionEngineMessage `json:"history"`
 	// Available tools
 	Tools []ToolDefinition `json:"tools,omitempty"`
+	// Endpoint the engine calls to have Ark execute the available tools
+	ToolCallback *ToolCallback `json:"toolCallback,omitempty"`
+	// Whether Ark accepts a streamed response
+	Stream bool `json:"stream,omitempty"`
+}
+
+// ExecutionEngineCancelRequest is sent to the optional cancel endpoint of an engine when Ark stops
+// waiting for an execution, because its query was cancelled or timed out
+type ExecutionEngineCancelRequest struct {
+	ExecutionID string `json:"executionId"`
+	Reason      string `json:"reason,omitempty"`
 }
 
 // AgentConfig contains agent configuration for the execution engine
@@ -75,6 +97,23 @@ type ExecutionEngineResponse struct {
 	TokenUsage TokenUsage               `json:"token_usage,omitempty"`
 }
 
+// ExecutionEngineStreamEvent is one event of a streamed execution engine response, sent either as
+// server-sent events or as newline-delimited JSON. Engines send chunks while they run and end the
+// stream with the final response.
+type ExecutionEngineStreamEvent struct {
+	Chunk    *openai.ChatCompletionChunk `json:"chunk,omitempty"`
+	Response *ExecutionEngineResponse    `json:"response,omitempty"`
+}
+
+// usage converts the usage reported by an engine to the usage recorded on queries
+func (u TokenUsage) usage() arkv1alpha1.TokenUsage {
+	return arkv1alpha1.TokenUsage{
+		PromptTokens:     u.PromptTokens,
+		CompletionTokens: u.CompletionTokens,
+		TotalTokens:      u.TotalTokens,
+	}
+}
+
 // convertToExecutionEngineMessage converts internal genai.Message to ExecutionEngineMessage format
 func convertToExecutionEngineMessage(msg Message) ExecutionEngineMessage {
 	// Handle different message types from OpenAI ChatCompletionMessageParamUnion
@@ -150,6 +189,7 @@ type ExecutionEngineClient struct {
 	client           client.Client
 	httpClient       *http.Client
 	eventingRecorder eventing.ExecutionEngineRecorder
+	headers          map[string]string
 }
 
 // NewExecutionEngineClient creates a new ExecutionEngine client
@@ -163,17 +203,47 @@ func NewExecutionEngineClient(k8sClient client.Client, eventingRecorder eventing
 	}
 }
 
-// Execute sends a request to the execution engine and returns the response messages
-func (c *ExecutionEngineClient) Execute(ctx context.Context, engineRef *arkv1alpha1.ExecutionEngineRef, agentConfig AgentConfig, userInput Message, history []Message, tools []ToolDefinition) ([]Message, error) {
+// SetHeaders sets the headers sent with every request to the engine, such as those set by overrides
+func (c *ExecutionEngineClient) SetHeaders(headers map[string]string) {
+	c.headers = headers
+}
+
+// Execute sends a request to the execution engine and returns the response messages along with
+// the token usage the engine reported. When toolCallback is set, the engine can run the tools
+// through it while the request is in flight. When eventStream is set, the engine may stream its
+// response, and its chunks are forwarded to the stream.
+func (c *ExecutionEngineClient) Execute(ctx context.Context, engineRef *arkv1alpha1.ExecutionEngineRef, agentConfig AgentConfig, userInput Message, history []Message, tools []ToolDefinition, toolCallback *ToolCallback, eventStream EventStreamInterface) ([]Message, arkv1alpha1.TokenUsage, error) {
 	operationData := map[string]string{
 		"engineName": engineRef.Name,
 		"agentName":  agentConfig.Name,
 	}
 	ctx = c.eventingRecorder.Start(ctx, "ExecutionEngine", fmt.Sprintf("Executing agent via execution engine %s", engineRef.Name), operationData)
 
+	response, err := c.execute(ctx, engineRef, agentConfig, userInput, history, tools, toolCallback, eventStream)
+	if err != nil {
+		c.eventingRecorder.Fail(ctx, "ExecutionEngine", err.Error(), err, operationData)
+		return nil, arkv1alpha1.TokenUsage{}, err
+	}
+
+	if response.Error != "" {
+		err := fmt.Errorf("execution engine error: %s", response.Error)
+		c.eventingRecorder.Fail(ctx, "ExecutionEngine", err.Error(), err, operationData)
+		return nil, arkv1alpha1.TokenUsage{}, err
+	}
+
+	// Convert response messages back to internal format
+	convertedMessages := make([]Message, len(response.Messages))
+	for i, msg := range response.Messages {
+		convertedMessages[i] = convertFromExecutionEngineMessage(msg)
+	}
+
+	c.eventingRecorder.Complete(ctx, "ExecutionEngine", "Execution engine completed successfully", operationData)
+	return convertedMessages, response.TokenUsage.usage(), nil
+}
+
+func (c *ExecutionEngineClient) execute(ctx context.Context, engineRef *arkv1alpha1.ExecutionEngineRef, agentConfig AgentConfig, userInput Message, history []Message, tools []ToolDefinition, toolCallback *ToolCallback, eventStream EventStreamInterface) (*ExecutionEngineResponse, error) {
 	engineAddress, err := c.resolveExecutionEngineAddress(ctx, engineRef, agentConfig.Namespace)
 	if err != nil {
-		c.eventingRecorder.Fail(ctx, "ExecutionEngine", fmt.Sprintf("Failed to resolve execution engine address: %v", err), err, operationData)
 		return nil, fmt.Errorf("failed to resolve execution engine address: %w", err)
 	}
 
@@ -185,31 +255,41 @@ func (c *ExecutionEngineClient) Execute(ctx context.Context, engineRef *arkv1alp
 	}
 
 	request := ExecutionEngineRequest{
-		Agent:     agentConfig,
-		UserInput: convertedUserInput,
-		History:   convertedHistory,
-		Tools:     tools,
+		ExecutionID:  rand.Text(),
+		Agent:        agentConfig,
+		UserInput:    convertedUserInput,
+		History:      convertedHistory,
+		Tools:        tools,
+		ToolCallback: toolCallback,
+		Stream:       eventStream != nil,
 	}
 
 	requestBody, err := json.Marshal(request)
 	if err != nil {
-		c.eventingRecorder.Fail(ctx, "ExecutionEngine", fmt.Sprintf("Failed to marshal request: %v", err), err, operationData)
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
+	// Engines keep running when the request is abandoned, so they are asked to stop
+	defer func() {
+		if ctx.Err() != nil {
+			c.cancel(ctx, engineAddress, request.ExecutionID)
+		}
+	}()
+
 	url := fmt.Sprintf("%s/execute", engineAddress)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(requestBody))
 	if err != nil {
-		c.eventingRecorder.Fail(ctx, "ExecutionEngine", fmt.Sprintf("Failed to create request: %v", err), err, operationData)
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	c.setHeaders(req)
+	if eventStream != nil {
+		req.Header.Set("Accept", "text/event-stream, application/x-ndjson, application/json")
+	}
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		c.eventingRecorder.Fail(ctx, "ExecutionEngine", fmt.Sprintf("Execution engine request failed: %v", err), err, operationData)
 		return nil, fmt.Errorf("execution engine request failed: %w", err)
 	}
 	defer func() {
@@ -219,31 +299,170 @@ func (c *ExecutionEngineClient) Execute(ctx context.Context, engineRef *arkv1alp
 	}()
 
 	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("execution engine returned error status: %d", resp.StatusCode)
-		c.eventingRecorder.Fail(ctx, "ExecutionEngine", err.Error(), err, operationData)
-		return nil, err
+		return nil, fmt.Errorf("execution engine returned error status: %d", resp.StatusCode)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/event-stream":
+		return readExecutionEngineStream(ctx, resp.Body, true, agentConfig.Model.Name, eventStream)
+	case "application/x-ndjson", "application/jsonl":
+		return readExecutionEngineStream(ctx, resp.Body, false, agentConfig.Model.Name, eventStream)
 	}
 
 	var response ExecutionEngineResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		c.eventingRecorder.Fail(ctx, "ExecutionEngine", fmt.Sprintf("Failed to decode response: %v", err), err, operationData)
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
-	if response.Error != "" {
-		err := fmt.Errorf("execution engine error: %s", response.Error)
-		c.eventingRecorder.Fail(ctx, "ExecutionEngine", err.Error(), err, operationData)
-		return nil, err
+	// Engines that do not stream still send their response to the stream, as a single chunk
+	if eventStream != nil && response.Error == "" {
+		streamExecutionEngineResponse(ctx, &response, agentConfig.Model.Name, eventStream)
 	}
+	return &response, nil
+}
 
-	// Convert response messages back to internal format
-	convertedMessages := make([]Message, len(response.Messages))
-	for i, msg := range response.Messages {
-		convertedMessages[i] = convertFromExecutionEngineMessage(msg)
+// cancel asks the engine to stop an execution. Engines without a cancel endpoint are left to
+// notice the closed connection.
+func (c *ExecutionEngineClient) cancel(ctx context.Context, engineAddress, executionID string) {
+	log := logf.FromContext(ctx)
+
+	requestBody, err := json.Marshal(ExecutionEngineCancelRequest{
+		ExecutionID: executionID,
+		Reason:      context.Cause(ctx).Error(),
+	})
+	if err != nil {
+		log.Error(err, "failed to marshal execution engine cancel request")
+		return
 	}
 
-	c.eventingRecorder.Complete(ctx, "ExecutionEngine", "Execution engine completed successfully", operationData)
-	return convertedMessages, nil
+	cancelCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), executionEngineCancelTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(cancelCtx, http.MethodPost, fmt.Sprintf("%s/cancel", engineAddress), bytes.NewBuffer(requestBody))
+	if err != nil {
+		log.Error(err, "failed to create execution engine cancel request")
+		return
+	}
+	c.setHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		log.Error(err, "failed to cancel execution engine execution", "executionId", executionID)
+		return
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Error(closeErr, "failed to close response body")
+		}
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound, resp.StatusCode == http.StatusMethodNotAllowed, resp.StatusCode == http.StatusNotImplemented:
+		log.V(1).Info("execution engine does not support cancellation", "executionId", executionID)
+	case resp.StatusCode >= http.StatusBadRequest:
+		log.Info("execution engine failed to cancel execution", "executionId", executionID, "status", resp.StatusCode)
+	}
+}
+
+// setHeaders sets the headers of a request to the engine, which overrides cannot replace the
+// content type of
+func (c *ExecutionEngineClient) setHeaders(req *http.Request) {
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+}
+
+// readExecutionEngineStream forwards the chunks of a streamed response to the event stream until
+// the engine sends its final response. Engines that end the stream without one are answered with
+// the streamed content.
+func readExecutionEngineStream(ctx context.Context, body io.Reader, sse bool, modelName string, eventStream EventStreamInterface) (*ExecutionEngineResponse, error) {
+	var content strings.Builder
+	var usage TokenUsage
+
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if sse {
+			data, ok := strings.CutPrefix(line, "data:")
+			if !ok {
+				continue
+			}
+			line = strings.TrimSpace(data)
+		}
+		if line == "" || line == "[DONE]" {
+			continue
+		}
+
+		var event ExecutionEngineStreamEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode stream event: %w", err)
+		}
+		if event.Response != nil {
+			if event.Response.TokenUsage == (TokenUsage{}) {
+				event.Response.TokenUsage = usage
+			}
+			return event.Response, nil
+		}
+		chunk := event.Chunk
+		if chunk == nil {
+			continue
+		}
+
+		for _, choice := range chunk.Choices {
+			content.WriteString(choice.Delta.Content)
+		}
+		if chunk.Usage.TotalTokens > 0 {
+			usage = TokenUsage{
+				PromptTokens:     chunk.Usage.PromptTokens,
+				CompletionTokens: chunk.Usage.CompletionTokens,
+				TotalTokens:      chunk.Usage.TotalTokens,
+			}
+		}
+		if eventStream == nil {
+			continue
+		}
+		if chunk.ID == "" {
+			chunk.ID = getQueryID(ctx)
+		}
+		if chunk.Model == "" {
+			chunk.Model = modelName
+		}
+		if err := eventStream.StreamChunk(ctx, WrapChunkWithMetadata(ctx, chunk, modelName, nil)); err != nil {
+			logf.FromContext(ctx).Error(err, "failed to send execution engine chunk to event stream")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read execution engine stream: %w", err)
+	}
+
+	return &ExecutionEngineResponse{
+		Messages:   []ExecutionEngineMessage{{Role: RoleAssistant, Content: content.String()}},
+		TokenUsage: usage,
+	}, nil
+}
+
+// streamExecutionEngineResponse sends the assistant messages of a complete response as one chunk
+func streamExecutionEngineResponse(ctx context.Context, response *ExecutionEngineResponse, modelName string, eventStream EventStreamInterface) {
+	var content []string
+	for _, msg := range response.Messages {
+		if msg.Role == RoleAssistant && msg.Content != "" {
+			content = append(content, msg.Content)
+		}
+	}
+
+	chunk := NewContentChunk(getQueryID(ctx), modelName, strings.Join(content, "\n"))
+	chunk.Choices[0].Delta.Role = RoleAssistant
+	chunk.Choices[0].FinishReason = "stop"
+	chunk.Usage = openai.CompletionUsage{
+		PromptTokens:     response.TokenUsage.PromptTokens,
+		CompletionTokens: response.TokenUsage.CompletionTokens,
+		TotalTokens:      response.TokenUsage.TotalTokens,
+	}
+	if err := eventStream.StreamChunk(ctx, WrapChunkWithMetadata(ctx, chunk, modelName, nil)); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to send execution engine response to event stream")
+	}
 }
 
 // resolveExecutionEngineAddress resolves the address of the execution engine
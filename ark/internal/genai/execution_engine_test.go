@@ -0,0 +1,238 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/eventing/recorder"
+)
+
+// recordingEventStream keeps the chunks sent to it
+type recordingEventStream struct {
+	mu     sync.Mutex
+	chunks []any
+}
+
+func (s *recordingEventStream) StreamChunk(_ context.Context, chunk any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chunks = append(s.chunks, chunk)
+	return nil
+}
+
+func (s *recordingEventStream) NotifyCompletion(context.Context) error { return nil }
+
+func (s *recordingEventStream) Close() error { return nil }
+
+// newExecutionEngineStub serves an execution engine, passing each decoded request to handle
+func newExecutionEngineStub(t *testing.T, handle func(w http.ResponseWriter, request ExecutionEngineRequest)) *ExecutionEngineClient {
+	t.Helper()
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/execute", r.URL.Path)
+		var request ExecutionEngineRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		handle(w, request)
+	}))
+	t.Cleanup(engine.Close)
+	return newExecutionEngineClientFor(t, engine.URL)
+}
+
+// newExecutionEngineClientFor returns a client for an ExecutionEngine resolved to the address
+func newExecutionEngineClientFor(t *testing.T, address string) *ExecutionEngineClient {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1prealpha1.AddToScheme(scheme))
+	engineCRD := &arkv1prealpha1.ExecutionEngine{ObjectMeta: metav1.ObjectMeta{Name: "engine", Namespace: "default"}}
+	engineCRD.Status.LastResolvedAddress = address
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(engineCRD).WithStatusSubresource(engineCRD).Build()
+	require.NoError(t, k8sClient.Status().Update(context.Background(), engineCRD))
+
+	emitter := eventnoop.NewNoopEventEmitter()
+	return NewExecutionEngineClient(k8sClient, recorder.NewExecutionEngineRecorder(emitter, emitter))
+}
+
+func executeOnStub(engineClient *ExecutionEngineClient, toolCallback *ToolCallback, eventStream EventStreamInterface) ([]Message, arkv1alpha1.TokenUsage, error) {
+	agentConfig := AgentConfig{Name: "agent", Namespace: "default", Model: ExecutionEngineModel{Name: "gpt-4o"}}
+	return engineClient.Execute(context.Background(), &arkv1alpha1.ExecutionEngineRef{Name: "engine"}, agentConfig,
+		NewUserMessage("hi"), nil, []ToolDefinition{{Name: "echo"}}, toolCallback, eventStream)
+}
+
+func TestExecutionEngineClient_SendsToolCallback(t *testing.T) {
+	var engineRequest ExecutionEngineRequest
+	engineClient := newExecutionEngineStub(t, func(w http.ResponseWriter, request ExecutionEngineRequest) {
+		engineRequest = request
+		_ = json.NewEncoder(w).Encode(ExecutionEngineResponse{
+			Messages:   []ExecutionEngineMessage{{Role: RoleAssistant, Content: "done"}},
+			TokenUsage: TokenUsage{PromptTokens: 7, CompletionTokens: 3, TotalTokens: 10},
+		})
+	})
+
+	callback := &ToolCallback{URL: "http://localhost:8082/v1/tool-calls", Token: "token"}
+	messages, usage, err := executeOnStub(engineClient, callback, nil)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, arkv1alpha1.TokenUsage{PromptTokens: 7, CompletionTokens: 3, TotalTokens: 10}, usage)
+
+	assert.Equal(t, callback, engineRequest.ToolCallback)
+	assert.False(t, engineRequest.Stream)
+	require.Len(t, engineRequest.Tools, 1)
+	assert.Equal(t, "echo", engineRequest.Tools[0].Name)
+}
+
+func TestExecutionEngineClient_ForwardsStreamedChunks(t *testing.T) {
+	for _, format := range []struct {
+		contentType, prefix string
+	}{
+		{"text/event-stream", "data: "},
+		{"application/x-ndjson", ""},
+	} {
+		t.Run(format.contentType, func(t *testing.T) {
+			engineClient := newExecutionEngineStub(t, func(w http.ResponseWriter, request ExecutionEngineRequest) {
+				assert.True(t, request.Stream)
+				w.Header().Set("Content-Type", format.contentType)
+				for _, event := range []string{
+					`{"chunk":{"id":"c1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"role":"assistant","content":"Hel"}}]}}`,
+					`{"chunk":{"id":"c1","object":"chat.completion.chunk","choices":[{"index":0,"delta":{"content":"lo"},"finish_reason":"stop"}]}}`,
+					`{"response":{"messages":[{"role":"assistant","content":"Hello"}],"token_usage":{"prompt_tokens":4,"completion_tokens":2,"total_tokens":6}}}`,
+				} {
+					_, _ = fmt.Fprintf(w, "%s%s\n\n", format.prefix, event)
+				}
+			})
+
+			stream := &recordingEventStream{}
+			messages, usage, err := executeOnStub(engineClient, nil, stream)
+			require.NoError(t, err)
+
+			require.Len(t, messages, 1)
+			assert.Equal(t, "Hello", messages[0].OfAssistant.Content.OfString.Value)
+			assert.Equal(t, int64(6), usage.TotalTokens)
+
+			require.Len(t, stream.chunks, 2)
+			chunk := stream.chunks[1].(ChunkWithMetadata)
+			assert.Equal(t, "lo", chunk.Choices[0].Delta.Content)
+			assert.Equal(t, "gpt-4o", chunk.Model)
+			assert.Equal(t, "gpt-4o", chunk.Ark.Model)
+		})
+	}
+}
+
+func TestExecutionEngineClient_StreamWithoutFinalResponse(t *testing.T) {
+	engineClient := newExecutionEngineStub(t, func(w http.ResponseWriter, _ ExecutionEngineRequest) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "data: {\"chunk\":{\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi \"}}]}}\n\n")
+		_, _ = fmt.Fprint(w, "data: {\"chunk\":{\"choices\":[{\"index\":0,\"delta\":{\"content\":\"there\"}}],\"usage\":{\"prompt_tokens\":2,\"completion_tokens\":2,\"total_tokens\":4}}}\n\n")
+		_, _ = fmt.Fprint(w, "data: [DONE]\n\n")
+	})
+
+	messages, usage, err := executeOnStub(engineClient, nil, &recordingEventStream{})
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "Hi there", messages[0].OfAssistant.Content.OfString.Value)
+	assert.Equal(t, int64(4), usage.TotalTokens)
+}
+
+func TestExecutionEngineClient_StreamsCompleteResponseAsOneChunk(t *testing.T) {
+	engineClient := newExecutionEngineStub(t, func(w http.ResponseWriter, _ ExecutionEngineRequest) {
+		_ = json.NewEncoder(w).Encode(ExecutionEngineResponse{
+			Messages: []ExecutionEngineMessage{{Role: RoleAssistant, Content: "done"}},
+		})
+	})
+
+	stream := &recordingEventStream{}
+	_, _, err := executeOnStub(engineClient, nil, stream)
+	require.NoError(t, err)
+
+	require.Len(t, stream.chunks, 1)
+	chunk := stream.chunks[0].(ChunkWithMetadata)
+	assert.Equal(t, "done", chunk.Choices[0].Delta.Content)
+	assert.Equal(t, "stop", chunk.Choices[0].FinishReason)
+}
+
+func TestExecutionEngineClient_CancelsAbandonedExecutions(t *testing.T) {
+	executing := make(chan ExecutionEngineRequest, 1)
+	cancelled := make(chan ExecutionEngineCancelRequest, 1)
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/execute":
+			var request ExecutionEngineRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			executing <- request
+			<-r.Context().Done()
+		case "/cancel":
+			var request ExecutionEngineCancelRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			cancelled <- request
+		}
+	}))
+	defer engine.Close()
+	engineClient := newExecutionEngineClientFor(t, engine.URL)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-executing
+		cancel()
+	}()
+	agentConfig := AgentConfig{Name: "agent", Namespace: "default", Model: ExecutionEngineModel{Name: "gpt-4o"}}
+	_, _, err := engineClient.Execute(ctx, &arkv1alpha1.ExecutionEngineRef{Name: "engine"}, agentConfig, NewUserMessage("hi"), nil, nil, nil, nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case request := <-cancelled:
+		assert.NotEmpty(t, request.ExecutionID)
+		assert.Equal(t, context.Canceled.Error(), request.Reason)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the engine was not asked to cancel the execution")
+	}
+}
+
+func TestExecutionEngineClient_IdentifiesExecutions(t *testing.T) {
+	var executionIDs []string
+	engineClient := newExecutionEngineStub(t, func(w http.ResponseWriter, request ExecutionEngineRequest) {
+		executionIDs = append(executionIDs, request.ExecutionID)
+		_ = json.NewEncoder(w).Encode(ExecutionEngineResponse{
+			Messages: []ExecutionEngineMessage{{Role: RoleAssistant, Content: "done"}},
+		})
+	})
+
+	for range 2 {
+		_, _, err := executeOnStub(engineClient, nil, nil)
+		require.NoError(t, err)
+	}
+	require.Len(t, executionIDs, 2)
+	assert.NotEqual(t, executionIDs[0], executionIDs[1], "each execution has its own ID")
+}
+
+func TestExecutionEngineClient_SendsOverrideHeaders(t *testing.T) {
+	var received http.Header
+	engine := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		_ = json.NewEncoder(w).Encode(ExecutionEngineResponse{
+			Messages: []ExecutionEngineMessage{{Role: RoleAssistant, Content: "done"}},
+		})
+	}))
+	defer engine.Close()
+
+	engineClient := newExecutionEngineClientFor(t, engine.URL)
+	engineClient.SetHeaders(map[string]string{"Authorization": "Bearer end-user", "Content-Type": "text/plain"})
+	_, _, err := executeOnStub(engineClient, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer end-user", received.Get("Authorization"))
+	assert.Equal(t, "application/json", received.Get("Content-Type"), "overrides cannot change the request format")
+}
@@ -3,4 +3,8 @@ package genai
 type ExecutionResult struct {
 	Messages    []Message
 	A2AResponse *A2AResponse
+	// Model is the name of the Model resource that produced the result, if any
+	Model string
+	// TerminationReason is set when a team stopped before its strategy ended
+	TerminationReason string
 }
@@ -0,0 +1,52 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+)
+
+type inputRequesterKeyType struct{}
+
+var inputRequesterKey = inputRequesterKeyType{}
+
+// InputRequest is a question to the end user of a query
+type InputRequest struct {
+	Agent string
+	// TaskID is set when an A2A task asked for the input
+	TaskID   string
+	Question string
+}
+
+// InputRequester asks the end user for input. RequestInput blocks until an answer is submitted
+// or the context is done.
+type InputRequester interface {
+	RequestInput(ctx context.Context, request InputRequest) (string, error)
+}
+
+// WithInputRequester makes the requester available to the agents and tools executed with the context
+func WithInputRequester(ctx context.Context, requester InputRequester) context.Context {
+	return context.WithValue(ctx, inputRequesterKey, requester)
+}
+
+// GetInputRequester returns the requester of the context, if any
+func GetInputRequester(ctx context.Context) InputRequester {
+	requester, _ := ctx.Value(inputRequesterKey).(InputRequester)
+	return requester
+}
+
+// requestInput asks the requester of the context, failing when the execution cannot ask the end
+// user anything, such as a query without a controller watching it
+func requestInput(ctx context.Context, request InputRequest) (string, error) {
+	requester := GetInputRequester(ctx)
+	if requester == nil {
+		return "", fmt.Errorf("input from the user is required but this execution cannot ask for it: %s", request.Question)
+	}
+	if request.Agent == "" {
+		if agent, ok := GetExecutionMetadata(ctx)[MemberTypeAgent].(string); ok {
+			request.Agent = agent
+		}
+	}
+	return requester.RequestInput(ctx, request)
+}
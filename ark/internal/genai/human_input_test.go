@@ -0,0 +1,92 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// answeringRequester answers every question with the same text
+type answeringRequester struct {
+	answer   string
+	requests []InputRequest
+}
+
+func (r *answeringRequester) RequestInput(_ context.Context, request InputRequest) (string, error) {
+	r.requests = append(r.requests, request)
+	return r.answer, nil
+}
+
+func TestAskUserExecutor(t *testing.T) {
+	call := ToolCall{ID: "call_1", Type: "function"}
+	call.Function.Name = BuiltinToolAskUser
+	call.Function.Arguments = `{"question":"Which city?"}`
+
+	requester := &answeringRequester{answer: "Paris"}
+	ctx := WithExecutionMetadata(WithInputRequester(context.Background(), requester), map[string]interface{}{"agent": "weather-agent"})
+	result, err := (&AskUserExecutor{}).Execute(ctx, call)
+	require.NoError(t, err)
+	assert.Equal(t, "Paris", result.Content)
+	assert.Equal(t, []InputRequest{{Agent: "weather-agent", Question: "Which city?"}}, requester.requests)
+
+	_, err = (&AskUserExecutor{}).Execute(context.Background(), call)
+	assert.ErrorContains(t, err, "cannot ask for it: Which city?")
+}
+
+func TestExecuteA2AAgentMessageAnswersInputRequests(t *testing.T) {
+	var messages []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     any `json:"id"`
+			Params struct {
+				Message map[string]any `json:"message"`
+			} `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		messages = append(messages, request.Params.Message)
+
+		task := `{"kind":"task","id":"task-1","contextId":"ctx-1","metadata":{"usage":{"prompt_tokens":10,"completion_tokens":2}},
+			"status":{"state":"input-required","message":{"kind":"message","messageId":"m1","role":"agent","parts":[{"kind":"text","text":"Which city?"}]}}}`
+		if len(messages) > 1 {
+			task = `{"kind":"task","id":"task-1","contextId":"ctx-1","metadata":{"usage":{"prompt_tokens":20,"completion_tokens":5}},"status":{"state":"completed"},
+				"history":[{"kind":"message","messageId":"m2","role":"agent","parts":[{"kind":"text","text":"Sunny in Paris"}]}]}`
+		}
+		id, _ := json.Marshal(request.ID)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, id, task)
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	a2aClient, err := a2aclient.NewA2AClient(server.URL)
+	require.NoError(t, err)
+
+	requester := &answeringRequester{answer: "Paris"}
+	ctx := WithInputRequester(context.Background(), requester)
+	response, err := executeA2AAgentMessage(ctx, k8sClient, a2aClient, "What is the weather?", "weather-agent", "default", "weather-query", "", nil, nil, A2AExecutionOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Sunny in Paris", response.Content)
+	assert.Equal(t, arkv1alpha1.TokenUsage{PromptTokens: 30, CompletionTokens: 7, TotalTokens: 37}, response.TokenUsage)
+	assert.Equal(t, []InputRequest{{Agent: "weather-agent", TaskID: "task-1", Question: "Which city?"}}, requester.requests)
+
+	require.Len(t, messages, 2)
+	assert.Equal(t, "task-1", messages[1]["taskId"])
+	assert.Equal(t, "ctx-1", messages[1]["contextId"])
+	assert.Equal(t, "Paris", messages[1]["parts"].([]any)[0].(map[string]any)["text"])
+}
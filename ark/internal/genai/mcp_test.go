@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/openai/openai-go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -247,3 +248,39 @@ func waitForServer(t *testing.T, ctx context.Context, url string, timeout time.D
 
 	return fmt.Errorf("server at %s did not become ready within %v", url, timeout)
 }
+
+func TestMCPExecutorNotifiesServerOfCancelledCalls(t *testing.T) {
+	started := make(chan struct{})
+	cancelled := make(chan struct{})
+	server := mcp.NewServer(&mcp.Implementation{Name: "slow", Version: "v0.0.1"}, nil)
+	mcp.AddTool(server, &mcp.Tool{Name: "wait", Description: "wait until cancelled"},
+		func(ctx context.Context, _ *mcp.CallToolRequest, _ sayHiParams) (*mcp.CallToolResult, any, error) {
+			close(started)
+			<-ctx.Done()
+			close(cancelled)
+			return nil, nil, ctx.Err()
+		})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = serverSession.Close() }()
+	clientSession, err := createHTTPClient().Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	defer func() { _ = clientSession.Close() }()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	go func() {
+		<-started
+		cancel()
+	}()
+	executor := &MCPExecutor{MCPClient: &MCPClient{client: clientSession}, ToolName: "wait"}
+	_, err = executor.Execute(ctx, ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "wait", Arguments: `{"name":"ark"}`}})
+	require.ErrorIs(t, err, context.Canceled)
+
+	select {
+	case <-cancelled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the MCP server was not notified that the tool call was cancelled")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
 	"net/url"
 	"strings"
@@ -25,6 +26,7 @@ type HTTPMemory struct {
 	name             string
 	namespace        string
 	headers          map[string]string
+	overrideHeaders  map[string]string
 	eventingRecorder eventing.MemoryRecorder
 }
 
@@ -56,10 +58,17 @@ func NewHTTPMemory(ctx context.Context, k8sClient client.Client, memoryName, nam
 		return nil, fmt.Errorf("failed to resolve headers: %w", err)
 	}
 
+	// The query's overrides are resolved once, as later operations may run outside of its context
+	overrideHeaders, err := resolveQueryOverrideHeaders(ctx, k8sClient, OverrideTypeMemory, memoryName, namespace)
+	if err != nil {
+		return nil, err
+	}
+	maps.Copy(headers, overrideHeaders)
+
 	baseURL := strings.TrimSuffix(*memory.Status.LastResolvedAddress, "/")
 
 	// Create conversation or use provided ID
-	conversationId, err := createConversation(ctx, httpClient, baseURL, config.ConversationId)
+	conversationId, err := createConversation(ctx, httpClient, baseURL, config.ConversationId, headers)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create conversation: %w", err)
 	}
@@ -72,13 +81,14 @@ func NewHTTPMemory(ctx context.Context, k8sClient client.Client, memoryName, nam
 		name:             memoryName,
 		namespace:        namespace,
 		headers:          headers,
+		overrideHeaders:  overrideHeaders,
 		eventingRecorder: memoryRecorder,
 	}, nil
 }
 
 // createConversation calls broker to create a new conversation and get its ID.
 // If conversationID is already provided (non-empty), it returns that ID without making an HTTP call.
-func createConversation(ctx context.Context, httpClient *http.Client, baseURL, conversationID string) (string, error) {
+func createConversation(ctx context.Context, httpClient *http.Client, baseURL, conversationID string, headers map[string]string) (string, error) {
 	if conversationID != "" {
 		return conversationID, nil
 	}
@@ -95,6 +105,9 @@ func createConversation(ctx context.Context, httpClient *http.Client, baseURL, c
 
 	req.Header.Set("Content-Type", ContentTypeJSON)
 	req.Header.Set("User-Agent", UserAgent)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
@@ -152,6 +165,7 @@ func (m *HTTPMemory) resolveAndUpdateAddress(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to resolve headers: %w", err)
 	}
+	maps.Copy(headers, m.overrideHeaders)
 	m.headers = headers
 
 	return nil
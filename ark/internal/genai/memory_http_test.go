@@ -633,6 +633,51 @@ func TestHTTPMemoryWithQueryParameterRefHeaders(t *testing.T) {
 	require.Contains(t, err.Error(), "queryParameterRef requires query context")
 }
 
+func TestHTTPMemoryForwardsQueryOverrideHeaders(t *testing.T) {
+	received := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received[r.URL.Path] = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == ConversationsEndpoint {
+			_ = json.NewEncoder(w).Encode(map[string]string{"conversation_id": "test-conv-id"})
+		}
+	}))
+	defer server.Close()
+
+	resolvedAddress := server.URL
+	memory := &arkv1alpha1.Memory{
+		ObjectMeta: metav1.ObjectMeta{Name: "user-memory", Namespace: "default", Labels: map[string]string{"identity": "end-user"}},
+		Spec: arkv1alpha1.MemorySpec{
+			Address: arkv1alpha1.ValueSource{Value: server.URL},
+			Headers: []arkv1alpha1.Header{{Name: "Authorization", Value: arkv1alpha1.HeaderValue{Value: "Bearer service"}}},
+		},
+		Status: arkv1alpha1.MemoryStatus{LastResolvedAddress: &resolvedAddress, Phase: "ready"},
+	}
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-query", Namespace: "default"},
+		Spec: arkv1alpha1.QuerySpec{
+			Parameters: []arkv1alpha1.Parameter{{Name: "token", Value: "Bearer end-user"}},
+			Overrides: []arkv1alpha1.Override{{
+				ResourceType:  string(OverrideTypeMemory),
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"identity": "end-user"}},
+				Headers: []arkv1alpha1.Header{{
+					Name:  "Authorization",
+					Value: arkv1alpha1.HeaderValue{ValueFrom: &arkv1alpha1.HeaderValueSource{QueryParameterRef: &arkv1alpha1.QueryParameterReference{Name: "token"}}},
+				}},
+			}},
+		},
+	}
+	fakeClient := setupMemoryTestClient([]client.Object{memory})
+
+	ctx := context.WithValue(context.Background(), QueryContextKey, query)
+	mem, err := NewHTTPMemory(ctx, fakeClient, "user-memory", "default", Config{}, &noOpMemoryRecorder{})
+	require.NoError(t, err)
+	require.NoError(t, mem.AddMessages(context.Background(), "query-id", []Message{Message(openai.UserMessage("hi"))}))
+
+	require.Equal(t, "Bearer end-user", received[ConversationsEndpoint])
+	require.Equal(t, "Bearer end-user", received[MessagesEndpoint], "overrides are kept for operations outside the query context")
+}
+
 func TestNewHTTPMemoryWithMixedHeaderSources(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == ConversationsEndpoint {
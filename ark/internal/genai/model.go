@@ -56,6 +56,10 @@ func LoadModel(ctx context.Context, k8sClient client.Client, modelSpec interface
 		return nil, fmt.Errorf("failed to load model CRD %s in namespace %s: %w", modelName, namespace, err)
 	}
 
+	return loadModelFromCRD(ctx, k8sClient, modelCRD, namespace, additionalHeaders, telemetryRecorder, eventingRecorder)
+}
+
+func loadModelFromCRD(ctx context.Context, k8sClient client.Client, modelCRD *arkv1alpha1.Model, namespace string, additionalHeaders map[string]string, telemetryRecorder telemetry.ModelRecorder, eventingRecorder eventing.ModelRecorder) (*Model, error) {
 	resolver := common.NewValueSourceResolver(k8sClient)
 	model, err := resolver.ResolveValueSource(ctx, modelCRD.Spec.Model, namespace)
 	if err != nil {
@@ -63,8 +67,11 @@ func LoadModel(ctx context.Context, k8sClient client.Client, modelSpec interface
 	}
 
 	modelInstance := &Model{
+		Name:              modelCRD.Name,
 		Model:             model,
 		Type:              modelCRD.Spec.Type,
+		Pricing:           modelCRD.Spec.Pricing,
+		RetryPolicy:       modelCRD.Spec.RetryPolicy,
 		telemetryRecorder: telemetryRecorder,
 		eventingRecorder:  eventingRecorder,
 	}
@@ -82,6 +89,14 @@ func LoadModel(ctx context.Context, k8sClient client.Client, modelSpec interface
 		if err := loadBedrockConfig(ctx, resolver, modelCRD.Spec.Config.Bedrock, namespace, model, modelInstance); err != nil {
 			return nil, err
 		}
+	case ProviderAnthropic:
+		if err := loadAnthropicConfig(ctx, resolver, modelCRD.Spec.Config.Anthropic, namespace, modelInstance, additionalHeaders); err != nil {
+			return nil, err
+		}
+	case ProviderGemini:
+		if err := loadGeminiConfig(ctx, resolver, modelCRD.Spec.Config.Gemini, namespace, modelInstance, additionalHeaders); err != nil {
+			return nil, err
+		}
 	default:
 		if modelCRD.Spec.Provider == "" {
 			if IsDeprecatedProviderInType(modelCRD.Spec.Type) {
@@ -92,9 +107,26 @@ func LoadModel(ctx context.Context, k8sClient client.Client, modelSpec interface
 		return nil, fmt.Errorf("unsupported provider: %s", modelCRD.Spec.Provider)
 	}
 
+	if modelInstance.RetryPolicy != nil {
+		disableClientRetries(modelInstance.Provider)
+	}
+
 	return modelInstance, nil
 }
 
+// disableClientRetries turns off the retries built into the provider SDKs, so that the
+// model's retry policy alone decides how often a call is attempted
+func disableClientRetries(provider ChatCompletionProvider) {
+	switch p := provider.(type) {
+	case *OpenAIProvider:
+		p.DisableRetries = true
+	case *AzureProvider:
+		p.DisableRetries = true
+	case *BedrockModel:
+		p.DisableRetries = true
+	}
+}
+
 func loadModelCRD(ctx context.Context, k8sClient client.Client, name, namespace string) (*arkv1alpha1.Model, error) {
 	var modelCRD arkv1alpha1.Model
 	key := types.NamespacedName{Name: name, Namespace: namespace}
@@ -0,0 +1,118 @@
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/eventing"
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+// LoadModelWithFallbacks loads an agent's model together with its fallback models. Models whose
+// probe reports them unavailable are moved behind the available ones, and the first model of the
+// resulting chain is returned with the others as its fallbacks. Headers are keyed by model name.
+func LoadModelWithFallbacks(ctx context.Context, k8sClient client.Client, modelRef *arkv1alpha1.AgentModelRef, fallbackRefs []arkv1alpha1.AgentModelRef, defaultNamespace string, headers map[string]map[string]string, telemetryRecorder telemetry.ModelRecorder, eventingRecorder eventing.ModelRecorder) (*Model, error) {
+	if len(fallbackRefs) == 0 {
+		return LoadModel(ctx, k8sClient, modelRef, defaultNamespace, modelHeaders(headers, modelRef), telemetryRecorder, eventingRecorder)
+	}
+
+	log := logf.FromContext(ctx)
+	refs := []*arkv1alpha1.AgentModelRef{modelRef}
+	for i := range fallbackRefs {
+		refs = append(refs, &fallbackRefs[i])
+	}
+
+	var chain, unavailable []*Model
+	var primaryErr error
+	for i, ref := range refs {
+		model, modelAvailable, err := loadChainModel(ctx, k8sClient, ref, defaultNamespace, modelHeaders(headers, ref), telemetryRecorder, eventingRecorder)
+		if err != nil {
+			if i == 0 {
+				primaryErr = err
+			}
+			log.Info("skipping model that failed to load", "model", ref.Name, "error", err.Error())
+			continue
+		}
+		if modelAvailable {
+			chain = append(chain, model)
+		} else {
+			unavailable = append(unavailable, model)
+		}
+	}
+
+	chain = append(chain, unavailable...)
+	if len(chain) == 0 {
+		return nil, primaryErr
+	}
+
+	primaryName := modelRef.Name
+	for _, model := range chain {
+		if model.Name != primaryName {
+			model.fallbackFor = primaryName
+		}
+	}
+	chain[0].Fallbacks = chain[1:]
+	return chain[0], nil
+}
+
+// loadChainModel loads one model of a fallback chain and reports whether its probe last found it
+// available. Models that have not been probed yet are treated as available.
+func loadChainModel(ctx context.Context, k8sClient client.Client, ref *arkv1alpha1.AgentModelRef, defaultNamespace string, additionalHeaders map[string]string, telemetryRecorder telemetry.ModelRecorder, eventingRecorder eventing.ModelRecorder) (*Model, bool, error) {
+	modelName, namespace, err := ResolveModelSpec(ref, defaultNamespace)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve model spec: %w", err)
+	}
+	modelCRD, err := loadModelCRD(ctx, k8sClient, modelName, namespace)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load model CRD %s in namespace %s: %w", modelName, namespace, err)
+	}
+	model, err := loadModelFromCRD(ctx, k8sClient, modelCRD, namespace, additionalHeaders, telemetryRecorder, eventingRecorder)
+	if err != nil {
+		return nil, false, err
+	}
+	return model, !meta.IsStatusConditionFalse(modelCRD.Status.Conditions, "ModelAvailable"), nil
+}
+
+func modelHeaders(headers map[string]map[string]string, ref *arkv1alpha1.AgentModelRef) map[string]string {
+	if ref == nil {
+		return nil
+	}
+	return headers[ref.Name]
+}
+
+// shouldFallback reports whether a failed call is worth sending to the next model: the provider
+// answered with a status the model would retry, or could not be reached in time
+func (m *Model) shouldFallback(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if status, _ := retryStatus(err); status != 0 {
+		policy := newRetryPolicy(m.RetryPolicy)
+		if len(policy.retryOn) == 0 {
+			policy.retryOn = defaultRetryOn
+		}
+		return policy.retries(status)
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// UsedModelName returns the name of the Model resource that answered the last successful call,
+// which is a fallback model when the primary model failed
+func (m *Model) UsedModelName() string {
+	m.usedMutex.Lock()
+	defer m.usedMutex.Unlock()
+	if m.usedName == "" {
+		return m.Name
+	}
+	return m.usedName
+}
@@ -0,0 +1,93 @@
+package genai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func newFallbackTestModel(name string, provider ChatCompletionProvider) *Model {
+	model := newRetryTestModel(provider, nil)
+	model.Name = name
+	return model
+}
+
+func openAIModelCRD(name string, available metav1.ConditionStatus) *arkv1alpha1.Model {
+	model := &arkv1alpha1.Model{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: arkv1alpha1.ModelSpec{
+			Provider: ProviderOpenAI,
+			Model:    arkv1alpha1.ValueSource{Value: "gpt-4o"},
+			Config: arkv1alpha1.ModelConfig{OpenAI: &arkv1alpha1.OpenAIModelConfig{
+				BaseURL: arkv1alpha1.ValueSource{Value: "https://api.openai.com/v1"},
+				APIKey:  arkv1alpha1.ValueSource{Value: "test-key"},
+			}},
+		},
+	}
+	if available != "" {
+		model.Status.Conditions = []metav1.Condition{{Type: "ModelAvailable", Status: available, Reason: "Probe"}}
+	}
+	return model
+}
+
+func TestModelFallsBackOnRetryableErrors(t *testing.T) {
+	primaryProvider := &flakyProvider{errs: []error{apiError(http.StatusServiceUnavailable, nil)}}
+	fallbackProvider := &flakyProvider{}
+	primary := newFallbackTestModel("primary", primaryProvider)
+	fallback := newFallbackTestModel("fallback", fallbackProvider)
+	fallback.fallbackFor = "primary"
+	primary.Fallbacks = []*Model{fallback}
+
+	response, err := primary.ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", response.Choices[0].Message.Content)
+	assert.Equal(t, 1, primaryProvider.calls)
+	assert.Equal(t, 1, fallbackProvider.calls)
+	assert.Equal(t, "fallback", primary.UsedModelName())
+
+	// The primary model is tried again on the next call
+	_, err = primary.ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, primaryProvider.calls)
+	assert.Equal(t, "primary", primary.UsedModelName())
+}
+
+func TestModelDoesNotFallBackOnClientErrors(t *testing.T) {
+	fallbackProvider := &flakyProvider{}
+	primary := newFallbackTestModel("primary", &flakyProvider{errs: []error{apiError(http.StatusBadRequest, nil)}})
+	primary.Fallbacks = []*Model{newFallbackTestModel("fallback", fallbackProvider)}
+
+	_, err := primary.ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, nil, 1)
+	require.Error(t, err)
+	assert.Zero(t, fallbackProvider.calls)
+}
+
+func TestLoadModelWithFallbacksOrdersUnavailableModelsLast(t *testing.T) {
+	k8sClient := setupModelTestClient([]client.Object{
+		openAIModelCRD("primary", metav1.ConditionFalse),
+		openAIModelCRD("secondary", metav1.ConditionTrue),
+		openAIModelCRD("unprobed", ""),
+	})
+	fallbacks := []arkv1alpha1.AgentModelRef{{Name: "missing"}, {Name: "secondary"}, {Name: "unprobed"}}
+
+	model, err := LoadModelWithFallbacks(context.Background(), k8sClient, &arkv1alpha1.AgentModelRef{Name: "primary"}, fallbacks, "default", nil,
+		noop.NewModelRecorder(), eventnoop.NewProvider().ModelRecorder())
+	require.NoError(t, err)
+
+	names := []string{model.Name}
+	for _, fallback := range model.Fallbacks {
+		names = append(names, fallback.Name)
+	}
+	assert.Equal(t, []string{"secondary", "unprobed", "primary"}, names)
+	assert.Equal(t, "primary", model.fallbackFor)
+	assert.Empty(t, model.Fallbacks[1].fallbackFor)
+}
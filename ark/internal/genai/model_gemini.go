@@ -0,0 +1,127 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+)
+
+const (
+	geminiAPIBaseURL       = "https://generativelanguage.googleapis.com/v1beta"
+	vertexDefaultLocation  = "us-central1"
+	vertexGlobalLocation   = "global"
+	vertexGlobalAPIBaseURL = "https://aiplatform.googleapis.com/v1"
+)
+
+func loadGeminiConfig(ctx context.Context, resolver *common.ValueSourceResolver, config *arkv1alpha1.GeminiModelConfig, namespace string, model *Model, additionalHeaders map[string]string) error {
+	if config == nil {
+		return fmt.Errorf("gemini configuration is required for gemini provider")
+	}
+
+	apiKey, err := resolveGeminiValue(ctx, resolver, config.APIKey, namespace, "apiKey")
+	if err != nil {
+		return err
+	}
+	serviceAccountKey, err := resolveGeminiValue(ctx, resolver, config.ServiceAccountKey, namespace, "serviceAccountKey")
+	if err != nil {
+		return err
+	}
+	if (apiKey == "") == (serviceAccountKey == "") {
+		return fmt.Errorf("gemini configuration requires exactly one of apiKey or serviceAccountKey")
+	}
+
+	baseURL, err := resolveGeminiValue(ctx, resolver, config.BaseURL, namespace, "baseURL")
+	if err != nil {
+		return err
+	}
+	if baseURL == "" {
+		baseURL = geminiAPIBaseURL
+		if serviceAccountKey != "" {
+			if baseURL, err = vertexBaseURL(ctx, resolver, config, namespace, serviceAccountKey); err != nil {
+				return err
+			}
+		}
+	}
+
+	headers, err := resolveModelHeaders(ctx, resolver.Client, config.Headers, namespace)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range additionalHeaders {
+		headers[k] = v
+	}
+
+	var properties map[string]string
+	if config.Properties != nil {
+		properties = make(map[string]string)
+		for key, valueSource := range config.Properties {
+			value, err := resolver.ResolveValueSource(ctx, valueSource, namespace)
+			if err != nil {
+				return fmt.Errorf("failed to resolve Gemini property %s: %w", key, err)
+			}
+			properties[key] = value
+		}
+	}
+
+	model.Provider = &GeminiProvider{
+		Model:             model.Model,
+		BaseURL:           baseURL,
+		APIKey:            apiKey,
+		ServiceAccountKey: serviceAccountKey,
+		Headers:           headers,
+		Properties:        properties,
+	}
+	model.Properties = properties
+
+	return nil
+}
+
+func resolveGeminiValue(ctx context.Context, resolver *common.ValueSourceResolver, valueSource *arkv1alpha1.ValueSource, namespace, field string) (string, error) {
+	if valueSource == nil {
+		return "", nil
+	}
+	value, err := resolver.ResolveValueSource(ctx, *valueSource, namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve Gemini %s: %w", field, err)
+	}
+	return value, nil
+}
+
+// vertexBaseURL returns the Vertex AI root of Google's publisher models in the configured project
+// and location. The project defaults to the one the service account belongs to.
+func vertexBaseURL(ctx context.Context, resolver *common.ValueSourceResolver, config *arkv1alpha1.GeminiModelConfig, namespace, serviceAccountKey string) (string, error) {
+	project, err := resolveGeminiValue(ctx, resolver, config.Project, namespace, "project")
+	if err != nil {
+		return "", err
+	}
+	if project == "" {
+		var key struct {
+			ProjectID string `json:"project_id"`
+		}
+		if err := json.Unmarshal([]byte(serviceAccountKey), &key); err != nil {
+			return "", fmt.Errorf("failed to parse Gemini serviceAccountKey: %w", err)
+		}
+		project = key.ProjectID
+	}
+	if project == "" {
+		return "", fmt.Errorf("gemini project is required when the service account key has no project_id")
+	}
+
+	location, err := resolveGeminiValue(ctx, resolver, config.Location, namespace, "location")
+	if err != nil {
+		return "", err
+	}
+	if location == "" {
+		location = vertexDefaultLocation
+	}
+
+	root := fmt.Sprintf("https://%s-aiplatform.googleapis.com/v1", location)
+	if location == vertexGlobalLocation {
+		root = vertexGlobalAPIBaseURL
+	}
+	return fmt.Sprintf("%s/projects/%s/locations/%s/publishers/google", root, project, location), nil
+}
@@ -3,9 +3,12 @@ package genai
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/openai/openai-go"
 	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/eventing"
 	"mckinsey.com/ark/internal/telemetry"
@@ -22,14 +25,24 @@ type ConfigProvider interface {
 }
 
 type Model struct {
+	Name              string
 	Model             string
 	Type              string
 	Properties        map[string]string
 	Provider          ChatCompletionProvider
 	OutputSchema      *runtime.RawExtension
 	SchemaName        string
+	Pricing           *arkv1alpha1.ModelPricing
+	RetryPolicy       *arkv1alpha1.ModelRetryPolicy
+	Fallbacks         []*Model
 	telemetryRecorder telemetry.ModelRecorder
 	eventingRecorder  eventing.ModelRecorder
+
+	// fallbackFor is the name of the primary model when this model is a fallback, and usedName
+	// the model that answered the last call of a fallback chain
+	fallbackFor string
+	usedMutex   sync.Mutex
+	usedName    string
 }
 
 func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStream EventStreamInterface, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
@@ -37,8 +50,38 @@ func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStr
 		return nil, nil
 	}
 
-	ctx, span := m.telemetryRecorder.StartModelExecution(ctx, m.Model, m.Type)
-	defer span.End()
+	if m.OutputSchema != nil {
+		m.Provider.SetOutputSchema(m.OutputSchema, m.SchemaName)
+	}
+
+	used := m
+	response, streamed, err := m.chatCompletion(ctx, messages, eventStream, n, tools...)
+	for _, fallback := range m.Fallbacks {
+		if err == nil || streamed || !used.shouldFallback(ctx, err) {
+			break
+		}
+		logf.FromContext(ctx).Info("falling back to next model", "model", used.Name, "fallback", fallback.Name, "error", err.Error())
+		fallback.OutputSchema = m.OutputSchema
+		fallback.SchemaName = m.SchemaName
+		if fallback.OutputSchema != nil {
+			fallback.Provider.SetOutputSchema(fallback.OutputSchema, fallback.SchemaName)
+		}
+		used = fallback
+		response, streamed, err = fallback.chatCompletion(ctx, messages, eventStream, n, tools...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m.usedMutex.Lock()
+	m.usedName = used.Name
+	m.usedMutex.Unlock()
+	return response, nil
+}
+
+// chatCompletion calls the model's own provider, retrying as its retry policy allows. It reports
+// whether any part of the response was streamed before the call failed.
+func (m *Model) chatCompletion(ctx context.Context, messages []Message, eventStream EventStreamInterface, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, bool, error) {
 
 	operationData := map[string]string{
 		"model":     m.Model,
@@ -46,6 +89,51 @@ func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStr
 	}
 	ctx = m.eventingRecorder.Start(ctx, "LLMCall", fmt.Sprintf("Calling model %s", m.Model), operationData)
 
+	policy := newRetryPolicy(m.RetryPolicy)
+	var response *openai.ChatCompletion
+	var streamed bool
+	var err error
+	for attempt := 1; ; attempt++ {
+		response, err = m.attemptChatCompletion(ctx, messages, eventStream, n, attempt, policy.maxAttempts, &streamed, tools...)
+		if err == nil {
+			break
+		}
+
+		// A partially streamed response cannot be taken back, so it is not retried
+		delay, retry := policy.retryDelay(err, attempt)
+		if !retry || streamed {
+			break
+		}
+		logf.FromContext(ctx).Info("retrying model call", "model", m.Model, "attempt", attempt, "maxAttempts", policy.maxAttempts, "delay", delay.String(), "error", err.Error())
+		if waitForRetry(ctx, delay) != nil {
+			break
+		}
+	}
+
+	if err != nil {
+		m.eventingRecorder.Fail(ctx, "LLMCall", fmt.Sprintf("Model call failed: %v", err), err, operationData)
+		return nil, streamed, err
+	}
+
+	m.eventingRecorder.Complete(ctx, "LLMCall", "Model call completed successfully", operationData)
+	usage := arkv1alpha1.TokenUsage{
+		PromptTokens:     response.Usage.PromptTokens,
+		CompletionTokens: response.Usage.CompletionTokens,
+		TotalTokens:      response.Usage.TotalTokens,
+	}
+	m.eventingRecorder.AddTokenUsage(ctx, usage)
+	if tracker := GetBudgetTracker(ctx); tracker != nil {
+		tracker.Record(usage, m.Pricing)
+	}
+
+	return response, false, nil
+}
+
+// attemptChatCompletion makes a single call to the provider. Each attempt is traced in its own span.
+func (m *Model) attemptChatCompletion(ctx context.Context, messages []Message, eventStream EventStreamInterface, n int64, attempt, maxAttempts int, streamed *bool, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	ctx, span := m.telemetryRecorder.StartModelExecution(ctx, m.Model, m.Type)
+	defer span.End()
+
 	otelMessages := make([]openai.ChatCompletionMessageParamUnion, len(messages))
 	for i, msg := range messages {
 		otelMessages[i] = openai.ChatCompletionMessageParamUnion(msg)
@@ -53,9 +141,11 @@ func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStr
 
 	m.telemetryRecorder.RecordInput(span, otelMessages)
 	m.telemetryRecorder.RecordModelDetails(span, m.Model, m.Type)
-
-	if m.OutputSchema != nil {
-		m.Provider.SetOutputSchema(m.OutputSchema, m.SchemaName)
+	if maxAttempts > 1 {
+		m.telemetryRecorder.RecordAttempt(span, attempt, maxAttempts)
+	}
+	if m.fallbackFor != "" {
+		m.telemetryRecorder.RecordFallback(span, m.Name, m.fallbackFor)
 	}
 
 	var response *openai.ChatCompletion
@@ -63,6 +153,7 @@ func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStr
 
 	if eventStream != nil {
 		response, err = m.Provider.ChatCompletionStream(ctx, messages, n, func(chunk *openai.ChatCompletionChunk) error {
+			*streamed = true
 			chunkWithMeta := WrapChunkWithMetadata(ctx, chunk, m.Model, nil)
 			return eventStream.StreamChunk(ctx, chunkWithMeta)
 		}, tools...)
@@ -72,14 +163,12 @@ func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStr
 
 	if err != nil {
 		m.telemetryRecorder.RecordError(span, err)
-		m.eventingRecorder.Fail(ctx, "LLMCall", fmt.Sprintf("Model call failed: %v", err), err, operationData)
 		return nil, err
 	}
 
 	if response == nil {
 		err := fmt.Errorf("model provider returned nil response without error")
 		m.telemetryRecorder.RecordError(span, err)
-		m.eventingRecorder.Fail(ctx, "LLMCall", "Model returned nil response", err, operationData)
 		return nil, err
 	}
 
@@ -89,13 +178,6 @@ func (m *Model) ChatCompletion(ctx context.Context, messages []Message, eventStr
 
 	m.telemetryRecorder.RecordTokenUsage(span, response.Usage.PromptTokens, response.Usage.CompletionTokens, response.Usage.TotalTokens)
 	m.telemetryRecorder.RecordSuccess(span)
-	m.eventingRecorder.Complete(ctx, "LLMCall", "Model call completed successfully", operationData)
-	m.eventingRecorder.AddTokenUsage(ctx, arkv1alpha1.TokenUsage{
-		PromptTokens:     response.Usage.PromptTokens,
-		CompletionTokens: response.Usage.CompletionTokens,
-		TotalTokens:      response.Usage.TotalTokens,
-	})
-
 	return response, nil
 }
 
@@ -111,6 +193,10 @@ func (m *Model) HealthCheck(ctx context.Context) error {
 		return provider.HealthCheck(ctx)
 	case *BedrockModel:
 		return provider.HealthCheck(ctx)
+	case *AnthropicProvider:
+		return provider.HealthCheck(ctx)
+	case *GeminiProvider:
+		return provider.HealthCheck(ctx)
 	default:
 		testMessages := []Message{NewUserMessage("Hello")}
 		_, err := m.ChatCompletion(ctx, testMessages, nil, 1)
@@ -0,0 +1,89 @@
+package genai
+
+import (
+	"fmt"
+	"maps"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// resolveModelOverrideForAgent folds the query's model overrides that select the agent into the
+// model reference to use instead of the agent's own and the properties to merge over the
+// models' properties. Later overrides take precedence over earlier ones.
+func resolveModelOverrideForAgent(agentCRD *arkv1alpha1.Agent, queryCRD *arkv1alpha1.Query) (*arkv1alpha1.AgentModelRef, map[string]string, error) {
+	var modelRef *arkv1alpha1.AgentModelRef
+	var properties map[string]string
+
+	for i, override := range queryCRD.Spec.ModelOverrides {
+		selected, err := modelOverrideSelectsAgent(override, agentCRD)
+		if err != nil {
+			return nil, nil, fmt.Errorf("modelOverrides[%d]: %w", i, err)
+		}
+		if !selected {
+			continue
+		}
+
+		if override.ModelRef != nil {
+			modelRef = override.ModelRef.DeepCopy()
+		}
+		if len(override.Properties) > 0 {
+			if properties == nil {
+				properties = make(map[string]string, len(override.Properties))
+			}
+			maps.Copy(properties, override.Properties)
+		}
+	}
+
+	return modelRef, properties, nil
+}
+
+func modelOverrideSelectsAgent(override arkv1alpha1.ModelOverride, agentCRD *arkv1alpha1.Agent) (bool, error) {
+	if override.Agent != "" && override.Agent != agentCRD.Name {
+		return false, nil
+	}
+	if override.LabelSelector == nil {
+		return true, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(override.LabelSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid label selector: %w", err)
+	}
+	return selector.Matches(labels.Set(agentCRD.Labels)), nil
+}
+
+// applyModelPropertyOverrides merges properties over those of the model and its fallbacks
+func applyModelPropertyOverrides(model *Model, properties map[string]string) {
+	if model == nil || len(properties) == 0 {
+		return
+	}
+
+	for _, m := range append([]*Model{model}, model.Fallbacks...) {
+		merged := make(map[string]string, len(m.Properties)+len(properties))
+		maps.Copy(merged, m.Properties)
+		maps.Copy(merged, properties)
+		setModelProperties(m, merged)
+	}
+}
+
+// setModelProperties replaces the properties of the model and of the provider that applies them
+// to requests
+func setModelProperties(model *Model, properties map[string]string) {
+	model.Properties = properties
+
+	switch p := model.Provider.(type) {
+	case *OpenAIProvider:
+		p.Properties = properties
+	case *AzureProvider:
+		p.Properties = properties
+	case *AnthropicProvider:
+		p.Properties = properties
+	case *GeminiProvider:
+		p.Properties = properties
+	case *BedrockModel:
+		p.Properties = properties
+	}
+}
@@ -0,0 +1,116 @@
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func TestResolveModelOverrideForAgent(t *testing.T) {
+	agent := &arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{
+		Name:      "weather",
+		Namespace: "default",
+		Labels:    map[string]string{"suite": "eval"},
+	}}
+	query := func(overrides ...arkv1alpha1.ModelOverride) *arkv1alpha1.Query {
+		return &arkv1alpha1.Query{Spec: arkv1alpha1.QuerySpec{ModelOverrides: overrides}}
+	}
+
+	tests := []struct {
+		name           string
+		query          *arkv1alpha1.Query
+		wantModel      string
+		wantProperties map[string]string
+		wantErr        string
+	}{
+		{
+			name:  "no overrides",
+			query: query(),
+		},
+		{
+			name:      "selected by name",
+			query:     query(arkv1alpha1.ModelOverride{Agent: "weather", ModelRef: &arkv1alpha1.AgentModelRef{Name: "claude"}}),
+			wantModel: "claude",
+		},
+		{
+			name:  "other agent",
+			query: query(arkv1alpha1.ModelOverride{Agent: "travel", ModelRef: &arkv1alpha1.AgentModelRef{Name: "claude"}}),
+		},
+		{
+			name: "selected by label",
+			query: query(arkv1alpha1.ModelOverride{
+				LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"suite": "eval"}},
+				Properties:    map[string]string{"temperature": "0"},
+			}),
+			wantProperties: map[string]string{"temperature": "0"},
+		},
+		{
+			name: "later overrides take precedence",
+			query: query(
+				arkv1alpha1.ModelOverride{ModelRef: &arkv1alpha1.AgentModelRef{Name: "claude"}, Properties: map[string]string{"temperature": "0", "seed": "42"}},
+				arkv1alpha1.ModelOverride{Agent: "weather", ModelRef: &arkv1alpha1.AgentModelRef{Name: "gemini"}, Properties: map[string]string{"temperature": "0.7"}},
+			),
+			wantModel:      "gemini",
+			wantProperties: map[string]string{"temperature": "0.7", "seed": "42"},
+		},
+		{
+			name: "invalid label selector",
+			query: query(arkv1alpha1.ModelOverride{
+				LabelSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "suite", Operator: "Near"}}},
+				Properties:    map[string]string{"temperature": "0"},
+			}),
+			wantErr: "modelOverrides[0]: invalid label selector",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modelRef, properties, err := resolveModelOverrideForAgent(agent, tt.query)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantModel == "" {
+				assert.Nil(t, modelRef)
+			} else {
+				require.NotNil(t, modelRef)
+				assert.Equal(t, tt.wantModel, modelRef.Name)
+			}
+			assert.Equal(t, tt.wantProperties, properties)
+		})
+	}
+}
+
+func TestApplyModelPropertyOverridesToFallbackChain(t *testing.T) {
+	primary := openAIModelCRD("primary", metav1.ConditionTrue)
+	primary.Spec.Config.OpenAI.Properties = map[string]arkv1alpha1.ValueSource{
+		"temperature": {Value: "1"},
+		"max_tokens":  {Value: "512"},
+	}
+	k8sClient := setupModelTestClient([]client.Object{primary, openAIModelCRD("secondary", metav1.ConditionTrue)})
+
+	model, err := LoadModelWithFallbacks(context.Background(), k8sClient, &arkv1alpha1.AgentModelRef{Name: "primary"},
+		[]arkv1alpha1.AgentModelRef{{Name: "secondary"}}, "default", nil,
+		noop.NewModelRecorder(), eventnoop.NewProvider().ModelRecorder())
+	require.NoError(t, err)
+
+	applyModelPropertyOverrides(model, map[string]string{"temperature": "0", "seed": "42"})
+
+	want := map[string]string{"temperature": "0", "max_tokens": "512", "seed": "42"}
+	assert.Equal(t, want, model.Properties)
+	assert.Equal(t, want, model.Provider.(*OpenAIProvider).Properties)
+
+	require.Len(t, model.Fallbacks, 1)
+	fallbackWant := map[string]string{"temperature": "0", "seed": "42"}
+	assert.Equal(t, fallbackWant, model.Fallbacks[0].Properties)
+	assert.Equal(t, fallbackWant, model.Fallbacks[0].Provider.(*OpenAIProvider).Properties)
+}
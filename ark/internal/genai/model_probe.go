@@ -69,6 +69,18 @@ func extractStableError(err error, timeout time.Duration) string {
 		return fmt.Sprintf("%s (%d)", openaiErr.Message, openaiErr.StatusCode)
 	}
 
+	// Anthropic API error
+	var anthropicErr *AnthropicError
+	if errors.As(err, &anthropicErr) {
+		return fmt.Sprintf("%s (%d)", anthropicErr.Message, anthropicErr.StatusCode)
+	}
+
+	// Gemini API error
+	var geminiErr *GeminiError
+	if errors.As(err, &geminiErr) {
+		return fmt.Sprintf("%s (%d)", geminiErr.Message, geminiErr.StatusCode)
+	}
+
 	// AWS Smithy API error with HTTP response
 	var httpErr *smithyhttp.ResponseError
 	if errors.As(err, &httpErr) {
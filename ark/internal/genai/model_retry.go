@@ -0,0 +1,160 @@
+package genai
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openai/openai-go"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const (
+	defaultRetryMaxAttempts    = 3
+	defaultRetryInitialBackoff = time.Second
+	defaultRetryMaxBackoff     = 30 * time.Second
+)
+
+var defaultRetryOn = []string{"408", "429", "5xx"}
+
+// retryPolicy is a ModelRetryPolicy with defaults applied
+type retryPolicy struct {
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	retryOn        []string
+}
+
+// newRetryPolicy returns the retry policy of a model. Without a policy a model call is
+// attempted once.
+func newRetryPolicy(policy *arkv1alpha1.ModelRetryPolicy) retryPolicy {
+	if policy == nil {
+		return retryPolicy{maxAttempts: 1}
+	}
+
+	p := retryPolicy{
+		maxAttempts:    defaultRetryMaxAttempts,
+		initialBackoff: defaultRetryInitialBackoff,
+		maxBackoff:     defaultRetryMaxBackoff,
+		retryOn:        defaultRetryOn,
+	}
+	if policy.MaxAttempts != nil {
+		p.maxAttempts = int(*policy.MaxAttempts)
+	}
+	if policy.InitialBackoff != nil {
+		p.initialBackoff = policy.InitialBackoff.Duration
+	}
+	if policy.MaxBackoff != nil {
+		p.maxBackoff = policy.MaxBackoff.Duration
+	}
+	if len(policy.RetryOn) > 0 {
+		p.retryOn = policy.RetryOn
+	}
+	return p
+}
+
+// retryDelay returns how long to wait before the next attempt, or false when the failed
+// attempt must not be retried
+func (p retryPolicy) retryDelay(err error, attempt int) (time.Duration, bool) {
+	if attempt >= p.maxAttempts {
+		return 0, false
+	}
+	status, retryAfter := retryStatus(err)
+	if status == 0 || !p.retries(status) {
+		return 0, false
+	}
+	if retryAfter > 0 {
+		return retryAfter, true
+	}
+	return p.backoff(attempt), true
+}
+
+func (p retryPolicy) retries(status int) bool {
+	code := strconv.Itoa(status)
+	for _, pattern := range p.retryOn {
+		if pattern == code || (strings.HasSuffix(pattern, "xx") && pattern[0] == code[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff doubles the initial backoff for each attempt, capped at the maximum, and picks a
+// random delay in the upper half so that concurrent callers do not retry in lockstep
+func (p retryPolicy) backoff(attempt int) time.Duration {
+	delay := p.initialBackoff
+	for i := 1; i < attempt && delay < p.maxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, p.maxBackoff)
+	if delay <= 0 {
+		return 0
+	}
+	half := delay / 2
+	return half + rand.N(delay-half+1)
+}
+
+// retryStatus extracts the HTTP status and Retry-After delay from a provider error.
+// The status is 0 when the error did not come from an HTTP response.
+func retryStatus(err error) (int, time.Duration) {
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) {
+		var retryAfter time.Duration
+		if apiErr.Response != nil {
+			retryAfter = parseRetryAfter(apiErr.Response.Header, time.Now())
+		}
+		return apiErr.StatusCode, retryAfter
+	}
+
+	var anthropicErr *AnthropicError
+	if errors.As(err, &anthropicErr) {
+		return anthropicErr.StatusCode, parseRetryAfter(anthropicErr.Header, time.Now())
+	}
+
+	var geminiErr *GeminiError
+	if errors.As(err, &geminiErr) {
+		return geminiErr.StatusCode, parseRetryAfter(geminiErr.Header, time.Now())
+	}
+
+	// AWS SDK errors expose the status of the response they were built from
+	var statusErr interface{ HTTPStatusCode() int }
+	if errors.As(err, &statusErr) {
+		return statusErr.HTTPStatusCode(), 0
+	}
+	return 0, 0
+}
+
+// parseRetryAfter reads the retry-after-ms header sent by OpenAI and Azure OpenAI, or the
+// standard Retry-After header in seconds or as an HTTP date
+func parseRetryAfter(header http.Header, now time.Time) time.Duration {
+	if ms, err := strconv.ParseFloat(header.Get("Retry-After-Ms"), 64); err == nil && ms > 0 {
+		return time.Duration(ms * float64(time.Millisecond))
+	}
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds * float64(time.Second))
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
+func waitForRetry(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
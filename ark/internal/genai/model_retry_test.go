@@ -0,0 +1,139 @@
+package genai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// flakyProvider fails with the given errors before returning a response
+type flakyProvider struct {
+	errs  []error
+	calls int
+}
+
+func (p *flakyProvider) ChatCompletion(_ context.Context, _ []Message, _ int64, _ ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return nil, p.errs[p.calls-1]
+	}
+	return &openai.ChatCompletion{Choices: []openai.ChatCompletionChoice{{Message: openai.ChatCompletionMessage{Content: "ok"}}}}, nil
+}
+
+func (p *flakyProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, _ func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return p.ChatCompletion(ctx, messages, n, tools...)
+}
+
+func (p *flakyProvider) SetOutputSchema(_ *runtime.RawExtension, _ string) {}
+
+func apiError(status int, header http.Header) error {
+	return &openai.Error{
+		StatusCode: status,
+		Request:    &http.Request{Method: http.MethodPost, URL: &url.URL{Path: "/chat/completions"}},
+		Response:   &http.Response{StatusCode: status, Header: header},
+	}
+}
+
+func newRetryTestModel(provider ChatCompletionProvider, policy *arkv1alpha1.ModelRetryPolicy) *Model {
+	return &Model{
+		Model:             "gpt-4o",
+		Type:              "openai",
+		Provider:          provider,
+		RetryPolicy:       policy,
+		telemetryRecorder: noop.NewProvider().ModelRecorder(),
+		eventingRecorder:  eventnoop.NewProvider().ModelRecorder(),
+	}
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestModelRetriesRateLimitedCalls(t *testing.T) {
+	provider := &flakyProvider{errs: []error{
+		apiError(http.StatusTooManyRequests, http.Header{"Retry-After-Ms": []string{"1"}}),
+		apiError(http.StatusServiceUnavailable, nil),
+	}}
+	model := newRetryTestModel(provider, &arkv1alpha1.ModelRetryPolicy{
+		MaxAttempts:    int32Ptr(3),
+		InitialBackoff: &metav1.Duration{Duration: time.Millisecond},
+	})
+
+	response, err := model.ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, nil, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", response.Choices[0].Message.Content)
+	assert.Equal(t, 3, provider.calls)
+}
+
+func TestModelDoesNotRetryWithoutPolicyOrOnClientErrors(t *testing.T) {
+	provider := &flakyProvider{errs: []error{apiError(http.StatusTooManyRequests, nil)}}
+	_, err := newRetryTestModel(provider, nil).ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, nil, 1)
+	require.Error(t, err)
+	assert.Equal(t, 1, provider.calls)
+
+	provider = &flakyProvider{errs: []error{apiError(http.StatusBadRequest, nil)}}
+	_, err = newRetryTestModel(provider, &arkv1alpha1.ModelRetryPolicy{}).ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, nil, 1)
+	require.Error(t, err)
+	assert.Equal(t, 1, provider.calls)
+
+	provider = &flakyProvider{errs: []error{errors.New("connection reset")}}
+	_, err = newRetryTestModel(provider, &arkv1alpha1.ModelRetryPolicy{}).ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, nil, 1)
+	require.Error(t, err)
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestRetryPolicyStopsAfterMaxAttempts(t *testing.T) {
+	provider := &flakyProvider{errs: []error{
+		apiError(http.StatusBadGateway, nil),
+		apiError(http.StatusBadGateway, nil),
+		apiError(http.StatusBadGateway, nil),
+	}}
+	model := newRetryTestModel(provider, &arkv1alpha1.ModelRetryPolicy{
+		MaxAttempts:    int32Ptr(2),
+		InitialBackoff: &metav1.Duration{Duration: time.Millisecond},
+		RetryOn:        []string{"502"},
+	})
+
+	_, err := model.ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, nil, 1)
+	require.Error(t, err)
+	assert.Equal(t, 2, provider.calls)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := newRetryPolicy(&arkv1alpha1.ModelRetryPolicy{
+		InitialBackoff: &metav1.Duration{Duration: time.Second},
+		MaxBackoff:     &metav1.Duration{Duration: 3 * time.Second},
+	})
+
+	for range 20 {
+		first := policy.backoff(1)
+		assert.GreaterOrEqual(t, first, 500*time.Millisecond)
+		assert.LessOrEqual(t, first, time.Second)
+
+		capped := policy.backoff(5)
+		assert.GreaterOrEqual(t, capped, 1500*time.Millisecond)
+		assert.LessOrEqual(t, capped, 3*time.Second)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 1500*time.Millisecond, parseRetryAfter(http.Header{"Retry-After-Ms": []string{"1500"}}, now))
+	assert.Equal(t, 2*time.Second, parseRetryAfter(http.Header{"Retry-After": []string{"2"}}, now))
+	assert.Equal(t, 10*time.Second, parseRetryAfter(http.Header{"Retry-After": []string{now.Add(10 * time.Second).Format(http.TimeFormat)}}, now))
+	assert.Zero(t, parseRetryAfter(http.Header{}, now))
+}
@@ -17,8 +17,12 @@ import (
 type OverrideType string
 
 const (
-	OverrideTypeModel     OverrideType = "model"
-	OverrideTypeMCPServer OverrideType = "mcpserver"
+	OverrideTypeModel           OverrideType = "model"
+	OverrideTypeMCPServer       OverrideType = "mcpserver"
+	OverrideTypeTool            OverrideType = "tool"
+	OverrideTypeA2AServer       OverrideType = "a2aserver"
+	OverrideTypeExecutionEngine OverrideType = "executionengine"
+	OverrideTypeMemory          OverrideType = "memory"
 )
 
 func ResolveHeaders(ctx context.Context, k8sClient client.Client, headers []arkv1alpha1.Header, namespace string) (map[string]string, error) {
@@ -138,6 +142,42 @@ func listResourcesByLabels(ctx context.Context, k8sClient client.Client, namespa
 			resources = append(resources, &mcpServerList.Items[i])
 		}
 
+	case OverrideTypeTool:
+		var toolList arkv1alpha1.ToolList
+		if err := k8sClient.List(ctx, &toolList, listOpts); err != nil {
+			return nil, fmt.Errorf("failed to list tools: %w", err)
+		}
+		for i := range toolList.Items {
+			resources = append(resources, &toolList.Items[i])
+		}
+
+	case OverrideTypeA2AServer:
+		var a2aServerList arkv1prealpha1.A2AServerList
+		if err := k8sClient.List(ctx, &a2aServerList, listOpts); err != nil {
+			return nil, fmt.Errorf("failed to list A2A servers: %w", err)
+		}
+		for i := range a2aServerList.Items {
+			resources = append(resources, &a2aServerList.Items[i])
+		}
+
+	case OverrideTypeExecutionEngine:
+		var executionEngineList arkv1prealpha1.ExecutionEngineList
+		if err := k8sClient.List(ctx, &executionEngineList, listOpts); err != nil {
+			return nil, fmt.Errorf("failed to list execution engines: %w", err)
+		}
+		for i := range executionEngineList.Items {
+			resources = append(resources, &executionEngineList.Items[i])
+		}
+
+	case OverrideTypeMemory:
+		var memoryList arkv1alpha1.MemoryList
+		if err := k8sClient.List(ctx, &memoryList, listOpts); err != nil {
+			return nil, fmt.Errorf("failed to list memories: %w", err)
+		}
+		for i := range memoryList.Items {
+			resources = append(resources, &memoryList.Items[i])
+		}
+
 	default:
 		return nil, fmt.Errorf("unsupported overrideType: %s", overrideType)
 	}
@@ -178,3 +218,60 @@ func ResolveHeadersFromOverrides(ctx context.Context, k8sClient client.Client, o
 
 	return resourceHeaders, nil
 }
+
+// resolveHeadersFromAgentOverrides returns the headers the overrides of the agent, and then of
+// the query, set for resources of overrideType, keyed by resource name
+func resolveHeadersFromAgentOverrides(ctx context.Context, k8sClient client.Client, agentCRD *arkv1alpha1.Agent, queryCRD *arkv1alpha1.Query, overrideType OverrideType) (map[string]map[string]string, error) {
+	resourceHeaders, err := ResolveHeadersFromOverrides(ctx, k8sClient, agentCRD.Spec.Overrides, agentCRD.Namespace, overrideType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s headers for agent %s/%s: %w", overrideType, agentCRD.Namespace, agentCRD.Name, err)
+	}
+
+	queryHeaders, err := ResolveHeadersFromOverrides(ctx, k8sClient, queryCRD.Spec.Overrides, queryCRD.Namespace, overrideType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s headers from query %s/%s: %w", overrideType, queryCRD.Namespace, queryCRD.Name, err)
+	}
+
+	for name, headers := range queryHeaders {
+		if resourceHeaders[name] == nil {
+			resourceHeaders[name] = make(map[string]string)
+		}
+		maps.Copy(resourceHeaders[name], headers)
+	}
+	return resourceHeaders, nil
+}
+
+// ResolveA2AServerOverrideHeaders returns the headers the overrides of the agent, and then of the
+// query, set for the A2A server. Either may be nil, as when an A2A task outlives its agent or query.
+func ResolveA2AServerOverrideHeaders(ctx context.Context, k8sClient client.Client, agentCRD *arkv1alpha1.Agent, queryCRD *arkv1alpha1.Query, a2aServer string) (map[string]string, error) {
+	if queryCRD == nil {
+		queryCRD = &arkv1alpha1.Query{}
+	} else {
+		// Overrides may forward the parameters of the query
+		ctx = context.WithValue(ctx, QueryContextKey, queryCRD)
+	}
+	if agentCRD == nil {
+		agentCRD = &arkv1alpha1.Agent{}
+	}
+
+	headersMap, err := resolveHeadersFromAgentOverrides(ctx, k8sClient, agentCRD, queryCRD, OverrideTypeA2AServer)
+	if err != nil {
+		return nil, err
+	}
+	return headersMap[a2aServer], nil
+}
+
+// resolveQueryOverrideHeaders returns the headers the overrides of the query in the context set for
+// the resource of overrideType, which has to be in the namespace of the query
+func resolveQueryOverrideHeaders(ctx context.Context, k8sClient client.Client, overrideType OverrideType, name, namespace string) (map[string]string, error) {
+	query, _ := ctx.Value(QueryContextKey).(*arkv1alpha1.Query)
+	if query == nil || query.Namespace != namespace {
+		return nil, nil
+	}
+
+	resourceHeaders, err := ResolveHeadersFromOverrides(ctx, k8sClient, query.Spec.Overrides, query.Namespace, overrideType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s headers from query %s/%s: %w", overrideType, query.Namespace, query.Name, err)
+	}
+	return resourceHeaders[name], nil
+}
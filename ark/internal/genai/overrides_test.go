@@ -12,12 +12,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
 )
 
 func setupTestClient(objects []client.Object) client.Client {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
 	_ = arkv1alpha1.AddToScheme(scheme)
+	_ = arkv1prealpha1.AddToScheme(scheme)
 
 	return fake.NewClientBuilder().
 		WithScheme(scheme).
@@ -325,6 +327,59 @@ func TestListResourcesByLabels(t *testing.T) {
 			namespace: "default",
 			wantCount: 2,
 		},
+		{
+			name:         "labelSelector filters tools",
+			overrideType: OverrideTypeTool,
+			labelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"identity": "end-user"},
+			},
+			objects: []client.Object{
+				&arkv1alpha1.Tool{
+					ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "default", Labels: map[string]string{"identity": "end-user"}},
+				},
+				&arkv1alpha1.Tool{
+					ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "default"},
+				},
+			},
+			namespace: "default",
+			wantCount: 1,
+		},
+		{
+			name:         "nil labelSelector selects all a2aservers",
+			overrideType: OverrideTypeA2AServer,
+			objects: []client.Object{
+				&arkv1prealpha1.A2AServer{
+					ObjectMeta: metav1.ObjectMeta{Name: "a2a1", Namespace: "default"},
+				},
+			},
+			namespace: "default",
+			wantCount: 1,
+		},
+		{
+			name:         "nil labelSelector selects all executionengines",
+			overrideType: OverrideTypeExecutionEngine,
+			objects: []client.Object{
+				&arkv1prealpha1.ExecutionEngine{
+					ObjectMeta: metav1.ObjectMeta{Name: "langchain", Namespace: "default"},
+				},
+				&arkv1prealpha1.ExecutionEngine{
+					ObjectMeta: metav1.ObjectMeta{Name: "crewai", Namespace: "other"},
+				},
+			},
+			namespace: "default",
+			wantCount: 1,
+		},
+		{
+			name:         "nil labelSelector selects all memories",
+			overrideType: OverrideTypeMemory,
+			objects: []client.Object{
+				&arkv1alpha1.Memory{
+					ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"},
+				},
+			},
+			namespace: "default",
+			wantCount: 1,
+		},
 		{
 			name:         "labelSelector with no matches",
 			overrideType: OverrideTypeModel,
@@ -1023,3 +1078,69 @@ func TestResolveHeaderValue(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveHeadersFromAgentOverrides(t *testing.T) {
+	header := func(name, value string) arkv1alpha1.Header {
+		return arkv1alpha1.Header{Name: name, Value: arkv1alpha1.HeaderValue{Value: value}}
+	}
+	fakeClient := setupTestClient([]client.Object{
+		&arkv1prealpha1.ExecutionEngine{ObjectMeta: metav1.ObjectMeta{Name: "langchain", Namespace: "default"}},
+		&arkv1alpha1.Tool{ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "default"}},
+	})
+	agent := &arkv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default"},
+		Spec: arkv1alpha1.AgentSpec{Overrides: []arkv1alpha1.Override{
+			{ResourceType: string(OverrideTypeExecutionEngine), Headers: []arkv1alpha1.Header{header("Authorization", "Bearer agent"), header("X-Agent", "agent")}},
+			{ResourceType: string(OverrideTypeTool), Headers: []arkv1alpha1.Header{header("X-Tool", "tool")}},
+		}},
+	}
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Name: "query", Namespace: "default"},
+		Spec: arkv1alpha1.QuerySpec{Overrides: []arkv1alpha1.Override{
+			{ResourceType: string(OverrideTypeExecutionEngine), Headers: []arkv1alpha1.Header{header("Authorization", "Bearer query")}},
+		}},
+	}
+
+	got, err := resolveHeadersFromAgentOverrides(context.Background(), fakeClient, agent, query, OverrideTypeExecutionEngine)
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"langchain": {"Authorization": "Bearer query", "X-Agent": "agent"},
+	}, got, "query overrides take precedence over the agent's")
+
+	agent.Spec.ExecutionEngine = &arkv1alpha1.ExecutionEngineRef{Name: "langchain"}
+	engineHeaders, err := resolveEngineHeadersForAgent(context.Background(), fakeClient, agent, query)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"Authorization": "Bearer query", "X-Agent": "agent"}, engineHeaders)
+}
+
+func TestResolveQueryOverrideHeaders(t *testing.T) {
+	fakeClient := setupTestClient([]client.Object{
+		&arkv1alpha1.Memory{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+	})
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Name: "query", Namespace: "default"},
+		Spec: arkv1alpha1.QuerySpec{
+			Parameters: []arkv1alpha1.Parameter{{Name: "token", Value: "Bearer end-user"}},
+			Overrides: []arkv1alpha1.Override{{
+				ResourceType: string(OverrideTypeMemory),
+				Headers: []arkv1alpha1.Header{{
+					Name:  "Authorization",
+					Value: arkv1alpha1.HeaderValue{ValueFrom: &arkv1alpha1.HeaderValueSource{QueryParameterRef: &arkv1alpha1.QueryParameterReference{Name: "token"}}},
+				}},
+			}},
+		},
+	}
+	ctx := context.WithValue(context.Background(), QueryContextKey, query)
+
+	got, err := resolveQueryOverrideHeaders(ctx, fakeClient, OverrideTypeMemory, "default", "default")
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"Authorization": "Bearer end-user"}, got)
+
+	got, err = resolveQueryOverrideHeaders(ctx, fakeClient, OverrideTypeMemory, "default", "other")
+	require.NoError(t, err)
+	require.Nil(t, got, "overrides only select resources in the namespace of the query")
+
+	got, err = resolveQueryOverrideHeaders(context.Background(), fakeClient, OverrideTypeMemory, "default", "default")
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
@@ -0,0 +1,693 @@
+package genai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/openai/openai-go"
+	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"mckinsey.com/ark/internal/common"
+)
+
+const (
+	anthropicVersion          = "2023-06-01"
+	anthropicDefaultMaxTokens = 4096
+	// anthropicOutputToolName is the tool the model is asked to call with its final answer when
+	// the agent has an output schema, as the Messages API has no JSON schema response format
+	anthropicOutputToolName = "structured_output"
+	// maxSSELineSize bounds a single server-sent event line, large tool inputs arrive in one line
+	maxSSELineSize = 1024 * 1024
+)
+
+type AnthropicProvider struct {
+	Model        string
+	BaseURL      string
+	APIKey       string
+	Headers      map[string]string
+	Properties   map[string]string
+	outputSchema *runtime.RawExtension
+	schemaName   string
+}
+
+// AnthropicError is an error returned by the Anthropic API, either as an HTTP error response
+// or as an error event in a stream
+type AnthropicError struct {
+	StatusCode int
+	Type       string
+	Message    string
+	Header     http.Header
+}
+
+func (e *AnthropicError) Error() string {
+	return fmt.Sprintf("anthropic API error %d (%s): %s", e.StatusCode, e.Type, e.Message)
+}
+
+// HTTPStatusCode returns the status of the response the error was read from
+func (e *AnthropicError) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+type anthropicRequest struct {
+	Model      string               `json:"model"`
+	MaxTokens  int                  `json:"max_tokens"`
+	System     string               `json:"system,omitempty"`
+	Messages   []anthropicMessage   `json:"messages"`
+	Tools      []anthropicTool      `json:"tools,omitempty"`
+	ToolChoice *anthropicToolChoice `json:"tool_choice,omitempty"`
+	Stream     bool                 `json:"stream,omitempty"`
+}
+
+type anthropicMessage struct {
+	Role    string                  `json:"role"`
+	Content []anthropicContentBlock `json:"content"`
+}
+
+type anthropicContentBlock struct {
+	Type      string                `json:"type"`
+	Text      string                `json:"text,omitempty"`
+	Source    *anthropicImageSource `json:"source,omitempty"`
+	ID        string                `json:"id,omitempty"`
+	Name      string                `json:"name,omitempty"`
+	Input     json.RawMessage       `json:"input,omitempty"`
+	ToolUseID string                `json:"tool_use_id,omitempty"`
+	Content   string                `json:"content,omitempty"`
+}
+
+type anthropicImageSource struct {
+	Type      string `json:"type"`
+	MediaType string `json:"media_type,omitempty"`
+	Data      string `json:"data,omitempty"`
+	URL       string `json:"url,omitempty"`
+}
+
+type anthropicTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema"`
+}
+
+type anthropicToolChoice struct {
+	Type string `json:"type"`
+	Name string `json:"name,omitempty"`
+}
+
+type anthropicResponse struct {
+	ID         string                  `json:"id"`
+	Model      string                  `json:"model"`
+	StopReason string                  `json:"stop_reason"`
+	Content    []anthropicContentBlock `json:"content"`
+	Usage      anthropicUsage          `json:"usage"`
+}
+
+type anthropicUsage struct {
+	InputTokens  int64 `json:"input_tokens"`
+	OutputTokens int64 `json:"output_tokens"`
+}
+
+type anthropicStreamEvent struct {
+	Type         string                 `json:"type"`
+	Index        int                    `json:"index"`
+	Message      *anthropicResponse     `json:"message,omitempty"`
+	ContentBlock *anthropicContentBlock `json:"content_block,omitempty"`
+	Delta        *anthropicStreamDelta  `json:"delta,omitempty"`
+	Usage        *anthropicUsage        `json:"usage,omitempty"`
+	Error        *anthropicErrorDetail  `json:"error,omitempty"`
+}
+
+type anthropicStreamDelta struct {
+	Type        string `json:"type"`
+	Text        string `json:"text,omitempty"`
+	PartialJSON string `json:"partial_json,omitempty"`
+	StopReason  string `json:"stop_reason,omitempty"`
+}
+
+type anthropicErrorDetail struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+func (ap *AnthropicProvider) SetOutputSchema(schema *runtime.RawExtension, schemaName string) {
+	ap.outputSchema = schema
+	ap.schemaName = schemaName
+}
+
+func (ap *AnthropicProvider) HealthCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ap.endpoint("models?limit=1"), nil)
+	if err != nil {
+		return err
+	}
+	ap.setHeaders(ctx, req)
+
+	resp, err := ap.httpClient(ctx).Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return readAnthropicError(resp)
+	}
+	return nil
+}
+
+func (ap *AnthropicProvider) ChatCompletion(ctx context.Context, messages []Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	body, err := ap.buildRequest(messages, false, tools...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := ap.postMessages(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var response anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode Anthropic response: %w", err)
+	}
+
+	return ap.convertResponse(response), nil
+}
+
+// ChatCompletionStream streams a response of the Messages API, translating its server-sent
+// events into OpenAI chat completion chunks
+func (ap *AnthropicProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	body, err := ap.buildRequest(messages, true, tools...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := ap.postMessages(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	stream := &anthropicStream{provider: ap, streamFunc: streamFunc, toolIndex: map[int]int64{}}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineSize)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event); err != nil {
+			return nil, fmt.Errorf("failed to decode Anthropic stream event: %w", err)
+		}
+		if err := stream.handle(&event); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if stream.response == nil {
+		return nil, fmt.Errorf("streaming completed but no response was accumulated")
+	}
+	return ap.convertResponse(*stream.response), nil
+}
+
+// anthropicStream accumulates the events of a streamed response into a complete response
+type anthropicStream struct {
+	provider   *AnthropicProvider
+	streamFunc func(*openai.ChatCompletionChunk) error
+	response   *anthropicResponse
+	inputs     []strings.Builder
+	// toolIndex maps content block indexes of tool calls to their index in the tool call list
+	toolIndex map[int]int64
+}
+
+func (s *anthropicStream) handle(event *anthropicStreamEvent) error {
+	switch event.Type {
+	case "message_start":
+		if event.Message == nil {
+			return fmt.Errorf("anthropic stream started without a message")
+		}
+		s.response = event.Message
+		return s.send(openai.ChatCompletionChunkChoiceDelta{Role: RoleAssistant}, "", nil)
+
+	case "content_block_start":
+		if s.response == nil || event.ContentBlock == nil {
+			return nil
+		}
+		block := *event.ContentBlock
+		block.Input = nil
+		s.response.Content = append(s.response.Content, block)
+		s.inputs = append(s.inputs, strings.Builder{})
+		if block.Type != "tool_use" || s.isOutputTool(block) {
+			return nil
+		}
+		index := int64(len(s.toolIndex))
+		s.toolIndex[event.Index] = index
+		return s.send(openai.ChatCompletionChunkChoiceDelta{
+			ToolCalls: []openai.ChatCompletionChunkChoiceDeltaToolCall{{
+				Index:    index,
+				ID:       block.ID,
+				Type:     "function",
+				Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Name: block.Name},
+			}},
+		}, "", nil)
+
+	case "content_block_delta":
+		if s.response == nil || event.Delta == nil || event.Index >= len(s.response.Content) {
+			return nil
+		}
+		block := &s.response.Content[event.Index]
+		switch event.Delta.Type {
+		case "text_delta":
+			block.Text += event.Delta.Text
+			return s.send(openai.ChatCompletionChunkChoiceDelta{Content: event.Delta.Text}, "", nil)
+		case "input_json_delta":
+			s.inputs[event.Index].WriteString(event.Delta.PartialJSON)
+			// The structured output is the answer, so it is streamed as content
+			if s.isOutputTool(*block) {
+				return s.send(openai.ChatCompletionChunkChoiceDelta{Content: event.Delta.PartialJSON}, "", nil)
+			}
+			return s.send(openai.ChatCompletionChunkChoiceDelta{
+				ToolCalls: []openai.ChatCompletionChunkChoiceDeltaToolCall{{
+					Index:    s.toolIndex[event.Index],
+					Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Arguments: event.Delta.PartialJSON},
+				}},
+			}, "", nil)
+		}
+
+	case "content_block_stop":
+		if s.response == nil || event.Index >= len(s.response.Content) {
+			return nil
+		}
+		if block := &s.response.Content[event.Index]; block.Type == "tool_use" {
+			block.Input = json.RawMessage(s.inputs[event.Index].String())
+		}
+
+	case "message_delta":
+		if s.response == nil {
+			return nil
+		}
+		if event.Delta != nil && event.Delta.StopReason != "" {
+			s.response.StopReason = event.Delta.StopReason
+		}
+		if event.Usage != nil {
+			s.response.Usage.OutputTokens = event.Usage.OutputTokens
+		}
+		usage := s.response.Usage
+		return s.send(openai.ChatCompletionChunkChoiceDelta{}, s.provider.finishReason(*s.response), &openai.CompletionUsage{
+			PromptTokens:     usage.InputTokens,
+			CompletionTokens: usage.OutputTokens,
+			TotalTokens:      usage.InputTokens + usage.OutputTokens,
+		})
+
+	case "error":
+		if event.Error != nil {
+			return &AnthropicError{StatusCode: anthropicErrorStatus(event.Error.Type), Type: event.Error.Type, Message: event.Error.Message}
+		}
+	}
+	return nil
+}
+
+func (s *anthropicStream) isOutputTool(block anthropicContentBlock) bool {
+	return block.Type == "tool_use" && block.Name == anthropicOutputToolName && s.provider.hasOutputSchema()
+}
+
+func (s *anthropicStream) send(delta openai.ChatCompletionChunkChoiceDelta, finishReason string, usage *openai.CompletionUsage) error {
+	chunk := &openai.ChatCompletionChunk{
+		ID:     s.response.ID,
+		Object: "chat.completion.chunk",
+		Model:  s.response.Model,
+		Choices: []openai.ChatCompletionChunkChoice{{
+			Index:        0,
+			Delta:        delta,
+			FinishReason: finishReason,
+		}},
+	}
+	if usage != nil {
+		chunk.Usage = *usage
+	}
+	return s.streamFunc(chunk)
+}
+
+// anthropicErrorStatus maps the error types of stream error events to the HTTP status the API
+// uses for them, so that they are retried like error responses
+func anthropicErrorStatus(errorType string) int {
+	switch errorType {
+	case "overloaded_error":
+		return 529
+	case "rate_limit_error":
+		return http.StatusTooManyRequests
+	case "invalid_request_error":
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (ap *AnthropicProvider) buildRequest(messages []Message, stream bool, tools ...[]openai.ChatCompletionToolParam) ([]byte, error) {
+	var toolsParam []openai.ChatCompletionToolParam
+	if len(tools) > 0 {
+		toolsParam = tools[0]
+	}
+
+	anthropicMessages, system := convertAnthropicMessages(messages)
+	request := anthropicRequest{
+		Model:     ap.Model,
+		MaxTokens: getIntProperty(ap.Properties, "max_tokens", anthropicDefaultMaxTokens),
+		System:    system,
+		Messages:  anthropicMessages,
+		Tools:     convertAnthropicTools(toolsParam),
+		Stream:    stream,
+	}
+
+	// Structured output is requested as a tool call. Without other tools the model must call it,
+	// with other tools it must call one of them, so the final answer always follows the schema.
+	if schema := ap.outputSchemaObject(); schema != nil {
+		request.Tools = append(request.Tools, anthropicTool{
+			Name:        anthropicOutputToolName,
+			Description: fmt.Sprintf("Respond with the final answer (%s). Call this tool once you are ready to answer.", ap.schemaName),
+			InputSchema: schema,
+		})
+		request.ToolChoice = &anthropicToolChoice{Type: "any"}
+		if len(toolsParam) == 0 {
+			request.ToolChoice = &anthropicToolChoice{Type: "tool", Name: anthropicOutputToolName}
+		}
+	}
+
+	return applyAnthropicProperties(request, ap.Properties)
+}
+
+// applyAnthropicProperties sets the model properties as request fields. Values are parsed as
+// JSON where possible, so that numbers and lists are sent with their type.
+func applyAnthropicProperties(request anthropicRequest, properties map[string]string) ([]byte, error) {
+	body, err := json.Marshal(request)
+	if err != nil || len(properties) == 0 {
+		return body, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range properties {
+		if value == "" || key == "model" || key == "messages" || key == "stream" {
+			continue
+		}
+		var parsed any
+		if err := json.Unmarshal([]byte(value), &parsed); err == nil {
+			fields[key] = parsed
+		} else {
+			fields[key] = value
+		}
+	}
+	return json.Marshal(fields)
+}
+
+func (ap *AnthropicProvider) hasOutputSchema() bool {
+	return ap.outputSchema != nil && ap.outputSchema.Raw != nil
+}
+
+func (ap *AnthropicProvider) outputSchemaObject() map[string]any {
+	if !ap.hasOutputSchema() {
+		return nil
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(ap.outputSchema.Raw, &schema); err != nil {
+		return nil
+	}
+	return schema
+}
+
+// convertAnthropicMessages converts messages to the Messages API format. System messages are
+// returned separately, tool results become user messages, and consecutive messages of the same
+// role are merged as the API expects user and assistant turns to alternate.
+func convertAnthropicMessages(messages []Message) ([]anthropicMessage, string) {
+	var result []anthropicMessage
+	var system []string
+
+	appendBlocks := func(role string, blocks []anthropicContentBlock) {
+		if len(blocks) == 0 {
+			return
+		}
+		if last := len(result) - 1; last >= 0 && result[last].Role == role {
+			result[last].Content = append(result[last].Content, blocks...)
+			return
+		}
+		result = append(result, anthropicMessage{Role: role, Content: blocks})
+	}
+
+	for _, msg := range messages {
+		param := openai.ChatCompletionMessageParamUnion(msg)
+		switch {
+		case param.OfSystem != nil:
+			if text := joinTextParts(param.OfSystem.Content.OfString.Value, param.OfSystem.Content.OfArrayOfContentParts); text != "" {
+				system = append(system, text)
+			}
+		case param.OfDeveloper != nil:
+			if text := joinTextParts(param.OfDeveloper.Content.OfString.Value, param.OfDeveloper.Content.OfArrayOfContentParts); text != "" {
+				system = append(system, text)
+			}
+		case param.OfUser != nil:
+			appendBlocks(RoleUser, convertAnthropicUserContent(param.OfUser.Content))
+		case param.OfAssistant != nil:
+			appendBlocks(RoleAssistant, convertAnthropicAssistantContent(param.OfAssistant))
+		case param.OfTool != nil:
+			appendBlocks(RoleUser, []anthropicContentBlock{{
+				Type:      "tool_result",
+				ToolUseID: param.OfTool.ToolCallID,
+				Content:   joinTextParts(param.OfTool.Content.OfString.Value, param.OfTool.Content.OfArrayOfContentParts),
+			}})
+		}
+	}
+
+	return result, strings.Join(system, "\n\n")
+}
+
+func joinTextParts(text string, parts []openai.ChatCompletionContentPartTextParam) string {
+	if text != "" {
+		return text
+	}
+	texts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		texts = append(texts, part.Text)
+	}
+	return strings.Join(texts, "\n")
+}
+
+func convertAnthropicUserContent(content openai.ChatCompletionUserMessageParamContentUnion) []anthropicContentBlock {
+	if content.OfString.Value != "" {
+		return []anthropicContentBlock{{Type: "text", Text: content.OfString.Value}}
+	}
+
+	var blocks []anthropicContentBlock
+	for _, part := range content.OfArrayOfContentParts {
+		switch {
+		case part.OfText != nil && part.OfText.Text != "":
+			blocks = append(blocks, anthropicContentBlock{Type: "text", Text: part.OfText.Text})
+		case part.OfImageURL != nil:
+			blocks = append(blocks, anthropicContentBlock{Type: "image", Source: anthropicImage(part.OfImageURL.ImageURL.URL)})
+		}
+	}
+	return blocks
+}
+
+// anthropicImage converts an image URL, which may be a base64 data URL, to an image source
+func anthropicImage(url string) *anthropicImageSource {
+	if rest, ok := strings.CutPrefix(url, "data:"); ok {
+		if mediaType, data, found := strings.Cut(rest, ";base64,"); found {
+			return &anthropicImageSource{Type: "base64", MediaType: mediaType, Data: data}
+		}
+	}
+	return &anthropicImageSource{Type: "url", URL: url}
+}
+
+func convertAnthropicAssistantContent(assistant *openai.ChatCompletionAssistantMessageParam) []anthropicContentBlock {
+	var blocks []anthropicContentBlock
+	if text := assistant.Content.OfString.Value; text != "" {
+		blocks = append(blocks, anthropicContentBlock{Type: "text", Text: text})
+	}
+	for _, part := range assistant.Content.OfArrayOfContentParts {
+		if part.OfText != nil && part.OfText.Text != "" {
+			blocks = append(blocks, anthropicContentBlock{Type: "text", Text: part.OfText.Text})
+		}
+	}
+
+	for _, toolCall := range assistant.ToolCalls {
+		input := json.RawMessage(toolCall.Function.Arguments)
+		if !json.Valid(input) {
+			input = json.RawMessage("{}")
+		}
+		blocks = append(blocks, anthropicContentBlock{
+			Type:  "tool_use",
+			ID:    toolCall.ID,
+			Name:  toolCall.Function.Name,
+			Input: input,
+		})
+	}
+	return blocks
+}
+
+func convertAnthropicTools(tools []openai.ChatCompletionToolParam) []anthropicTool {
+	var anthropicTools []anthropicTool
+	for _, tool := range tools {
+		inputSchema := map[string]any(tool.Function.Parameters)
+		if inputSchema == nil {
+			inputSchema = map[string]any{"type": "object", "properties": map[string]any{}}
+		}
+		anthropicTools = append(anthropicTools, anthropicTool{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description.Value,
+			InputSchema: inputSchema,
+		})
+	}
+	return anthropicTools
+}
+
+func (ap *AnthropicProvider) convertResponse(response anthropicResponse) *openai.ChatCompletion {
+	var content strings.Builder
+	var structuredOutput string
+	var toolCalls []openai.ChatCompletionMessageToolCall
+
+	for _, block := range response.Content {
+		switch block.Type {
+		case "text":
+			content.WriteString(block.Text)
+		case "tool_use":
+			if block.Name == anthropicOutputToolName && ap.hasOutputSchema() {
+				structuredOutput = string(block.Input)
+				continue
+			}
+			toolCalls = append(toolCalls, openai.ChatCompletionMessageToolCall{
+				ID:   block.ID,
+				Type: "function",
+				Function: openai.ChatCompletionMessageToolCallFunction{
+					Name:      block.Name,
+					Arguments: anthropicToolArguments(block.Input),
+				},
+			})
+		}
+	}
+
+	message := openai.ChatCompletionMessage{
+		Role:      "assistant",
+		Content:   content.String(),
+		ToolCalls: toolCalls,
+	}
+	// The structured output replaces any text the model wrote before calling the output tool
+	if structuredOutput != "" {
+		message.Content = structuredOutput
+	}
+
+	return &openai.ChatCompletion{
+		ID:     response.ID,
+		Object: "chat.completion",
+		Model:  response.Model,
+		Choices: []openai.ChatCompletionChoice{{
+			Index:        0,
+			Message:      message,
+			FinishReason: ap.finishReason(response),
+		}},
+		Usage: openai.CompletionUsage{
+			PromptTokens:     response.Usage.InputTokens,
+			CompletionTokens: response.Usage.OutputTokens,
+			TotalTokens:      response.Usage.InputTokens + response.Usage.OutputTokens,
+		},
+	}
+}
+
+func anthropicToolArguments(input json.RawMessage) string {
+	if len(input) == 0 {
+		return "{}"
+	}
+	return string(input)
+}
+
+func (ap *AnthropicProvider) finishReason(response anthropicResponse) string {
+	switch response.StopReason {
+	case "max_tokens":
+		return "length"
+	case "tool_use":
+		for _, block := range response.Content {
+			if block.Type == "tool_use" && (block.Name != anthropicOutputToolName || !ap.hasOutputSchema()) {
+				return "tool_calls"
+			}
+		}
+	}
+	return "stop"
+}
+
+func (ap *AnthropicProvider) postMessages(ctx context.Context, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ap.endpoint("messages"), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	ap.setHeaders(ctx, req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := ap.httpClient(ctx).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		defer func() { _ = resp.Body.Close() }()
+		return nil, readAnthropicError(resp)
+	}
+	return resp, nil
+}
+
+func readAnthropicError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	apiErr := &AnthropicError{StatusCode: resp.StatusCode, Header: resp.Header, Message: http.StatusText(resp.StatusCode)}
+
+	var errorBody struct {
+		Error anthropicErrorDetail `json:"error"`
+	}
+	if json.Unmarshal(body, &errorBody) == nil && errorBody.Error.Message != "" {
+		apiErr.Type = errorBody.Error.Type
+		apiErr.Message = errorBody.Error.Message
+	}
+	return apiErr
+}
+
+func (ap *AnthropicProvider) endpoint(path string) string {
+	return strings.TrimSuffix(ap.BaseURL, "/") + "/" + path
+}
+
+func (ap *AnthropicProvider) setHeaders(ctx context.Context, req *http.Request) {
+	req.Header.Set("x-api-key", ap.APIKey)
+	req.Header.Set("anthropic-version", anthropicVersion)
+
+	if len(ap.Headers) == 0 {
+		return
+	}
+	log := logf.FromContext(ctx)
+	log.V(1).Info("applying custom headers to client", "model", ap.Model, "header_count", len(ap.Headers))
+	for name, value := range ap.Headers {
+		req.Header.Set(name, value)
+	}
+}
+
+func (ap *AnthropicProvider) httpClient(ctx context.Context) *http.Client {
+	if IsProbeContext(ctx) {
+		return common.NewHTTPClientWithoutTracing()
+	}
+	return common.NewHTTPClientWithLogging(ctx)
+}
+
+func (ap *AnthropicProvider) BuildConfig() map[string]any {
+	config := map[string]any{
+		"baseUrl": ap.BaseURL,
+	}
+	if ap.APIKey != "" {
+		config["apiKey"] = ap.APIKey
+	}
+	return config
+}
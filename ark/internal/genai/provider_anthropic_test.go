@@ -0,0 +1,211 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// newAnthropicStub serves the Messages API, passing each decoded request to handle
+func newAnthropicStub(t *testing.T, handle func(w http.ResponseWriter, request map[string]any)) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/messages", r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("x-api-key"))
+		assert.Equal(t, anthropicVersion, r.Header.Get("anthropic-version"))
+
+		var request map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		handle(w, request)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestAnthropicProvider(baseURL string) *AnthropicProvider {
+	return &AnthropicProvider{
+		Model:      "claude-sonnet-4-5",
+		BaseURL:    baseURL + "/v1",
+		APIKey:     "test-key",
+		Properties: map[string]string{"temperature": "0.2"},
+	}
+}
+
+func weatherTool() openai.ChatCompletionToolParam {
+	return openai.ChatCompletionToolParam{
+		Function: openai.FunctionDefinitionParam{
+			Name:        "get_weather",
+			Description: openai.String("Get the weather"),
+			Parameters:  openai.FunctionParameters{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}},
+		},
+	}
+}
+
+func TestAnthropicProvider_ChatCompletionTranslatesMessagesAndTools(t *testing.T) {
+	server := newAnthropicStub(t, func(w http.ResponseWriter, request map[string]any) {
+		assert.Equal(t, "claude-sonnet-4-5", request["model"])
+		assert.Equal(t, "You are helpful.", request["system"])
+		assert.InDelta(t, 0.2, request["temperature"], 0.0001)
+		assert.EqualValues(t, anthropicDefaultMaxTokens, request["max_tokens"])
+
+		messages := request["messages"].([]any)
+		require.Len(t, messages, 3)
+		assistant := messages[1].(map[string]any)
+		assert.Equal(t, "assistant", assistant["role"])
+		toolUse := assistant["content"].([]any)[0].(map[string]any)
+		assert.Equal(t, "tool_use", toolUse["type"])
+		assert.Equal(t, map[string]any{"city": "Paris"}, toolUse["input"])
+		toolResult := messages[2].(map[string]any)["content"].([]any)[0].(map[string]any)
+		assert.Equal(t, "tool_result", toolResult["type"])
+		assert.Equal(t, "call_1", toolResult["tool_use_id"])
+
+		tools := request["tools"].([]any)
+		require.Len(t, tools, 1)
+		assert.Equal(t, "get_weather", tools[0].(map[string]any)["name"])
+
+		_, _ = fmt.Fprint(w, `{"id":"msg_1","model":"claude-sonnet-4-5","stop_reason":"tool_use",
+			"content":[{"type":"text","text":"Checking again."},{"type":"tool_use","id":"call_2","name":"get_weather","input":{"city":"Rome"}}],
+			"usage":{"input_tokens":20,"output_tokens":10}}`)
+	})
+
+	messages := []Message{
+		NewSystemMessage("You are helpful."),
+		NewUserMessage("Weather in Paris?"),
+		Message(openai.ChatCompletionMessage{
+			Role:      "assistant",
+			ToolCalls: []openai.ChatCompletionMessageToolCall{{ID: "call_1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "get_weather", Arguments: `{"city":"Paris"}`}}},
+		}.ToParam()),
+		Message(openai.ToolMessage("sunny", "call_1")),
+	}
+
+	response, err := newTestAnthropicProvider(server.URL).ChatCompletion(context.Background(), messages, 1, []openai.ChatCompletionToolParam{weatherTool()})
+	require.NoError(t, err)
+
+	choice := response.Choices[0]
+	assert.Equal(t, "tool_calls", choice.FinishReason)
+	assert.Equal(t, "Checking again.", choice.Message.Content)
+	require.Len(t, choice.Message.ToolCalls, 1)
+	assert.Equal(t, "call_2", choice.Message.ToolCalls[0].ID)
+	assert.JSONEq(t, `{"city":"Rome"}`, choice.Message.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, int64(30), response.Usage.TotalTokens)
+}
+
+func TestAnthropicProvider_StructuredOutput(t *testing.T) {
+	server := newAnthropicStub(t, func(w http.ResponseWriter, request map[string]any) {
+		assert.Equal(t, map[string]any{"type": "tool", "name": anthropicOutputToolName}, request["tool_choice"])
+
+		_, _ = fmt.Fprintf(w, `{"id":"msg_1","model":"claude","stop_reason":"tool_use",
+			"content":[{"type":"tool_use","id":"call_1","name":%q,"input":{"answer":"42"}}],
+			"usage":{"input_tokens":5,"output_tokens":5}}`, anthropicOutputToolName)
+	})
+
+	provider := newTestAnthropicProvider(server.URL)
+	provider.SetOutputSchema(&runtime.RawExtension{Raw: []byte(`{"type":"object","properties":{"answer":{"type":"string"}}}`)}, "answer")
+
+	response, err := provider.ChatCompletion(context.Background(), []Message{NewUserMessage("What is the answer?")}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "stop", response.Choices[0].FinishReason)
+	assert.JSONEq(t, `{"answer":"42"}`, response.Choices[0].Message.Content)
+	assert.Empty(t, response.Choices[0].Message.ToolCalls)
+}
+
+func TestAnthropicProvider_ChatCompletionStream(t *testing.T) {
+	events := []string{
+		`{"type":"message_start","message":{"id":"msg_1","model":"claude","content":[],"usage":{"input_tokens":12,"output_tokens":1}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Let me "}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"check."}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"call_1","name":"get_weather","input":{}}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"city\":"}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"Paris\"}"}}`,
+		`{"type":"content_block_stop","index":1}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"output_tokens":18}}`,
+		`{"type":"message_stop"}`,
+	}
+	server := newAnthropicStub(t, func(w http.ResponseWriter, request map[string]any) {
+		assert.Equal(t, true, request["stream"])
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			var typed struct{ Type string }
+			_ = json.Unmarshal([]byte(event), &typed)
+			_, _ = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", typed.Type, event)
+		}
+	})
+
+	var content, arguments strings.Builder
+	var finishReason string
+	response, err := newTestAnthropicProvider(server.URL).ChatCompletionStream(context.Background(), []Message{NewUserMessage("Weather?")}, 1,
+		func(chunk *openai.ChatCompletionChunk) error {
+			delta := chunk.Choices[0].Delta
+			content.WriteString(delta.Content)
+			for _, toolCall := range delta.ToolCalls {
+				arguments.WriteString(toolCall.Function.Arguments)
+			}
+			if chunk.Choices[0].FinishReason != "" {
+				finishReason = chunk.Choices[0].FinishReason
+			}
+			return nil
+		}, []openai.ChatCompletionToolParam{weatherTool()})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Let me check.", content.String())
+	assert.JSONEq(t, `{"city":"Paris"}`, arguments.String())
+	assert.Equal(t, "tool_calls", finishReason)
+
+	message := response.Choices[0].Message
+	assert.Equal(t, "Let me check.", message.Content)
+	require.Len(t, message.ToolCalls, 1)
+	assert.JSONEq(t, `{"city":"Paris"}`, message.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, int64(30), response.Usage.TotalTokens)
+}
+
+func TestAnthropicProvider_ErrorsAreRetryable(t *testing.T) {
+	server := newAnthropicStub(t, func(w http.ResponseWriter, _ map[string]any) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(529)
+		_, _ = fmt.Fprint(w, `{"type":"error","error":{"type":"overloaded_error","message":"Overloaded"}}`)
+	})
+
+	_, err := newTestAnthropicProvider(server.URL).ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, 1)
+	var apiErr *AnthropicError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "overloaded_error", apiErr.Type)
+
+	status, retryAfter := retryStatus(err)
+	assert.Equal(t, 529, status)
+	assert.Equal(t, 3*time.Second, retryAfter)
+}
+
+func TestAnthropicProvider_HealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/models", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		if r.Header.Get("x-api-key") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(w, `{"type":"error","error":{"type":"authentication_error","message":"invalid x-api-key"}}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"data":[{"id":"claude-sonnet-4-5","type":"model"}]}`)
+	}))
+	defer server.Close()
+
+	provider := newTestAnthropicProvider(server.URL)
+	require.NoError(t, provider.HealthCheck(context.Background()))
+
+	provider.APIKey = "wrong-key"
+	err := provider.HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "invalid x-api-key (401)", extractStableError(err, time.Second))
+}
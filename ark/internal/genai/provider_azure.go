@@ -12,14 +12,16 @@ import (
 )
 
 type AzureProvider struct {
-	Model        string
-	BaseURL      string
-	APIVersion   string
-	APIKey       string
-	Headers      map[string]string
-	Properties   map[string]string
-	outputSchema *runtime.RawExtension
-	schemaName   string
+	Model      string
+	BaseURL    string
+	APIVersion string
+	APIKey     string
+	Headers    map[string]string
+	Properties map[string]string
+	// DisableRetries turns off the SDK's own retries when the model has a retry policy
+	DisableRetries bool
+	outputSchema   *runtime.RawExtension
+	schemaName     string
 }
 
 func (ap *AzureProvider) SetOutputSchema(schema *runtime.RawExtension, schemaName string) {
@@ -154,6 +156,10 @@ func (ap *AzureProvider) createClient(ctx context.Context) openai.Client {
 		option.WithQueryAdd("api-version", ap.APIVersion),
 	}
 
+	if ap.DisableRetries {
+		options = append(options, option.WithMaxRetries(0))
+	}
+
 	options = applyHeadersToOptions(ctx, ap.Headers, options, ap.Model)
 
 	return openai.NewClient(options...)
@@ -2,18 +2,36 @@ package genai
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/document"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go/middleware"
 	"github.com/openai/openai-go"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+const bedrockDefaultMaxTokens = 4096
+
+// bedrockInferenceProperties are the model properties sent in the inference configuration of a
+// Converse request, all other properties are sent as additional model request fields
+var bedrockInferenceProperties = map[string]bool{
+	"max_tokens":  true,
+	"temperature": true,
+	"top_p":       true,
+	"stop":        true,
+}
+
+// BedrockModel calls models on AWS Bedrock through the Converse API, which works the same way
+// for all model families that support it, such as Claude, Llama, Mistral and Nova
 type BedrockModel struct {
 	Model           string
 	Region          string
@@ -23,48 +41,20 @@ type BedrockModel struct {
 	SessionToken    string
 	ModelArn        string
 	Properties      map[string]string
-	client          *bedrockruntime.Client
-	outputSchema    *runtime.RawExtension
-	schemaName      string
-}
-
-type bedrockMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
-
-type bedrockRequest struct {
-	Messages         []bedrockMessage `json:"messages"`
-	MaxTokens        int              `json:"max_tokens"`
-	Temperature      float64          `json:"temperature"`
-	SystemPrompt     string           `json:"system,omitempty"`
-	AnthropicVersion string           `json:"anthropic_version,omitempty"`
-	Tools            []bedrockTool    `json:"tools,omitempty"`
-}
-
-type bedrockTool struct {
-	Name        string                 `json:"name"`
-	Description string                 `json:"description"`
-	InputSchema map[string]interface{} `json:"input_schema"`
-}
-
-type bedrockResponse struct {
-	Content    []bedrockContent `json:"content"`
-	ID         string           `json:"id"`
-	Model      string           `json:"model"`
-	StopReason string           `json:"stop_reason"`
-	Usage      struct {
-		InputTokens  int `json:"input_tokens"`
-		OutputTokens int `json:"output_tokens"`
-	} `json:"usage"`
+	// DisableRetries turns off the SDK's own retries when the model has a retry policy
+	DisableRetries bool
+	client         *bedrockruntime.Client
+	outputSchema   *runtime.RawExtension
+	schemaName     string
 }
 
-type bedrockContent struct {
-	Text  string                 `json:"text,omitempty"`
-	Type  string                 `json:"type"`
-	ID    string                 `json:"id,omitempty"`
-	Name  string                 `json:"name,omitempty"`
-	Input map[string]interface{} `json:"input,omitempty"`
+// bedrockConverseRequest holds the fields shared by Converse and ConverseStream requests
+type bedrockConverseRequest struct {
+	messages         []types.Message
+	system           []types.SystemContentBlock
+	toolConfig       *types.ToolConfiguration
+	inferenceConfig  *types.InferenceConfiguration
+	additionalFields document.Interface
 }
 
 func NewBedrockModel(model, region, baseURL, accessKeyID, secretAccessKey, sessionToken, modelArn string, properties map[string]string) *BedrockModel {
@@ -85,15 +75,15 @@ func (bm *BedrockModel) initClient(ctx context.Context) error {
 		return nil
 	}
 
-	var cfg aws.Config
-	var err error
-
+	options := []func(*config.LoadOptions) error{config.WithRegion(bm.Region)}
 	if bm.AccessKeyID != "" && bm.SecretAccessKey != "" {
 		creds := credentials.NewStaticCredentialsProvider(bm.AccessKeyID, bm.SecretAccessKey, bm.SessionToken)
-		cfg, err = config.LoadDefaultConfig(ctx, config.WithRegion(bm.Region), config.WithCredentialsProvider(creds))
-	} else {
-		cfg, err = config.LoadDefaultConfig(ctx, config.WithRegion(bm.Region))
+		options = append(options, config.WithCredentialsProvider(creds))
+	}
+	if bm.DisableRetries {
+		options = append(options, config.WithRetryMaxAttempts(1))
 	}
+	cfg, err := config.LoadDefaultConfig(ctx, options...)
 
 	if err != nil {
 		return fmt.Errorf("failed to load AWS config: %w", err)
@@ -114,256 +104,449 @@ func (bm *BedrockModel) SetOutputSchema(schema *runtime.RawExtension, schemaName
 }
 
 func (bm *BedrockModel) ChatCompletion(ctx context.Context, messages []Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
-	var toolsParam []openai.ChatCompletionToolParam
-	if len(tools) > 0 {
-		toolsParam = tools[0]
-	}
 	if err := bm.initClient(ctx); err != nil {
 		return nil, err
 	}
 
-	bedrockMessages, systemPrompt := bm.convertMessages(messages)
-	bedrockTools := bm.convertTools(toolsParam)
-
-	request := bm.buildRequest(bedrockMessages, systemPrompt, bedrockTools)
-
-	if strings.Contains(strings.ToLower(bm.Model), "claude") {
-		request.AnthropicVersion = "bedrock-2023-05-31"
-	}
-
-	requestBody, err := json.Marshal(request)
+	request := bm.buildRequest(messages, tools...)
+	output, err := bm.client.Converse(ctx, &bedrockruntime.ConverseInput{
+		ModelId:                      aws.String(bm.modelID()),
+		Messages:                     request.messages,
+		System:                       request.system,
+		ToolConfig:                   request.toolConfig,
+		InferenceConfig:              request.inferenceConfig,
+		AdditionalModelRequestFields: request.additionalFields,
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to invoke Bedrock model: %w", err)
 	}
 
-	modelID := bm.Model
-	if bm.ModelArn != "" {
-		modelID = bm.ModelArn
+	var content strings.Builder
+	var toolCalls []openai.ChatCompletionMessageToolCall
+	if message, ok := output.Output.(*types.ConverseOutputMemberMessage); ok {
+		for _, block := range message.Value.Content {
+			switch block := block.(type) {
+			case *types.ContentBlockMemberText:
+				content.WriteString(block.Value)
+			case *types.ContentBlockMemberToolUse:
+				toolCalls = append(toolCalls, openai.ChatCompletionMessageToolCall{
+					ID:   aws.ToString(block.Value.ToolUseId),
+					Type: "function",
+					Function: openai.ChatCompletionMessageToolCallFunction{
+						Name:      aws.ToString(block.Value.Name),
+						Arguments: bedrockToolArguments(block.Value.Input),
+					},
+				})
+			}
+		}
 	}
 
-	input := &bedrockruntime.InvokeModelInput{
-		ModelId:     aws.String(modelID),
-		Body:        requestBody,
-		ContentType: aws.String("application/json"),
-		Accept:      aws.String("application/json"),
+	return bm.completion(bedrockRequestID(output.ResultMetadata), content.String(), toolCalls, output.StopReason, output.Usage), nil
+}
+
+func (bm *BedrockModel) ChatCompletionWithSchema(ctx context.Context, messages []Message, outputSchema *runtime.RawExtension, schemaName string, tools []openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	return bm.ChatCompletion(ctx, messages, 1, tools)
+}
+
+// ChatCompletionStream streams a response through the ConverseStream API, sending text and tool
+// call deltas as chunks as they arrive
+func (bm *BedrockModel) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	if err := bm.initClient(ctx); err != nil {
+		return nil, err
 	}
 
-	result, err := bm.client.InvokeModel(ctx, input)
+	request := bm.buildRequest(messages, tools...)
+	output, err := bm.client.ConverseStream(ctx, &bedrockruntime.ConverseStreamInput{
+		ModelId:                      aws.String(bm.modelID()),
+		Messages:                     request.messages,
+		System:                       request.system,
+		ToolConfig:                   request.toolConfig,
+		InferenceConfig:              request.inferenceConfig,
+		AdditionalModelRequestFields: request.additionalFields,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to invoke Bedrock model: %w", err)
 	}
 
-	var response bedrockResponse
-	if err := json.Unmarshal(result.Body, &response); err != nil {
-		return nil, err
+	eventStream := output.GetStream()
+	defer func() { _ = eventStream.Close() }()
+
+	stream := &bedrockStream{id: bedrockRequestID(output.ResultMetadata), model: bm.Model, streamFunc: streamFunc, toolIndex: map[int32]int{}}
+	for event := range eventStream.Events() {
+		if err := stream.handle(event); err != nil {
+			return nil, err
+		}
+	}
+	if err := eventStream.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read Bedrock response stream: %w", err)
+	}
+	if !stream.finished && stream.stopReason != "" {
+		if err := stream.finish(); err != nil {
+			return nil, err
+		}
 	}
 
-	return bm.convertResponse(response), nil
+	toolCalls := make([]openai.ChatCompletionMessageToolCall, len(stream.toolCalls))
+	for i, toolCall := range stream.toolCalls {
+		toolCalls[i] = toolCall
+		if toolCall.Function.Arguments == "" {
+			toolCalls[i].Function.Arguments = "{}"
+		}
+	}
+	return bm.completion(stream.id, stream.content.String(), toolCalls, stream.stopReason, stream.usage), nil
 }
 
-func (bm *BedrockModel) ChatCompletionWithSchema(ctx context.Context, messages []Message, outputSchema *runtime.RawExtension, schemaName string, tools []openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
-	return bm.ChatCompletion(ctx, messages, 1, tools)
+// bedrockStream accumulates the events of a ConverseStream response
+type bedrockStream struct {
+	id         string
+	model      string
+	streamFunc func(*openai.ChatCompletionChunk) error
+	content    strings.Builder
+	toolCalls  []openai.ChatCompletionMessageToolCall
+	// toolIndex maps content block indexes of tool calls to their index in the tool call list
+	toolIndex  map[int32]int
+	stopReason types.StopReason
+	usage      *types.TokenUsage
+	finished   bool
 }
 
-func (bm *BedrockModel) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
-	// Per OpenAI spec, when streaming is requested for a model that doesn't support it,
-	// return the complete response as a single chunk
-	completion, err := bm.ChatCompletion(ctx, messages, n, tools...)
-	if err != nil {
-		return nil, err
-	}
+func (s *bedrockStream) handle(event types.ConverseStreamOutput) error {
+	switch event := event.(type) {
+	case *types.ConverseStreamOutputMemberMessageStart:
+		return s.send(openai.ChatCompletionChunkChoiceDelta{Role: RoleAssistant}, "", nil)
 
-	// Convert the completion to a single streaming chunk
-	// We send the full content in one chunk as per the OpenAI fallback spec
-	for _, choice := range completion.Choices {
-		chunk := &openai.ChatCompletionChunk{
-			ID:      completion.ID,
-			Object:  "chat.completion.chunk",
-			Created: completion.Created,
-			Model:   completion.Model,
-			Choices: []openai.ChatCompletionChunkChoice{
-				{
-					Index: choice.Index,
-					Delta: openai.ChatCompletionChunkChoiceDelta{
-						Content: choice.Message.Content,
-						Role:    "assistant",
-					},
-					FinishReason: choice.FinishReason,
-				},
-			},
+	case *types.ConverseStreamOutputMemberContentBlockStart:
+		toolUse, ok := event.Value.Start.(*types.ContentBlockStartMemberToolUse)
+		if !ok {
+			return nil
 		}
-
-		// Send the chunk via the stream callback
-		if err := streamFunc(chunk); err != nil {
-			return nil, err
+		index := len(s.toolCalls)
+		s.toolIndex[aws.ToInt32(event.Value.ContentBlockIndex)] = index
+		s.toolCalls = append(s.toolCalls, openai.ChatCompletionMessageToolCall{
+			ID:       aws.ToString(toolUse.Value.ToolUseId),
+			Type:     "function",
+			Function: openai.ChatCompletionMessageToolCallFunction{Name: aws.ToString(toolUse.Value.Name)},
+		})
+		return s.send(openai.ChatCompletionChunkChoiceDelta{
+			ToolCalls: []openai.ChatCompletionChunkChoiceDeltaToolCall{{
+				Index:    int64(index),
+				ID:       aws.ToString(toolUse.Value.ToolUseId),
+				Type:     "function",
+				Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Name: aws.ToString(toolUse.Value.Name)},
+			}},
+		}, "", nil)
+
+	case *types.ConverseStreamOutputMemberContentBlockDelta:
+		switch delta := event.Value.Delta.(type) {
+		case *types.ContentBlockDeltaMemberText:
+			s.content.WriteString(delta.Value)
+			return s.send(openai.ChatCompletionChunkChoiceDelta{Content: delta.Value}, "", nil)
+		case *types.ContentBlockDeltaMemberToolUse:
+			index, ok := s.toolIndex[aws.ToInt32(event.Value.ContentBlockIndex)]
+			if !ok {
+				return nil
+			}
+			input := aws.ToString(delta.Value.Input)
+			s.toolCalls[index].Function.Arguments += input
+			return s.send(openai.ChatCompletionChunkChoiceDelta{
+				ToolCalls: []openai.ChatCompletionChunkChoiceDeltaToolCall{{
+					Index:    int64(index),
+					Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Arguments: input},
+				}},
+			}, "", nil)
 		}
+
+	case *types.ConverseStreamOutputMemberMessageStop:
+		s.stopReason = event.Value.StopReason
+
+	case *types.ConverseStreamOutputMemberMetadata:
+		// Usage arrives after the stop reason, so the final chunk is sent with both
+		s.usage = event.Value.Usage
+		return s.finish()
 	}
+	return nil
+}
 
-	// Return the original completion
-	return completion, nil
+func (s *bedrockStream) finish() error {
+	s.finished = true
+	usage := bedrockUsage(s.usage)
+	return s.send(openai.ChatCompletionChunkChoiceDelta{}, bedrockFinishReason(s.stopReason), &usage)
 }
 
-func (bm *BedrockModel) buildRequest(messages []bedrockMessage, systemPrompt string, tools []bedrockTool) bedrockRequest {
-	temperature := getFloatProperty(bm.Properties, "temperature", 1.0)
-	maxTokens := getIntProperty(bm.Properties, "max_tokens", 4096)
+func (s *bedrockStream) send(delta openai.ChatCompletionChunkChoiceDelta, finishReason string, usage *openai.CompletionUsage) error {
+	chunk := &openai.ChatCompletionChunk{
+		ID:     s.id,
+		Object: "chat.completion.chunk",
+		Model:  s.model,
+		Choices: []openai.ChatCompletionChunkChoice{{
+			Index:        0,
+			Delta:        delta,
+			FinishReason: finishReason,
+		}},
+	}
+	if usage != nil {
+		chunk.Usage = *usage
+	}
+	return s.streamFunc(chunk)
+}
 
-	return bedrockRequest{
-		Messages:     messages,
-		MaxTokens:    maxTokens,
-		Temperature:  temperature,
-		SystemPrompt: systemPrompt,
-		Tools:        tools,
+func (bm *BedrockModel) modelID() string {
+	if bm.ModelArn != "" {
+		return bm.ModelArn
 	}
+	return bm.Model
 }
 
-func (bm *BedrockModel) convertMessages(messages []Message) ([]bedrockMessage, string) {
-	var bedrockMessages []bedrockMessage
-	var systemPrompt string
+func (bm *BedrockModel) buildRequest(messages []Message, tools ...[]openai.ChatCompletionToolParam) bedrockConverseRequest {
+	bedrockMessages, system := convertBedrockMessages(messages)
+	request := bedrockConverseRequest{
+		messages: bedrockMessages,
+		system:   system,
+		inferenceConfig: &types.InferenceConfiguration{
+			MaxTokens: aws.Int32(int32(getIntProperty(bm.Properties, "max_tokens", bedrockDefaultMaxTokens))),
+		},
+	}
+
+	if len(tools) > 0 && len(tools[0]) > 0 {
+		request.toolConfig = &types.ToolConfiguration{Tools: convertBedrockTools(tools[0])}
+	}
 
-	for _, msg := range messages {
-		content, role := extractMessageContent(msg)
-		if content == "" {
-			continue
+	if _, ok := bm.Properties["temperature"]; ok {
+		request.inferenceConfig.Temperature = aws.Float32(float32(getFloatProperty(bm.Properties, "temperature", 1.0)))
+	}
+	if _, ok := bm.Properties["top_p"]; ok {
+		request.inferenceConfig.TopP = aws.Float32(float32(getFloatProperty(bm.Properties, "top_p", 1.0)))
+	}
+	if stop := bm.Properties["stop"]; stop != "" {
+		var sequences []string
+		if err := json.Unmarshal([]byte(stop), &sequences); err != nil {
+			sequences = []string{stop}
 		}
+		request.inferenceConfig.StopSequences = sequences
+	}
 
-		switch role {
-		case RoleSystem:
-			systemPrompt = content
-		case RoleUser, RoleAssistant, RoleTool:
-			msgRole := role
-			if role == RoleTool {
-				msgRole = RoleUser
-			}
-			bedrockMessages = append(bedrockMessages, bedrockMessage{
-				Role:    msgRole,
-				Content: content,
-			})
+	// Model specific parameters such as top_k are passed through as additional request fields,
+	// with values parsed as JSON where possible so that they keep their type
+	additionalFields := map[string]any{}
+	for key, value := range bm.Properties {
+		if bedrockInferenceProperties[key] || value == "" {
+			continue
+		}
+		var parsed any
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			parsed = value
 		}
+		additionalFields[key] = parsed
+	}
+	if len(additionalFields) > 0 {
+		request.additionalFields = document.NewLazyDocument(additionalFields)
 	}
 
-	return bedrockMessages, systemPrompt
+	return request
 }
 
-func (bm *BedrockModel) convertResponse(response bedrockResponse) *openai.ChatCompletion {
-	var content string
-	var toolCalls []openai.ChatCompletionMessageToolCall
+// convertBedrockMessages converts messages to Converse messages. System messages are returned
+// separately, tool results become tool result blocks of user messages, and consecutive messages
+// of the same role are merged as the Converse API expects user and assistant turns to alternate.
+func convertBedrockMessages(messages []Message) ([]types.Message, []types.SystemContentBlock) {
+	var result []types.Message
+	var system []types.SystemContentBlock
 
-	for _, c := range response.Content {
-		switch c.Type {
-		case "text":
-			content = c.Text
-		case "tool_use":
-			toolCall := openai.ChatCompletionMessageToolCall{
-				ID:   c.ID,
-				Type: "function",
-				Function: openai.ChatCompletionMessageToolCallFunction{
-					Name:      c.Name,
-					Arguments: mustMarshalJSON(c.Input),
-				},
-			}
-			toolCalls = append(toolCalls, toolCall)
+	appendBlocks := func(role types.ConversationRole, blocks []types.ContentBlock) {
+		if len(blocks) == 0 {
+			return
+		}
+		if last := len(result) - 1; last >= 0 && result[last].Role == role {
+			result[last].Content = append(result[last].Content, blocks...)
+			return
 		}
+		result = append(result, types.Message{Role: role, Content: blocks})
 	}
 
-	finishReason := "stop"
-	switch response.StopReason {
-	case "max_tokens":
-		finishReason = "length"
-	case "tool_use":
-		finishReason = "tool_calls"
+	for _, msg := range messages {
+		param := openai.ChatCompletionMessageParamUnion(msg)
+		switch {
+		case param.OfSystem != nil:
+			if text := joinTextParts(param.OfSystem.Content.OfString.Value, param.OfSystem.Content.OfArrayOfContentParts); text != "" {
+				system = append(system, &types.SystemContentBlockMemberText{Value: text})
+			}
+		case param.OfDeveloper != nil:
+			if text := joinTextParts(param.OfDeveloper.Content.OfString.Value, param.OfDeveloper.Content.OfArrayOfContentParts); text != "" {
+				system = append(system, &types.SystemContentBlockMemberText{Value: text})
+			}
+		case param.OfUser != nil:
+			appendBlocks(types.ConversationRoleUser, convertBedrockUserContent(param.OfUser.Content))
+		case param.OfAssistant != nil:
+			appendBlocks(types.ConversationRoleAssistant, convertBedrockAssistantContent(param.OfAssistant))
+		case param.OfTool != nil:
+			appendBlocks(types.ConversationRoleUser, []types.ContentBlock{&types.ContentBlockMemberToolResult{
+				Value: types.ToolResultBlock{
+					ToolUseId: aws.String(param.OfTool.ToolCallID),
+					Content: []types.ToolResultContentBlock{&types.ToolResultContentBlockMemberText{
+						Value: joinTextParts(param.OfTool.Content.OfString.Value, param.OfTool.Content.OfArrayOfContentParts),
+					}},
+				},
+			}})
+		}
 	}
 
-	message := openai.ChatCompletionMessage{
-		Role:    "assistant",
-		Content: content,
-	}
+	return result, system
+}
 
-	if len(toolCalls) > 0 {
-		message.ToolCalls = toolCalls
+func convertBedrockUserContent(content openai.ChatCompletionUserMessageParamContentUnion) []types.ContentBlock {
+	if content.OfString.Value != "" {
+		return []types.ContentBlock{&types.ContentBlockMemberText{Value: content.OfString.Value}}
 	}
 
-	return &openai.ChatCompletion{
-		ID:     response.ID,
-		Object: "chat.completion",
-		Model:  response.Model,
-		Choices: []openai.ChatCompletionChoice{
-			{
-				Index:        0,
-				Message:      message,
-				FinishReason: finishReason,
-			},
-		},
-		Usage: openai.CompletionUsage{
-			PromptTokens:     int64(response.Usage.InputTokens),
-			CompletionTokens: int64(response.Usage.OutputTokens),
-			TotalTokens:      int64(response.Usage.InputTokens + response.Usage.OutputTokens),
-		},
+	var blocks []types.ContentBlock
+	for _, part := range content.OfArrayOfContentParts {
+		switch {
+		case part.OfText != nil && part.OfText.Text != "":
+			blocks = append(blocks, &types.ContentBlockMemberText{Value: part.OfText.Text})
+		case part.OfImageURL != nil:
+			if image := bedrockImage(part.OfImageURL.ImageURL.URL); image != nil {
+				blocks = append(blocks, image)
+			}
+		}
 	}
+	return blocks
 }
 
-func mustMarshalJSON(v interface{}) string {
-	if v == nil {
-		return "{}"
+// bedrockImage converts a base64 data URL to an image block. Bedrock cannot fetch images from
+// URLs, so other images are left out.
+func bedrockImage(url string) types.ContentBlock {
+	rest, ok := strings.CutPrefix(url, "data:image/")
+	if !ok {
+		return nil
+	}
+	format, data, found := strings.Cut(rest, ";base64,")
+	if !found {
+		return nil
 	}
-	data, err := json.Marshal(v)
+	decoded, err := base64.StdEncoding.DecodeString(data)
 	if err != nil {
-		return "{}"
+		return nil
 	}
-	return string(data)
+	if format == "jpg" {
+		format = "jpeg"
+	}
+	return &types.ContentBlockMemberImage{Value: types.ImageBlock{
+		Format: types.ImageFormat(format),
+		Source: &types.ImageSourceMemberBytes{Value: decoded},
+	}}
 }
 
-func (bm *BedrockModel) convertTools(tools []openai.ChatCompletionToolParam) []bedrockTool {
-	var bedrockTools []bedrockTool
-
-	for _, tool := range tools {
-		if tool.Type == "function" {
-			bedrockTool := bedrockTool{
-				Name: tool.Function.Name,
-			}
+func convertBedrockAssistantContent(assistant *openai.ChatCompletionAssistantMessageParam) []types.ContentBlock {
+	var blocks []types.ContentBlock
+	if text := assistant.Content.OfString.Value; text != "" {
+		blocks = append(blocks, &types.ContentBlockMemberText{Value: text})
+	}
+	for _, part := range assistant.Content.OfArrayOfContentParts {
+		if part.OfText != nil && part.OfText.Text != "" {
+			blocks = append(blocks, &types.ContentBlockMemberText{Value: part.OfText.Text})
+		}
+	}
 
-			if tool.Function.Description.Value != "" {
-				bedrockTool.Description = tool.Function.Description.Value
-			}
+	for _, toolCall := range assistant.ToolCalls {
+		var input any
+		if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &input); err != nil || input == nil {
+			input = map[string]any{}
+		}
+		blocks = append(blocks, &types.ContentBlockMemberToolUse{Value: types.ToolUseBlock{
+			ToolUseId: aws.String(toolCall.ID),
+			Name:      aws.String(toolCall.Function.Name),
+			Input:     document.NewLazyDocument(input),
+		}})
+	}
+	return blocks
+}
 
-			if tool.Function.Parameters != nil {
-				bedrockTool.InputSchema = map[string]interface{}(tool.Function.Parameters)
-			}
+func convertBedrockTools(tools []openai.ChatCompletionToolParam) []types.Tool {
+	bedrockTools := make([]types.Tool, 0, len(tools))
+	for _, tool := range tools {
+		inputSchema := map[string]any(tool.Function.Parameters)
+		if inputSchema == nil {
+			inputSchema = map[string]any{"type": "object", "properties": map[string]any{}}
+		}
 
-			bedrockTools = append(bedrockTools, bedrockTool)
+		spec := types.ToolSpecification{
+			Name:        aws.String(tool.Function.Name),
+			InputSchema: &types.ToolInputSchemaMemberJson{Value: document.NewLazyDocument(inputSchema)},
 		}
+		if description := tool.Function.Description.Value; description != "" {
+			spec.Description = aws.String(description)
+		}
+		bedrockTools = append(bedrockTools, &types.ToolMemberToolSpec{Value: spec})
 	}
-
 	return bedrockTools
 }
 
-func extractMessageContent(msg Message) (string, string) {
-	openaiMsg := openai.ChatCompletionMessageParamUnion(msg)
+func bedrockToolArguments(input document.Interface) string {
+	if input == nil {
+		return "{}"
+	}
+	data, err := input.MarshalSmithyDocument()
+	if err != nil || len(data) == 0 || string(data) == "null" {
+		return "{}"
+	}
+	return string(data)
+}
 
-	if systemMsg := openaiMsg.OfSystem; systemMsg != nil {
-		if content := systemMsg.Content.OfString; content.Value != "" {
-			return content.Value, "system"
-		}
+func (bm *BedrockModel) completion(id, content string, toolCalls []openai.ChatCompletionMessageToolCall, stopReason types.StopReason, usage *types.TokenUsage) *openai.ChatCompletion {
+	message := openai.ChatCompletionMessage{
+		Role:    "assistant",
+		Content: content,
+	}
+	if len(toolCalls) > 0 {
+		message.ToolCalls = toolCalls
 	}
 
-	if userMsg := openaiMsg.OfUser; userMsg != nil {
-		if content := userMsg.Content.OfString; content.Value != "" {
-			return content.Value, RoleUser
-		}
+	return &openai.ChatCompletion{
+		ID:     id,
+		Object: "chat.completion",
+		Model:  bm.Model,
+		Choices: []openai.ChatCompletionChoice{
+			{
+				Index:        0,
+				Message:      message,
+				FinishReason: bedrockFinishReason(stopReason),
+			},
+		},
+		Usage: bedrockUsage(usage),
 	}
+}
 
-	if assistantMsg := openaiMsg.OfAssistant; assistantMsg != nil {
-		if content := assistantMsg.Content.OfString; content.Value != "" {
-			return content.Value, "assistant"
-		}
+func bedrockFinishReason(stopReason types.StopReason) string {
+	switch stopReason {
+	case types.StopReasonMaxTokens:
+		return "length"
+	case types.StopReasonToolUse:
+		return "tool_calls"
+	case types.StopReasonGuardrailIntervened, types.StopReasonContentFiltered:
+		return "content_filter"
+	default:
+		return "stop"
 	}
+}
 
-	if toolMsg := openaiMsg.OfTool; toolMsg != nil {
-		if content := toolMsg.Content.OfString; content.Value != "" {
-			return content.Value, "tool"
-		}
+func bedrockUsage(usage *types.TokenUsage) openai.CompletionUsage {
+	if usage == nil {
+		return openai.CompletionUsage{}
 	}
+	inputTokens := int64(aws.ToInt32(usage.InputTokens))
+	outputTokens := int64(aws.ToInt32(usage.OutputTokens))
+	return openai.CompletionUsage{
+		PromptTokens:     inputTokens,
+		CompletionTokens: outputTokens,
+		TotalTokens:      inputTokens + outputTokens,
+	}
+}
 
-	return "", ""
+// bedrockRequestID returns the AWS request ID of a response, which identifies the completion as the
+// Converse API returns no ID of its own
+func bedrockRequestID(metadata middleware.Metadata) string {
+	id, _ := awsmiddleware.GetRequestIDMetadata(metadata)
+	return id
 }
 
 func (bm *BedrockModel) BuildConfig() map[string]any {
@@ -0,0 +1,160 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBedrockStub serves the Bedrock runtime API, passing each decoded request to handle
+func newBedrockStub(t *testing.T, operation string, handle func(w http.ResponseWriter, request map[string]any)) *BedrockModel {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/model/meta.llama3-70b-instruct-v1:0/"+operation, r.URL.Path)
+
+		var request map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		handle(w, request)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewBedrockModel("meta.llama3-70b-instruct-v1:0", "us-east-1", server.URL, "test-access-key", "test-secret-key", "", "",
+		map[string]string{"temperature": "0.2", "top_k": "50"})
+}
+
+func TestBedrockModel_ChatCompletionSendsToolTurnsAsContentBlocks(t *testing.T) {
+	bm := newBedrockStub(t, "converse", func(w http.ResponseWriter, request map[string]any) {
+		assert.Equal(t, []any{map[string]any{"text": "You are helpful."}}, request["system"])
+		assert.Equal(t, map[string]any{"maxTokens": float64(bedrockDefaultMaxTokens), "temperature": 0.2}, request["inferenceConfig"])
+		assert.Equal(t, map[string]any{"top_k": float64(50)}, request["additionalModelRequestFields"])
+
+		messages := request["messages"].([]any)
+		require.Len(t, messages, 3)
+		assistant := messages[1].(map[string]any)
+		assert.Equal(t, "assistant", assistant["role"])
+		assert.Equal(t, map[string]any{"toolUse": map[string]any{"toolUseId": "call_1", "name": "get_weather", "input": map[string]any{"city": "Paris"}}},
+			assistant["content"].([]any)[0])
+		assert.Equal(t, map[string]any{"toolResult": map[string]any{"toolUseId": "call_1", "content": []any{map[string]any{"text": "sunny"}}}},
+			messages[2].(map[string]any)["content"].([]any)[0])
+
+		tools := request["toolConfig"].(map[string]any)["tools"].([]any)
+		require.Len(t, tools, 1)
+		spec := tools[0].(map[string]any)["toolSpec"].(map[string]any)
+		assert.Equal(t, "get_weather", spec["name"])
+		assert.Equal(t, map[string]any{"json": map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}}}, spec["inputSchema"])
+
+		w.Header().Set("X-Amzn-Requestid", "req-1")
+		_, _ = fmt.Fprint(w, `{"output":{"message":{"role":"assistant","content":[
+			{"text":"Checking again."},{"toolUse":{"toolUseId":"call_2","name":"get_weather","input":{"city":"Rome"}}}]}},
+			"stopReason":"tool_use","usage":{"inputTokens":20,"outputTokens":10,"totalTokens":30}}`)
+	})
+
+	messages := []Message{
+		NewSystemMessage("You are helpful."),
+		NewUserMessage("Weather in Paris?"),
+		Message(openai.ChatCompletionMessage{
+			Role:      "assistant",
+			ToolCalls: []openai.ChatCompletionMessageToolCall{{ID: "call_1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "get_weather", Arguments: `{"city":"Paris"}`}}},
+		}.ToParam()),
+		Message(openai.ToolMessage("sunny", "call_1")),
+	}
+
+	response, err := bm.ChatCompletion(context.Background(), messages, 1, []openai.ChatCompletionToolParam{weatherTool()})
+	require.NoError(t, err)
+
+	assert.Equal(t, "req-1", response.ID)
+	choice := response.Choices[0]
+	assert.Equal(t, "tool_calls", choice.FinishReason)
+	assert.Equal(t, "Checking again.", choice.Message.Content)
+	require.Len(t, choice.Message.ToolCalls, 1)
+	assert.Equal(t, "call_2", choice.Message.ToolCalls[0].ID)
+	assert.JSONEq(t, `{"city":"Rome"}`, choice.Message.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, int64(30), response.Usage.TotalTokens)
+}
+
+func TestBedrockModel_ChatCompletionStream(t *testing.T) {
+	events := []struct{ eventType, payload string }{
+		{"messageStart", `{"role":"assistant"}`},
+		{"contentBlockDelta", `{"contentBlockIndex":0,"delta":{"text":"Let me "}}`},
+		{"contentBlockDelta", `{"contentBlockIndex":0,"delta":{"text":"check."}}`},
+		{"contentBlockStop", `{"contentBlockIndex":0}`},
+		{"contentBlockStart", `{"contentBlockIndex":1,"start":{"toolUse":{"toolUseId":"call_1","name":"get_weather"}}}`},
+		{"contentBlockDelta", `{"contentBlockIndex":1,"delta":{"toolUse":{"input":"{\"city\":"}}}`},
+		{"contentBlockDelta", `{"contentBlockIndex":1,"delta":{"toolUse":{"input":"\"Paris\"}"}}}`},
+		{"contentBlockStop", `{"contentBlockIndex":1}`},
+		{"messageStop", `{"stopReason":"tool_use"}`},
+		{"metadata", `{"usage":{"inputTokens":12,"outputTokens":18,"totalTokens":30},"metrics":{"latencyMs":100}}`},
+	}
+	bm := newBedrockStub(t, "converse-stream", func(w http.ResponseWriter, _ map[string]any) {
+		w.Header().Set("Content-Type", "application/vnd.amazon.eventstream")
+		encoder := eventstream.NewEncoder()
+		for _, event := range events {
+			var headers eventstream.Headers
+			headers.Set(":message-type", eventstream.StringValue("event"))
+			headers.Set(":event-type", eventstream.StringValue(event.eventType))
+			headers.Set(":content-type", eventstream.StringValue("application/json"))
+			require.NoError(t, encoder.Encode(w, eventstream.Message{Headers: headers, Payload: []byte(event.payload)}))
+		}
+	})
+
+	var content, arguments strings.Builder
+	var finishReason string
+	var chunkUsage openai.CompletionUsage
+	response, err := bm.ChatCompletionStream(context.Background(), []Message{NewUserMessage("Weather?")}, 1,
+		func(chunk *openai.ChatCompletionChunk) error {
+			delta := chunk.Choices[0].Delta
+			content.WriteString(delta.Content)
+			for _, toolCall := range delta.ToolCalls {
+				arguments.WriteString(toolCall.Function.Arguments)
+			}
+			if chunk.Choices[0].FinishReason != "" {
+				finishReason = chunk.Choices[0].FinishReason
+				chunkUsage = chunk.Usage
+			}
+			return nil
+		}, []openai.ChatCompletionToolParam{weatherTool()})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Let me check.", content.String())
+	assert.JSONEq(t, `{"city":"Paris"}`, arguments.String())
+	assert.Equal(t, "tool_calls", finishReason)
+	assert.Equal(t, int64(30), chunkUsage.TotalTokens)
+
+	message := response.Choices[0].Message
+	assert.Equal(t, "Let me check.", message.Content)
+	require.Len(t, message.ToolCalls, 1)
+	assert.Equal(t, "call_1", message.ToolCalls[0].ID)
+	assert.JSONEq(t, `{"city":"Paris"}`, message.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, int64(30), response.Usage.TotalTokens)
+}
+
+func TestConvertBedrockMessagesMergesConsecutiveToolResults(t *testing.T) {
+	messages := []Message{
+		NewUserMessage("Compare Paris and Rome"),
+		Message(openai.ChatCompletionMessage{
+			Role: "assistant",
+			ToolCalls: []openai.ChatCompletionMessageToolCall{
+				{ID: "call_1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+				{ID: "call_2", Function: openai.ChatCompletionMessageToolCallFunction{Name: "get_weather", Arguments: `{"city":"Rome"}`}},
+			},
+		}.ToParam()),
+		Message(openai.ToolMessage("sunny", "call_1")),
+		Message(openai.ToolMessage("rainy", "call_2")),
+	}
+
+	converted, system := convertBedrockMessages(messages)
+	assert.Empty(t, system)
+	require.Len(t, converted, 3)
+	assert.Len(t, converted[1].Content, 2)
+	assert.Equal(t, "user", string(converted[2].Role))
+	assert.Len(t, converted[2].Content, 2)
+}
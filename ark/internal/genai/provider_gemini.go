@@ -0,0 +1,741 @@
+package genai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/openai/openai-go"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+	"k8s.io/apimachinery/pkg/runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"mckinsey.com/ark/internal/common"
+)
+
+const (
+	geminiCloudPlatformScope = "https://www.googleapis.com/auth/cloud-platform"
+
+	// geminiThoughtSignatureSeparator separates the thought signature of a function call from
+	// the call ID in the tool call ID, so that the signature is sent back with the call
+	geminiThoughtSignatureSeparator = "#sig:"
+)
+
+// GeminiProvider calls Gemini models through the Gemini API with an API key, or through Vertex AI
+// with a service account key
+type GeminiProvider struct {
+	Model             string
+	BaseURL           string
+	APIKey            string
+	ServiceAccountKey string
+	Headers           map[string]string
+	Properties        map[string]string
+	outputSchema      *runtime.RawExtension
+	schemaName        string
+
+	tokenSourceOnce sync.Once
+	tokenSource     oauth2.TokenSource
+	tokenSourceErr  error
+}
+
+// GeminiError is an error returned by the Gemini or Vertex AI API
+type GeminiError struct {
+	StatusCode int
+	Status     string
+	Message    string
+	Header     http.Header
+}
+
+func (e *GeminiError) Error() string {
+	return fmt.Sprintf("gemini API error %d (%s): %s", e.StatusCode, e.Status, e.Message)
+}
+
+// HTTPStatusCode returns the status of the response the error was read from
+func (e *GeminiError) HTTPStatusCode() int {
+	return e.StatusCode
+}
+
+type geminiRequest struct {
+	Contents          []geminiContent `json:"contents"`
+	SystemInstruction *geminiContent  `json:"systemInstruction,omitempty"`
+	Tools             []geminiTool    `json:"tools,omitempty"`
+	GenerationConfig  map[string]any  `json:"generationConfig,omitempty"`
+}
+
+type geminiContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []geminiPart `json:"parts"`
+}
+
+type geminiPart struct {
+	Text             string                  `json:"text,omitempty"`
+	Thought          bool                    `json:"thought,omitempty"`
+	InlineData       *geminiBlob             `json:"inlineData,omitempty"`
+	FileData         *geminiFileData         `json:"fileData,omitempty"`
+	FunctionCall     *geminiFunctionCall     `json:"functionCall,omitempty"`
+	FunctionResponse *geminiFunctionResponse `json:"functionResponse,omitempty"`
+	ThoughtSignature string                  `json:"thoughtSignature,omitempty"`
+}
+
+type geminiBlob struct {
+	MimeType string `json:"mimeType"`
+	Data     string `json:"data"`
+}
+
+type geminiFileData struct {
+	MimeType string `json:"mimeType,omitempty"`
+	FileURI  string `json:"fileUri"`
+}
+
+type geminiFunctionCall struct {
+	ID   string          `json:"id,omitempty"`
+	Name string          `json:"name"`
+	Args json.RawMessage `json:"args,omitempty"`
+}
+
+type geminiFunctionResponse struct {
+	ID       string         `json:"id,omitempty"`
+	Name     string         `json:"name"`
+	Response map[string]any `json:"response"`
+}
+
+type geminiTool struct {
+	FunctionDeclarations []geminiFunctionDeclaration `json:"functionDeclarations"`
+}
+
+type geminiFunctionDeclaration struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"`
+}
+
+type geminiResponse struct {
+	ResponseID    string            `json:"responseId"`
+	ModelVersion  string            `json:"modelVersion"`
+	Candidates    []geminiCandidate `json:"candidates"`
+	UsageMetadata *geminiUsage      `json:"usageMetadata,omitempty"`
+	Error         *geminiErrorBody  `json:"error,omitempty"`
+}
+
+type geminiErrorBody struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type geminiCandidate struct {
+	Content      geminiContent `json:"content"`
+	FinishReason string        `json:"finishReason,omitempty"`
+}
+
+type geminiUsage struct {
+	PromptTokenCount     int64 `json:"promptTokenCount"`
+	CandidatesTokenCount int64 `json:"candidatesTokenCount"`
+	ThoughtsTokenCount   int64 `json:"thoughtsTokenCount"`
+	TotalTokenCount      int64 `json:"totalTokenCount"`
+}
+
+// geminiGenerationConfigNames maps the OpenAI names of model properties to generation config fields
+var geminiGenerationConfigNames = map[string]string{
+	"max_tokens":            "maxOutputTokens",
+	"max_completion_tokens": "maxOutputTokens",
+	"top_p":                 "topP",
+	"top_k":                 "topK",
+	"stop":                  "stopSequences",
+	"presence_penalty":      "presencePenalty",
+	"frequency_penalty":     "frequencyPenalty",
+}
+
+// geminiSchemaFields are the schema keywords of the OpenAPI subset Gemini accepts for function
+// parameters and response schemas
+var geminiSchemaFields = map[string]bool{
+	"type": true, "format": true, "title": true, "description": true, "nullable": true, "enum": true,
+	"properties": true, "required": true, "items": true, "anyOf": true, "propertyOrdering": true,
+	"minItems": true, "maxItems": true, "minLength": true, "maxLength": true, "pattern": true,
+	"minimum": true, "maximum": true, "minProperties": true, "maxProperties": true,
+}
+
+func (gp *GeminiProvider) SetOutputSchema(schema *runtime.RawExtension, schemaName string) {
+	gp.outputSchema = schema
+	gp.schemaName = schemaName
+}
+
+// HealthCheck counts the tokens of a short prompt, which checks the credentials and the model
+// without generating a response
+func (gp *GeminiProvider) HealthCheck(ctx context.Context) error {
+	body, err := json.Marshal(map[string]any{
+		"contents": []geminiContent{{Role: RoleUser, Parts: []geminiPart{{Text: "Hello"}}}},
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := gp.post(ctx, "countTokens", body)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func (gp *GeminiProvider) ChatCompletion(ctx context.Context, messages []Message, n int64, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	body, err := gp.buildRequest(messages, tools...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := gp.post(ctx, "generateContent", body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var response geminiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode Gemini response: %w", err)
+	}
+
+	return gp.convertResponse(response), nil
+}
+
+// ChatCompletionStream streams a response with server-sent events. Each event is a partial
+// response, which is sent as a chunk and merged into the complete response.
+func (gp *GeminiProvider) ChatCompletionStream(ctx context.Context, messages []Message, n int64, streamFunc func(*openai.ChatCompletionChunk) error, tools ...[]openai.ChatCompletionToolParam) (*openai.ChatCompletion, error) {
+	body, err := gp.buildRequest(messages, tools...)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := gp.post(ctx, "streamGenerateContent?alt=sse", body)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	var response *geminiResponse
+	var toolCallCount int
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSSELineSize)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+
+		var partial geminiResponse
+		if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &partial); err != nil {
+			return nil, fmt.Errorf("failed to decode Gemini stream event: %w", err)
+		}
+		if partial.Error != nil {
+			// Errors after the response started arrive as an event instead of an HTTP status
+			return nil, &GeminiError{StatusCode: partial.Error.Code, Status: partial.Error.Status, Message: partial.Error.Message, Header: resp.Header}
+		}
+		if response == nil {
+			response = &geminiResponse{ResponseID: partial.ResponseID, ModelVersion: partial.ModelVersion, Candidates: []geminiCandidate{{}}}
+		}
+
+		chunk := gp.convertChunk(response.ResponseID, partial, &toolCallCount)
+		mergeGeminiResponse(response, partial)
+		if err := streamFunc(chunk); err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if response == nil {
+		return nil, fmt.Errorf("streaming completed but no response was accumulated")
+	}
+	return gp.convertResponse(*response), nil
+}
+
+// convertChunk converts a partial response to a chunk. Function calls always arrive complete,
+// so each is sent as a single tool call delta numbered after the calls of earlier events.
+func (gp *GeminiProvider) convertChunk(responseID string, partial geminiResponse, toolCallCount *int) *openai.ChatCompletionChunk {
+	var delta openai.ChatCompletionChunkChoiceDelta
+	var finishReason string
+	if len(partial.Candidates) > 0 {
+		candidate := partial.Candidates[0]
+		for _, part := range candidate.Content.Parts {
+			switch {
+			case part.Thought:
+			case part.FunctionCall != nil:
+				toolCall := geminiToolCall(responseID, *toolCallCount, part.FunctionCall, part.ThoughtSignature)
+				delta.ToolCalls = append(delta.ToolCalls, openai.ChatCompletionChunkChoiceDeltaToolCall{
+					Index:    int64(*toolCallCount),
+					ID:       toolCall.ID,
+					Type:     "function",
+					Function: openai.ChatCompletionChunkChoiceDeltaToolCallFunction{Name: toolCall.Function.Name, Arguments: toolCall.Function.Arguments},
+				})
+				*toolCallCount++
+			default:
+				delta.Content += part.Text
+			}
+		}
+		if candidate.FinishReason != "" {
+			finishReason = geminiFinishReason(candidate.FinishReason, *toolCallCount > 0)
+		}
+	}
+
+	chunk := &openai.ChatCompletionChunk{
+		ID:     responseID,
+		Object: "chat.completion.chunk",
+		Model:  partial.ModelVersion,
+		Choices: []openai.ChatCompletionChunkChoice{{
+			Index:        0,
+			Delta:        delta,
+			FinishReason: finishReason,
+		}},
+	}
+	if partial.UsageMetadata != nil && finishReason != "" {
+		chunk.Usage = geminiCompletionUsage(partial.UsageMetadata)
+	}
+	return chunk
+}
+
+// mergeGeminiResponse appends the parts of a partial response to the accumulated response,
+// joining consecutive text
+func mergeGeminiResponse(response *geminiResponse, partial geminiResponse) {
+	if partial.UsageMetadata != nil {
+		response.UsageMetadata = partial.UsageMetadata
+	}
+	if len(partial.Candidates) == 0 {
+		return
+	}
+
+	candidate := &response.Candidates[0]
+	if reason := partial.Candidates[0].FinishReason; reason != "" {
+		candidate.FinishReason = reason
+	}
+	for _, part := range partial.Candidates[0].Content.Parts {
+		last := len(candidate.Content.Parts) - 1
+		if part.Text != "" && last >= 0 && candidate.Content.Parts[last].Text != "" && candidate.Content.Parts[last].Thought == part.Thought {
+			candidate.Content.Parts[last].Text += part.Text
+			continue
+		}
+		candidate.Content.Parts = append(candidate.Content.Parts, part)
+	}
+}
+
+func (gp *GeminiProvider) buildRequest(messages []Message, tools ...[]openai.ChatCompletionToolParam) ([]byte, error) {
+	request := convertGeminiMessages(messages)
+
+	if len(tools) > 0 && len(tools[0]) > 0 {
+		request.Tools = []geminiTool{{FunctionDeclarations: convertGeminiTools(tools[0])}}
+	}
+
+	generationConfig := map[string]any{}
+	for key, value := range gp.Properties {
+		if value == "" {
+			continue
+		}
+		if name, ok := geminiGenerationConfigNames[key]; ok {
+			key = name
+		}
+		var parsed any
+		if err := json.Unmarshal([]byte(value), &parsed); err != nil {
+			parsed = value
+		}
+		// Gemini takes a list of stop sequences where OpenAI also accepts a single one
+		if stop, ok := parsed.(string); ok && key == "stopSequences" {
+			parsed = []string{stop}
+		}
+		generationConfig[key] = parsed
+	}
+
+	if gp.outputSchema != nil && gp.outputSchema.Raw != nil {
+		var schema map[string]any
+		if err := json.Unmarshal(gp.outputSchema.Raw, &schema); err != nil {
+			return nil, fmt.Errorf("failed to parse output schema %s: %w", gp.schemaName, err)
+		}
+		generationConfig["responseMimeType"] = "application/json"
+		generationConfig["responseSchema"] = geminiSchema(schema)
+	}
+
+	if len(generationConfig) > 0 {
+		request.GenerationConfig = generationConfig
+	}
+	return json.Marshal(request)
+}
+
+// convertGeminiMessages converts messages to Gemini contents. System messages become the system
+// instruction, and tool results become function responses with the ID and name of the calls
+// they answer.
+func convertGeminiMessages(messages []Message) geminiRequest {
+	var request geminiRequest
+	var system []string
+	toolNames := map[string]string{}
+
+	appendParts := func(role string, parts []geminiPart) {
+		if len(parts) == 0 {
+			return
+		}
+		if last := len(request.Contents) - 1; last >= 0 && request.Contents[last].Role == role {
+			request.Contents[last].Parts = append(request.Contents[last].Parts, parts...)
+			return
+		}
+		request.Contents = append(request.Contents, geminiContent{Role: role, Parts: parts})
+	}
+
+	for _, msg := range messages {
+		param := openai.ChatCompletionMessageParamUnion(msg)
+		switch {
+		case param.OfSystem != nil:
+			if text := joinTextParts(param.OfSystem.Content.OfString.Value, param.OfSystem.Content.OfArrayOfContentParts); text != "" {
+				system = append(system, text)
+			}
+		case param.OfDeveloper != nil:
+			if text := joinTextParts(param.OfDeveloper.Content.OfString.Value, param.OfDeveloper.Content.OfArrayOfContentParts); text != "" {
+				system = append(system, text)
+			}
+		case param.OfUser != nil:
+			appendParts(RoleUser, convertGeminiUserContent(param.OfUser.Content))
+		case param.OfAssistant != nil:
+			for _, toolCall := range param.OfAssistant.ToolCalls {
+				toolNames[toolCall.ID] = toolCall.Function.Name
+			}
+			appendParts("model", convertGeminiAssistantContent(param.OfAssistant))
+		case param.OfTool != nil:
+			content := joinTextParts(param.OfTool.Content.OfString.Value, param.OfTool.Content.OfArrayOfContentParts)
+			id, _ := splitGeminiToolCallID(param.OfTool.ToolCallID)
+			appendParts(RoleUser, []geminiPart{{FunctionResponse: &geminiFunctionResponse{
+				ID:       id,
+				Name:     toolNames[param.OfTool.ToolCallID],
+				Response: geminiToolResult(content),
+			}}})
+		}
+	}
+
+	if len(system) > 0 {
+		request.SystemInstruction = &geminiContent{Parts: []geminiPart{{Text: strings.Join(system, "\n\n")}}}
+	}
+	return request
+}
+
+// geminiToolResult wraps a tool result in the object Gemini expects as a function response
+func geminiToolResult(content string) map[string]any {
+	var result map[string]any
+	if err := json.Unmarshal([]byte(content), &result); err == nil && result != nil {
+		return result
+	}
+	return map[string]any{"result": content}
+}
+
+func convertGeminiUserContent(content openai.ChatCompletionUserMessageParamContentUnion) []geminiPart {
+	if content.OfString.Value != "" {
+		return []geminiPart{{Text: content.OfString.Value}}
+	}
+
+	var parts []geminiPart
+	for _, part := range content.OfArrayOfContentParts {
+		switch {
+		case part.OfText != nil && part.OfText.Text != "":
+			parts = append(parts, geminiPart{Text: part.OfText.Text})
+		case part.OfImageURL != nil:
+			parts = append(parts, geminiImage(part.OfImageURL.ImageURL.URL))
+		}
+	}
+	return parts
+}
+
+// geminiImage converts an image URL to inline data when it is a base64 data URL, and to a file
+// reference otherwise
+func geminiImage(url string) geminiPart {
+	if rest, ok := strings.CutPrefix(url, "data:"); ok {
+		if mimeType, data, found := strings.Cut(rest, ";base64,"); found {
+			return geminiPart{InlineData: &geminiBlob{MimeType: mimeType, Data: data}}
+		}
+	}
+	return geminiPart{FileData: &geminiFileData{FileURI: url}}
+}
+
+func convertGeminiAssistantContent(assistant *openai.ChatCompletionAssistantMessageParam) []geminiPart {
+	var parts []geminiPart
+	if text := assistant.Content.OfString.Value; text != "" {
+		parts = append(parts, geminiPart{Text: text})
+	}
+	for _, part := range assistant.Content.OfArrayOfContentParts {
+		if part.OfText != nil && part.OfText.Text != "" {
+			parts = append(parts, geminiPart{Text: part.OfText.Text})
+		}
+	}
+
+	for _, toolCall := range assistant.ToolCalls {
+		args := json.RawMessage(toolCall.Function.Arguments)
+		if !json.Valid(args) {
+			args = json.RawMessage("{}")
+		}
+		id, signature := splitGeminiToolCallID(toolCall.ID)
+		parts = append(parts, geminiPart{
+			FunctionCall:     &geminiFunctionCall{ID: id, Name: toolCall.Function.Name, Args: args},
+			ThoughtSignature: signature,
+		})
+	}
+	return parts
+}
+
+func convertGeminiTools(tools []openai.ChatCompletionToolParam) []geminiFunctionDeclaration {
+	declarations := make([]geminiFunctionDeclaration, 0, len(tools))
+	for _, tool := range tools {
+		declaration := geminiFunctionDeclaration{
+			Name:        tool.Function.Name,
+			Description: tool.Function.Description.Value,
+		}
+		// Gemini rejects object parameters without properties, so tools without arguments have none
+		if properties, _ := tool.Function.Parameters["properties"].(map[string]any); len(properties) > 0 {
+			declaration.Parameters = geminiSchema(tool.Function.Parameters)
+		}
+		declarations = append(declarations, declaration)
+	}
+	return declarations
+}
+
+// geminiSchema reduces a JSON schema to the keywords Gemini accepts. Type lists that include null
+// become a nullable type.
+func geminiSchema(schema map[string]any) map[string]any {
+	result := make(map[string]any, len(schema))
+	for key, value := range schema {
+		if !geminiSchemaFields[key] {
+			continue
+		}
+		switch key {
+		case "type":
+			types, ok := value.([]any)
+			if !ok {
+				result[key] = value
+				continue
+			}
+			for _, t := range types {
+				if t == "null" {
+					result["nullable"] = true
+				} else if _, set := result["type"]; !set {
+					result["type"] = t
+				}
+			}
+		case "properties":
+			properties, _ := value.(map[string]any)
+			converted := make(map[string]any, len(properties))
+			for name, property := range properties {
+				if propertySchema, ok := property.(map[string]any); ok {
+					converted[name] = geminiSchema(propertySchema)
+				}
+			}
+			result[key] = converted
+		case "items":
+			if items, ok := value.(map[string]any); ok {
+				result[key] = geminiSchema(items)
+			}
+		case "anyOf":
+			options, _ := value.([]any)
+			converted := make([]any, 0, len(options))
+			for _, option := range options {
+				if optionSchema, ok := option.(map[string]any); ok {
+					converted = append(converted, geminiSchema(optionSchema))
+				}
+			}
+			result[key] = converted
+		default:
+			result[key] = value
+		}
+	}
+	return result
+}
+
+func (gp *GeminiProvider) convertResponse(response geminiResponse) *openai.ChatCompletion {
+	message := openai.ChatCompletionMessage{Role: "assistant"}
+	var finishReason string
+
+	if len(response.Candidates) > 0 {
+		candidate := response.Candidates[0]
+		var content strings.Builder
+		for _, part := range candidate.Content.Parts {
+			switch {
+			case part.Thought:
+			case part.FunctionCall != nil:
+				message.ToolCalls = append(message.ToolCalls, geminiToolCall(response.ResponseID, len(message.ToolCalls), part.FunctionCall, part.ThoughtSignature))
+			default:
+				content.WriteString(part.Text)
+			}
+		}
+		message.Content = content.String()
+		finishReason = geminiFinishReason(candidate.FinishReason, len(message.ToolCalls) > 0)
+	}
+
+	completion := &openai.ChatCompletion{
+		ID:     response.ResponseID,
+		Object: "chat.completion",
+		Model:  response.ModelVersion,
+		Choices: []openai.ChatCompletionChoice{{
+			Index:        0,
+			Message:      message,
+			FinishReason: finishReason,
+		}},
+	}
+	if response.UsageMetadata != nil {
+		completion.Usage = geminiCompletionUsage(response.UsageMetadata)
+	}
+	return completion
+}
+
+// geminiToolCall converts a function call to a tool call. Gemini only returns call IDs on some
+// models, otherwise one is derived from the response ID and the position of the call. Thinking
+// models sign their function calls and expect the signature back on the next turn, so it is
+// carried in the tool call ID.
+func geminiToolCall(responseID string, index int, call *geminiFunctionCall, signature string) openai.ChatCompletionMessageToolCall {
+	id := call.ID
+	if id == "" {
+		id = fmt.Sprintf("call_%s_%d", responseID, index)
+	}
+	if signature != "" {
+		id += geminiThoughtSignatureSeparator + signature
+	}
+	arguments := string(call.Args)
+	if len(call.Args) == 0 || string(call.Args) == "null" {
+		arguments = "{}"
+	}
+	return openai.ChatCompletionMessageToolCall{
+		ID:       id,
+		Type:     "function",
+		Function: openai.ChatCompletionMessageToolCallFunction{Name: call.Name, Arguments: arguments},
+	}
+}
+
+// splitGeminiToolCallID returns the call ID and the thought signature of a tool call ID
+func splitGeminiToolCallID(toolCallID string) (string, string) {
+	id, signature, _ := strings.Cut(toolCallID, geminiThoughtSignatureSeparator)
+	return id, signature
+}
+
+func geminiFinishReason(reason string, hasToolCalls bool) string {
+	switch reason {
+	case "":
+		return ""
+	case "MAX_TOKENS":
+		return "length"
+	case "SAFETY", "RECITATION", "BLOCKLIST", "PROHIBITED_CONTENT", "SPII", "IMAGE_SAFETY":
+		return "content_filter"
+	}
+	if hasToolCalls {
+		return "tool_calls"
+	}
+	return "stop"
+}
+
+func geminiCompletionUsage(usage *geminiUsage) openai.CompletionUsage {
+	completionTokens := usage.CandidatesTokenCount + usage.ThoughtsTokenCount
+	return openai.CompletionUsage{
+		PromptTokens:     usage.PromptTokenCount,
+		CompletionTokens: completionTokens,
+		TotalTokens:      usage.PromptTokenCount + completionTokens,
+		CompletionTokensDetails: openai.CompletionUsageCompletionTokensDetails{
+			ReasoningTokens: usage.ThoughtsTokenCount,
+		},
+	}
+}
+
+// post sends a request to a method of the model, such as generateContent
+func (gp *GeminiProvider) post(ctx context.Context, method string, body []byte) (*http.Response, error) {
+	url := fmt.Sprintf("%s/models/%s:%s", strings.TrimSuffix(gp.BaseURL, "/"), gp.Model, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if err := gp.setHeaders(ctx, req); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := gp.httpClient(ctx).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		defer func() { _ = resp.Body.Close() }()
+		return nil, readGeminiError(resp)
+	}
+	return resp, nil
+}
+
+func readGeminiError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	apiErr := &GeminiError{StatusCode: resp.StatusCode, Header: resp.Header, Message: http.StatusText(resp.StatusCode)}
+
+	var errorBody struct {
+		Error struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &errorBody) == nil && errorBody.Error.Message != "" {
+		apiErr.Status = errorBody.Error.Status
+		apiErr.Message = errorBody.Error.Message
+	}
+	return apiErr
+}
+
+func (gp *GeminiProvider) setHeaders(ctx context.Context, req *http.Request) error {
+	if gp.APIKey != "" {
+		req.Header.Set("x-goog-api-key", gp.APIKey)
+	} else {
+		token, err := gp.token()
+		if err != nil {
+			return err
+		}
+		token.SetAuthHeader(req)
+	}
+
+	if len(gp.Headers) == 0 {
+		return nil
+	}
+	log := logf.FromContext(ctx)
+	log.V(1).Info("applying custom headers to client", "model", gp.Model, "header_count", len(gp.Headers))
+	for name, value := range gp.Headers {
+		req.Header.Set(name, value)
+	}
+	return nil
+}
+
+// token returns an access token for the service account, the token source caches it until it expires
+func (gp *GeminiProvider) token() (*oauth2.Token, error) {
+	gp.tokenSourceOnce.Do(func() {
+		config, err := google.JWTConfigFromJSON([]byte(gp.ServiceAccountKey), geminiCloudPlatformScope)
+		if err != nil {
+			gp.tokenSourceErr = fmt.Errorf("failed to parse Gemini service account key: %w", err)
+			return
+		}
+		gp.tokenSource = config.TokenSource(context.Background())
+	})
+	if gp.tokenSourceErr != nil {
+		return nil, gp.tokenSourceErr
+	}
+
+	token, err := gp.tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Gemini access token: %w", err)
+	}
+	return token, nil
+}
+
+func (gp *GeminiProvider) httpClient(ctx context.Context) *http.Client {
+	if IsProbeContext(ctx) {
+		return common.NewHTTPClientWithoutTracing()
+	}
+	return common.NewHTTPClientWithLogging(ctx)
+}
+
+func (gp *GeminiProvider) BuildConfig() map[string]any {
+	config := map[string]any{
+		"baseUrl": gp.BaseURL,
+	}
+	if gp.APIKey != "" {
+		config["apiKey"] = gp.APIKey
+	}
+	return config
+}
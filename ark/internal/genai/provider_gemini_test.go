@@ -0,0 +1,317 @@
+package genai
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+)
+
+// newGeminiStub serves a model of the Gemini API, passing each decoded request to handle
+func newGeminiStub(t *testing.T, method string, handle func(w http.ResponseWriter, request map[string]any)) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1beta/models/gemini-2.5-flash:"+method, r.URL.Path)
+		assert.Equal(t, "test-key", r.Header.Get("x-goog-api-key"))
+
+		var request map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		handle(w, request)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestGeminiProvider(baseURL string) *GeminiProvider {
+	return &GeminiProvider{
+		Model:      "gemini-2.5-flash",
+		BaseURL:    baseURL + "/v1beta",
+		APIKey:     "test-key",
+		Properties: map[string]string{"temperature": "0.2", "max_tokens": "512"},
+	}
+}
+
+func TestGeminiProvider_ChatCompletionTranslatesMessagesAndTools(t *testing.T) {
+	server := newGeminiStub(t, "generateContent", func(w http.ResponseWriter, request map[string]any) {
+		assert.Equal(t, map[string]any{"parts": []any{map[string]any{"text": "You are helpful."}}}, request["systemInstruction"])
+		assert.Equal(t, map[string]any{"temperature": 0.2, "maxOutputTokens": float64(512)}, request["generationConfig"])
+
+		contents := request["contents"].([]any)
+		require.Len(t, contents, 3)
+		model := contents[1].(map[string]any)
+		assert.Equal(t, "model", model["role"])
+		functionCall := model["parts"].([]any)[0].(map[string]any)["functionCall"]
+		assert.Equal(t, map[string]any{"id": "call_1", "name": "get_weather", "args": map[string]any{"city": "Paris"}}, functionCall)
+		functionResponse := contents[2].(map[string]any)["parts"].([]any)[0].(map[string]any)["functionResponse"]
+		assert.Equal(t, map[string]any{"id": "call_1", "name": "get_weather", "response": map[string]any{"result": "sunny"}}, functionResponse)
+
+		declarations := request["tools"].([]any)[0].(map[string]any)["functionDeclarations"].([]any)
+		require.Len(t, declarations, 1)
+		assert.Equal(t, map[string]any{
+			"name":        "get_weather",
+			"description": "Get the weather",
+			"parameters":  map[string]any{"type": "object", "properties": map[string]any{"city": map[string]any{"type": "string"}}},
+		}, declarations[0])
+
+		_, _ = fmt.Fprint(w, `{"responseId":"resp1","modelVersion":"gemini-2.5-flash",
+			"candidates":[{"finishReason":"STOP","content":{"role":"model","parts":[
+				{"text":"thinking...","thought":true},{"text":"Checking again."},{"functionCall":{"name":"get_weather","args":{"city":"Rome"}}}]}}],
+			"usageMetadata":{"promptTokenCount":20,"candidatesTokenCount":6,"thoughtsTokenCount":4,"totalTokenCount":30}}`)
+	})
+
+	messages := []Message{
+		NewSystemMessage("You are helpful."),
+		NewUserMessage("Weather in Paris?"),
+		Message(openai.ChatCompletionMessage{
+			Role:      "assistant",
+			ToolCalls: []openai.ChatCompletionMessageToolCall{{ID: "call_1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "get_weather", Arguments: `{"city":"Paris"}`}}},
+		}.ToParam()),
+		Message(openai.ToolMessage("sunny", "call_1")),
+	}
+
+	response, err := newTestGeminiProvider(server.URL).ChatCompletion(context.Background(), messages, 1, []openai.ChatCompletionToolParam{weatherTool()})
+	require.NoError(t, err)
+
+	choice := response.Choices[0]
+	assert.Equal(t, "tool_calls", choice.FinishReason)
+	assert.Equal(t, "Checking again.", choice.Message.Content)
+	require.Len(t, choice.Message.ToolCalls, 1)
+	assert.Equal(t, "call_resp1_0", choice.Message.ToolCalls[0].ID)
+	assert.JSONEq(t, `{"city":"Rome"}`, choice.Message.ToolCalls[0].Function.Arguments)
+	assert.Equal(t, int64(30), response.Usage.TotalTokens)
+	assert.Equal(t, int64(4), response.Usage.CompletionTokensDetails.ReasoningTokens)
+}
+
+func TestGeminiProvider_SendsThoughtSignaturesBack(t *testing.T) {
+	calls := 0
+	server := newGeminiStub(t, "generateContent", func(w http.ResponseWriter, request map[string]any) {
+		calls++
+		if calls == 1 {
+			_, _ = fmt.Fprint(w, `{"responseId":"resp1","candidates":[{"finishReason":"STOP","content":{"role":"model","parts":[
+				{"functionCall":{"name":"get_weather","args":{"city":"Paris"}},"thoughtSignature":"c2lnbmF0dXJl"}]}}]}`)
+			return
+		}
+
+		contents := request["contents"].([]any)
+		require.Len(t, contents, 3)
+		part := contents[1].(map[string]any)["parts"].([]any)[0].(map[string]any)
+		assert.Equal(t, "c2lnbmF0dXJl", part["thoughtSignature"])
+		assert.Equal(t, "call_resp1_0", part["functionCall"].(map[string]any)["id"])
+		functionResponse := contents[2].(map[string]any)["parts"].([]any)[0].(map[string]any)["functionResponse"].(map[string]any)
+		assert.Equal(t, "call_resp1_0", functionResponse["id"])
+		_, _ = fmt.Fprint(w, `{"responseId":"resp2","candidates":[{"finishReason":"STOP","content":{"parts":[{"text":"Sunny."}]}}]}`)
+	})
+	provider := newTestGeminiProvider(server.URL)
+
+	messages := []Message{NewUserMessage("Weather in Paris?")}
+	response, err := provider.ChatCompletion(context.Background(), messages, 1, []openai.ChatCompletionToolParam{weatherTool()})
+	require.NoError(t, err)
+	toolCall := response.Choices[0].Message.ToolCalls[0]
+
+	messages = append(messages, Message(response.Choices[0].Message.ToParam()), Message(openai.ToolMessage("sunny", toolCall.ID)))
+	response, err = provider.ChatCompletion(context.Background(), messages, 1, []openai.ChatCompletionToolParam{weatherTool()})
+	require.NoError(t, err)
+	assert.Equal(t, "Sunny.", response.Choices[0].Message.Content)
+	assert.Equal(t, 2, calls)
+}
+
+func TestGeminiProvider_StreamReturnsErrorEvents(t *testing.T) {
+	server := newGeminiStub(t, "streamGenerateContent", func(w http.ResponseWriter, _ map[string]any) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = fmt.Fprint(w, "data: {\"responseId\":\"resp1\",\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"Let me \"}]}}]}\r\n\r\n")
+		_, _ = fmt.Fprint(w, "data: {\"error\":{\"code\":503,\"status\":\"UNAVAILABLE\",\"message\":\"The model is overloaded.\"}}\r\n\r\n")
+	})
+
+	_, err := newTestGeminiProvider(server.URL).ChatCompletionStream(context.Background(), []Message{NewUserMessage("Weather?")}, 1,
+		func(*openai.ChatCompletionChunk) error { return nil })
+	var geminiErr *GeminiError
+	require.ErrorAs(t, err, &geminiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, geminiErr.HTTPStatusCode())
+	assert.Contains(t, err.Error(), "The model is overloaded.")
+}
+
+func TestGeminiProvider_StructuredOutput(t *testing.T) {
+	server := newGeminiStub(t, "generateContent", func(w http.ResponseWriter, request map[string]any) {
+		generationConfig := request["generationConfig"].(map[string]any)
+		assert.Equal(t, "application/json", generationConfig["responseMimeType"])
+		assert.Equal(t, map[string]any{
+			"type":     "object",
+			"required": []any{"answer"},
+			"properties": map[string]any{
+				"answer": map[string]any{"type": "string", "nullable": true},
+			},
+		}, generationConfig["responseSchema"])
+
+		_, _ = fmt.Fprint(w, `{"responseId":"resp1","candidates":[{"finishReason":"STOP","content":{"parts":[{"text":"{\"answer\":\"42\"}"}]}}]}`)
+	})
+
+	provider := newTestGeminiProvider(server.URL)
+	provider.SetOutputSchema(&runtime.RawExtension{Raw: []byte(`{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object",
+		"additionalProperties":false,"required":["answer"],"properties":{"answer":{"type":["string","null"]}}}`)}, "answer")
+
+	response, err := provider.ChatCompletion(context.Background(), []Message{NewUserMessage("What is the answer?")}, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "stop", response.Choices[0].FinishReason)
+	assert.JSONEq(t, `{"answer":"42"}`, response.Choices[0].Message.Content)
+}
+
+func TestGeminiProvider_ChatCompletionStream(t *testing.T) {
+	events := []string{
+		`{"responseId":"resp1","modelVersion":"gemini-2.5-flash","candidates":[{"content":{"role":"model","parts":[{"text":"Let me "}]}}]}`,
+		`{"responseId":"resp1","modelVersion":"gemini-2.5-flash","candidates":[{"content":{"role":"model","parts":[{"text":"check."}]}}]}`,
+		`{"responseId":"resp1","modelVersion":"gemini-2.5-flash","candidates":[{"finishReason":"STOP","content":{"role":"model",
+			"parts":[{"functionCall":{"name":"get_weather","args":{"city":"Paris"}}}]}}],
+			"usageMetadata":{"promptTokenCount":12,"candidatesTokenCount":18,"totalTokenCount":30}}`,
+	}
+	server := newGeminiStub(t, "streamGenerateContent", func(w http.ResponseWriter, _ map[string]any) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			_, _ = fmt.Fprintf(w, "data: %s\r\n\r\n", strings.ReplaceAll(event, "\n", ""))
+		}
+	})
+
+	var content strings.Builder
+	var toolCalls []openai.ChatCompletionChunkChoiceDeltaToolCall
+	var finishReason string
+	var usage openai.CompletionUsage
+	response, err := newTestGeminiProvider(server.URL).ChatCompletionStream(context.Background(), []Message{NewUserMessage("Weather?")}, 1,
+		func(chunk *openai.ChatCompletionChunk) error {
+			content.WriteString(chunk.Choices[0].Delta.Content)
+			toolCalls = append(toolCalls, chunk.Choices[0].Delta.ToolCalls...)
+			if chunk.Choices[0].FinishReason != "" {
+				finishReason = chunk.Choices[0].FinishReason
+				usage = chunk.Usage
+			}
+			return nil
+		}, []openai.ChatCompletionToolParam{weatherTool()})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Let me check.", content.String())
+	require.Len(t, toolCalls, 1)
+	assert.Equal(t, "call_resp1_0", toolCalls[0].ID)
+	assert.JSONEq(t, `{"city":"Paris"}`, toolCalls[0].Function.Arguments)
+	assert.Equal(t, "tool_calls", finishReason)
+	assert.Equal(t, int64(30), usage.TotalTokens)
+
+	message := response.Choices[0].Message
+	assert.Equal(t, "Let me check.", message.Content)
+	require.Len(t, message.ToolCalls, 1)
+	assert.Equal(t, "call_resp1_0", message.ToolCalls[0].ID)
+	assert.Equal(t, int64(30), response.Usage.TotalTokens)
+}
+
+func TestGeminiProvider_ErrorsAreRetryable(t *testing.T) {
+	server := newGeminiStub(t, "generateContent", func(w http.ResponseWriter, _ map[string]any) {
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = fmt.Fprint(w, `{"error":{"code":429,"message":"Resource has been exhausted","status":"RESOURCE_EXHAUSTED"}}`)
+	})
+
+	_, err := newTestGeminiProvider(server.URL).ChatCompletion(context.Background(), []Message{NewUserMessage("hi")}, 1)
+	var apiErr *GeminiError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, "RESOURCE_EXHAUSTED", apiErr.Status)
+
+	status, retryAfter := retryStatus(err)
+	assert.Equal(t, http.StatusTooManyRequests, status)
+	assert.Equal(t, 3*time.Second, retryAfter)
+}
+
+func TestGeminiProvider_HealthCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1beta/models/gemini-2.5-flash:countTokens", r.URL.Path)
+		if r.Header.Get("x-goog-api-key") != "test-key" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"error":{"code":400,"message":"API key not valid","status":"INVALID_ARGUMENT"}}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"totalTokens":1}`)
+	}))
+	defer server.Close()
+
+	provider := newTestGeminiProvider(server.URL)
+	require.NoError(t, provider.HealthCheck(context.Background()))
+
+	provider.APIKey = "wrong-key"
+	err := provider.HealthCheck(context.Background())
+	require.Error(t, err)
+	assert.Equal(t, "API key not valid (400)", extractStableError(err, time.Second))
+}
+
+func TestGeminiProvider_ServiceAccountAuthentication(t *testing.T) {
+	tokenRequests := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		assert.Equal(t, "urn:ietf:params:oauth:grant-type:jwt-bearer", r.FormValue("grant_type"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"access_token":"vertex-token","token_type":"Bearer","expires_in":3600}`)
+	})
+	mux.HandleFunc("/v1/projects/my-project/locations/us-central1/publishers/google/models/gemini-2.5-flash:countTokens", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer vertex-token", r.Header.Get("Authorization"))
+		assert.Empty(t, r.Header.Get("x-goog-api-key"))
+		_, _ = fmt.Fprint(w, `{"totalTokens":1}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	serviceAccountKey, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "my-project",
+		"private_key":  string(keyPEM),
+		"client_email": "ark@my-project.iam.gserviceaccount.com",
+		"token_uri":    server.URL + "/token",
+	})
+	require.NoError(t, err)
+
+	provider := &GeminiProvider{
+		Model:             "gemini-2.5-flash",
+		BaseURL:           server.URL + "/v1/projects/my-project/locations/us-central1/publishers/google",
+		ServiceAccountKey: string(serviceAccountKey),
+	}
+	require.NoError(t, provider.HealthCheck(context.Background()))
+	require.NoError(t, provider.HealthCheck(context.Background()))
+	assert.Equal(t, 1, tokenRequests)
+}
+
+func TestLoadGeminiConfigDefaultsToVertexForServiceAccounts(t *testing.T) {
+	resolver := common.NewValueSourceResolver(setupModelTestClient(nil))
+	config := &arkv1alpha1.GeminiModelConfig{
+		ServiceAccountKey: &arkv1alpha1.ValueSource{Value: `{"type":"service_account","project_id":"key-project"}`},
+		Location:          &arkv1alpha1.ValueSource{Value: "europe-west4"},
+	}
+
+	model := &Model{Model: "gemini-2.5-flash"}
+	require.NoError(t, loadGeminiConfig(context.Background(), resolver, config, "default", model, nil))
+	assert.Equal(t, "https://europe-west4-aiplatform.googleapis.com/v1/projects/key-project/locations/europe-west4/publishers/google",
+		model.Provider.(*GeminiProvider).BaseURL)
+
+	config.Project = &arkv1alpha1.ValueSource{Value: "other-project"}
+	config.Location = &arkv1alpha1.ValueSource{Value: "global"}
+	require.NoError(t, loadGeminiConfig(context.Background(), resolver, config, "default", model, nil))
+	assert.Equal(t, "https://aiplatform.googleapis.com/v1/projects/other-project/locations/global/publishers/google",
+		model.Provider.(*GeminiProvider).BaseURL)
+
+	config.APIKey = &arkv1alpha1.ValueSource{Value: "test-key"}
+	require.ErrorContains(t, loadGeminiConfig(context.Background(), resolver, config, "default", model, nil), "exactly one of apiKey or serviceAccountKey")
+}
@@ -14,13 +14,15 @@ import (
 )
 
 type OpenAIProvider struct {
-	Model        string
-	BaseURL      string
-	APIKey       string
-	Headers      map[string]string
-	Properties   map[string]string
-	outputSchema *runtime.RawExtension
-	schemaName   string
+	Model      string
+	BaseURL    string
+	APIKey     string
+	Headers    map[string]string
+	Properties map[string]string
+	// DisableRetries turns off the SDK's own retries when the model has a retry policy
+	DisableRetries bool
+	outputSchema   *runtime.RawExtension
+	schemaName     string
 }
 
 func (op *OpenAIProvider) SetOutputSchema(schema *runtime.RawExtension, schemaName string) {
@@ -282,6 +284,10 @@ func (op *OpenAIProvider) createClient(ctx context.Context) openai.Client {
 		option.WithHTTPClient(httpClient),
 	}
 
+	if op.DisableRetries {
+		options = append(options, option.WithMaxRetries(0))
+	}
+
 	options = applyHeadersToOptions(ctx, op.Headers, options, op.Model)
 
 	return openai.NewClient(options...)
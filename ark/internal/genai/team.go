@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"sync"
 
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -21,6 +22,8 @@ type Team struct {
 	MaxTurns          *int
 	Selector          *arkv1alpha1.TeamSelectorSpec
 	Graph             *arkv1alpha1.TeamGraphSpec
+	Parallel          *arkv1alpha1.TeamParallelSpec
+	Termination       *arkv1alpha1.TeamTerminationSpec
 	telemetryRecorder telemetry.TeamRecorder
 	eventingRecorder  eventing.TeamRecorder
 	telemetry         telemetry.Provider
@@ -29,6 +32,15 @@ type Team struct {
 	Namespace         string
 	memory            MemoryInterface
 	eventStream       EventStreamInterface
+	checkpointer      ExecutionCheckpointer
+
+	// State of the current execution used to stop the team early
+	timeoutCause          error
+	terminationConditions []terminationCondition
+	terminationMutex      sync.Mutex
+	terminationReason     string
+	usageCtx              context.Context
+	branchUsage           map[int]context.Context
 }
 
 // FullName returns the namespace/name format for the team
@@ -44,6 +56,10 @@ func (t *Team) Execute(ctx context.Context, userInput Message, history []Message
 	// Store memory and streaming parameters for member execution
 	t.memory = memory
 	t.eventStream = eventStream
+	ctx, t.checkpointer = claimCheckpointer(ctx)
+	if err := t.startTermination(); err != nil {
+		return nil, err
+	}
 
 	var execFunc func(context.Context, Message, []Message) ([]Message, error)
 	switch t.Strategy {
@@ -55,19 +71,25 @@ func (t *Team) Execute(ctx context.Context, userInput Message, history []Message
 		execFunc = t.executeSelector
 	case "graph":
 		execFunc = t.executeGraph
+	case "parallel":
+		execFunc = t.executeParallel
 	default:
 		return nil, fmt.Errorf("unsupported strategy %s for team %s", t.Strategy, t.FullName())
 	}
 
 	messages, err := t.executeWithTracking(execFunc, ctx, userInput, history)
-	return &ExecutionResult{Messages: messages}, err
+	return &ExecutionResult{Messages: messages, TerminationReason: t.stopped()}, err
 }
 
 func (t *Team) executeSequential(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
-	messages := slices.Clone(history)
-	var newMessages []Message
+	messages, newMessages, completedTurns := t.resumeProgress(history)
 
 	for i, member := range t.Members {
+		// Skip turns completed by an interrupted attempt
+		if i < completedTurns {
+			continue
+		}
+
 		// Check if context was cancelled
 		if ctx.Err() != nil {
 			return newMessages, ctx.Err()
@@ -103,17 +125,15 @@ func (t *Team) executeSequential(ctx context.Context, userInput Message, history
 		t.telemetryRecorder.RecordSuccess(turnSpan)
 		turnSpan.End()
 		t.eventingRecorder.Complete(turnCtx, "TeamTurn", fmt.Sprintf("Team turn %d completed successfully", i), operationData)
+		t.recordProgress(ctx, newMessages, i+1)
 	}
 
 	return newMessages, nil
 }
 
 func (t *Team) executeRoundRobin(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
-	messages := slices.Clone(history)
-	var newMessages []Message
-
-	messageCount := 0 // Count individual agent messages
-	memberIndex := 0  // Track which agent should speak next
+	messages, newMessages, messageCount := t.resumeProgress(history) // Count individual agent messages
+	memberIndex := messageCount % len(t.Members)                     // Track which agent should speak next
 
 	for {
 		// Check if context was cancelled
@@ -122,7 +142,7 @@ func (t *Team) executeRoundRobin(ctx context.Context, userInput Message, history
 		}
 
 		// Check maxTurns before executing
-		if t.MaxTurns != nil && messageCount >= *t.MaxTurns {
+		if t.maxTurnsReached(messageCount) {
 			return newMessages, nil
 		}
 
@@ -164,7 +184,29 @@ func (t *Team) executeRoundRobin(ctx context.Context, userInput Message, history
 
 		messageCount++                                   // Increment message count
 		memberIndex = (memberIndex + 1) % len(t.Members) // Move to next agent in round-robin
+		t.recordProgress(ctx, newMessages, messageCount)
+	}
+}
+
+// resumeProgress returns the conversation state to start from, including the messages and
+// number of turns recorded by an interrupted attempt when the team is the query target.
+func (t *Team) resumeProgress(history []Message) (messages, newMessages []Message, completedTurns int) {
+	messages = slices.Clone(history)
+	if t.checkpointer == nil {
+		return messages, nil, 0
+	}
+
+	resumed := t.checkpointer.Resume()
+	messages = append(messages, resumed.Messages...)
+	return messages, slices.Clone(resumed.Messages), resumed.Turns
+}
+
+// recordProgress checkpoints the messages produced by completed turns
+func (t *Team) recordProgress(ctx context.Context, newMessages []Message, completedTurns int) {
+	if t.checkpointer == nil {
+		return
 	}
+	t.checkpointer.Checkpoint(ctx, ExecutionProgress{Messages: newMessages, Turns: completedTurns})
 }
 
 func (t *Team) GetName() string {
@@ -180,7 +222,12 @@ func (t *Team) GetDescription() string {
 }
 
 func MakeTeam(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Team, telemetryProvider telemetry.Provider, eventingProvider eventing.Provider) (*Team, error) {
-	members, err := loadTeamMembers(ctx, k8sClient, crd, telemetryProvider, eventingProvider)
+	// Nested teams are loaded with their parents, so a cycle of teams would never finish loading
+	loadCtx, err := enterDelegation(ctx, MemberTypeTeam, crd.Namespace+"/"+crd.Name)
+	if err != nil {
+		return nil, err
+	}
+	members, err := loadTeamMembers(loadCtx, k8sClient, crd, telemetryProvider, eventingProvider)
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +240,8 @@ func MakeTeam(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Tea
 		MaxTurns:          crd.Spec.MaxTurns,
 		Selector:          crd.Spec.Selector,
 		Graph:             crd.Spec.Graph,
+		Parallel:          crd.Spec.Parallel,
+		Termination:       crd.Spec.Termination,
 		telemetryRecorder: telemetryProvider.TeamRecorder(),
 		eventingRecorder:  eventingProvider.TeamRecorder(),
 		telemetry:         telemetryProvider,
@@ -225,7 +274,15 @@ func (t *Team) executeWithTracking(execFunc func(context.Context, Message, []Mes
 	teamctx, span := t.telemetryRecorder.StartTeamExecution(ctx, t.Name, t.Namespace, t.Strategy, len(t.Members), maxTurns)
 	defer span.End()
 
+	teamctx, err := enterDelegation(teamctx, MemberTypeTeam, t.FullName())
+	if err != nil {
+		t.telemetryRecorder.RecordError(span, err)
+		return nil, err
+	}
+	t.telemetryRecorder.RecordDelegationChain(span, delegationChain(teamctx))
+
 	teamctx = t.eventingRecorder.StartTokenCollection(teamctx)
+	t.usageCtx = teamctx
 	operationData := map[string]string{
 		"teamName":    t.Name,
 		"strategy":    t.Strategy,
@@ -233,7 +290,13 @@ func (t *Team) executeWithTracking(execFunc func(context.Context, Message, []Mes
 	}
 	teamctx = t.eventingRecorder.Start(teamctx, "TeamExecution", fmt.Sprintf("Executing team %s", t.FullName()), operationData)
 
-	result, err := execFunc(teamctx, userInput, history)
+	runCtx, cancel := t.withTimeout(teamctx)
+	defer cancel()
+	result, err := execFunc(runCtx, userInput, history)
+	if reason := t.timeoutReached(runCtx); err != nil && reason != "" {
+		t.stop(reason)
+		err = nil
+	}
 	if err != nil {
 		t.telemetryRecorder.RecordError(span, err)
 		t.eventingRecorder.Fail(teamctx, "TeamExecution", fmt.Sprintf("Team execution failed: %v", err), err, operationData)
@@ -276,6 +339,9 @@ func (t *Team) executeMemberAndAccumulate(ctx context.Context, member TeamMember
 			*messages = append(*messages, result.Messages...)
 			*newMessages = append(*newMessages, result.Messages...)
 		}
+		if IsTerminateTeam(err) {
+			t.stop(fmt.Sprintf("member %s called the terminate tool", member.GetName()))
+		}
 		t.eventingRecorder.Fail(ctx, "TeamMember", fmt.Sprintf("Team member execution failed: %v", err), err, operationData)
 		return err
 	}
@@ -283,6 +349,11 @@ func (t *Team) executeMemberAndAccumulate(ctx context.Context, member TeamMember
 	*messages = append(*messages, result.Messages...)
 	*newMessages = append(*newMessages, result.Messages...)
 	t.eventingRecorder.Complete(ctx, "TeamMember", "Team member execution completed successfully", operationData)
+
+	if reason := t.checkTermination(ctx, member, result.Messages); reason != "" {
+		t.stop(reason)
+		return &TerminateTeam{}
+	}
 	return nil
 }
 
@@ -3,6 +3,10 @@ package genai
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 )
 
 func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
@@ -10,24 +14,33 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 		return nil, fmt.Errorf("team %s has no members for graph execution", t.FullName())
 	}
 
-	messages := append([]Message{}, history...)
-	var newMessages []Message
+	routes, err := t.graphRoutes()
+	if err != nil {
+		return nil, err
+	}
+
+	messages, newMessages, completedTurns := t.resumeProgress(history)
 
 	memberMap := make(map[string]TeamMember)
 	for _, member := range t.Members {
 		memberMap[member.GetName()] = member
 	}
 
-	transitionMap := make(map[string]string)
-	if t.Graph != nil {
-		for _, edge := range t.Graph.Edges {
-			transitionMap[edge.From] = edge.To
+	// Continue with the member chosen by the last turn completed in an interrupted attempt.
+	// Checkpoints written before the next member was recorded only hold the messages, so the
+	// edges leaving the member that answered last are evaluated again.
+	currentMemberName := t.Members[0].GetName()
+	if completedTurns > 0 {
+		currentMemberName = t.checkpointer.Resume().NextMember
+		if currentMemberName == "" {
+			currentMemberName = routes.next(lastGraphMember(newMessages, memberMap), newMessages)
+		}
+		if currentMemberName == "" || t.maxTurnsReached(completedTurns) {
+			return newMessages, nil
 		}
 	}
 
-	currentMemberName := t.Members[0].GetName()
-
-	for turns := 0; ; turns++ {
+	for turns := completedTurns; ; turns++ {
 		member, exists := memberMap[currentMemberName]
 		if !exists {
 			return newMessages, fmt.Errorf("member %s not found in team %s", currentMemberName, t.FullName())
@@ -43,6 +56,7 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 		}
 		turnCtx = t.eventingRecorder.Start(turnCtx, "TeamTurn", fmt.Sprintf("Executing turn %d for team %s", turns, t.Name), operationData)
 
+		turnStart := len(newMessages)
 		err := t.executeMemberAndAccumulate(turnCtx, member, userInput, &messages, &newMessages, turns)
 
 		// Record turn output
@@ -60,21 +74,96 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 			return newMessages, err
 		}
 
+		nextMember := routes.next(currentMemberName, newMessages[turnStart:])
+		operationData["nextMember"] = nextMember
+
 		t.telemetryRecorder.RecordSuccess(turnSpan)
 		turnSpan.End()
 		t.eventingRecorder.Complete(turnCtx, "TeamTurn", fmt.Sprintf("Team turn %d completed successfully", turns), operationData)
-
-		nextMember := transitionMap[currentMemberName]
+		t.recordGraphProgress(ctx, newMessages, turns+1, nextMember)
 		if nextMember == "" {
 			break
 		}
 
 		currentMemberName = nextMember
 
-		if t.MaxTurns != nil && turns+1 >= *t.MaxTurns {
+		if t.maxTurnsReached(turns + 1) {
 			return newMessages, nil
 		}
 	}
 
 	return newMessages, nil
 }
+
+// graphRoute is a graph edge with its condition prepared for evaluation
+type graphRoute struct {
+	to        string
+	condition *answerCondition
+}
+
+// graphRoutes lists the edges leaving each member, in the order they are defined
+type graphRoutes map[string][]graphRoute
+
+func (t *Team) graphRoutes() (graphRoutes, error) {
+	routes := make(graphRoutes)
+	if t.Graph == nil {
+		return routes, nil
+	}
+	for i, edge := range t.Graph.Edges {
+		condition, err := compileGraphCondition(edge.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("graph edge %d of team %s: %w", i, t.FullName(), err)
+		}
+		routes[edge.From] = append(routes[edge.From], graphRoute{to: edge.To, condition: condition})
+	}
+	return routes, nil
+}
+
+// next returns the member that follows the given member, based on the messages of its turn, or
+// an empty string when no edge matches
+func (r graphRoutes) next(member string, turnMessages []Message) string {
+	answer := strings.TrimSpace(ExtractLastAssistantMessageContent(turnMessages))
+	for _, route := range r[member] {
+		if route.condition == nil || route.condition.matches(answer) {
+			return route.to
+		}
+	}
+	return ""
+}
+
+// lastGraphMember returns the member that wrote the last named assistant message, or an empty
+// string when no message names a member
+func lastGraphMember(messages []Message, members map[string]TeamMember) string {
+	for _, msg := range slices.Backward(messages) {
+		if m := msg.OfAssistant; m != nil {
+			if _, exists := members[m.Name.Value]; exists {
+				return m.Name.Value
+			}
+		}
+	}
+	return ""
+}
+
+// ValidateGraphCondition checks that a graph edge condition can be evaluated
+func ValidateGraphCondition(condition *arkv1alpha1.TeamGraphEdgeCondition) error {
+	_, err := compileGraphCondition(condition)
+	return err
+}
+
+func compileGraphCondition(condition *arkv1alpha1.TeamGraphEdgeCondition) (*answerCondition, error) {
+	if condition == nil {
+		return nil, nil
+	}
+	if (condition.Regex == "") == (condition.JSONPath == "") {
+		return nil, fmt.Errorf("condition must set exactly one of regex and jsonPath")
+	}
+	return compileAnswerCondition("", condition.Regex, condition.JSONPath, condition.Value)
+}
+
+// recordGraphProgress checkpoints the completed turns together with the member chosen to run next
+func (t *Team) recordGraphProgress(ctx context.Context, newMessages []Message, completedTurns int, nextMember string) {
+	if t.checkpointer == nil {
+		return
+	}
+	t.checkpointer.Checkpoint(ctx, ExecutionProgress{Messages: newMessages, Turns: completedTurns, NextMember: nextMember})
+}
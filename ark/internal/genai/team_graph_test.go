@@ -0,0 +1,130 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openai/openai-go/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func newReviewTestTeam(reviews ...string) (*Team, *mockTeamMember, *mockTeamMember, *mockTeamMember) {
+	writer := &mockTeamMember{name: "writer", replies: []string{"draft"}}
+	reviewer := &mockTeamMember{name: "reviewer", replies: reviews}
+	publisher := &mockTeamMember{name: "publisher", replies: []string{"published"}}
+
+	team := newTestTeam("graph", writer, reviewer, publisher)
+	team.Graph = &arkv1alpha1.TeamGraphSpec{
+		Edges: []arkv1alpha1.TeamGraphEdge{
+			{From: "writer", To: "reviewer"},
+			{From: "reviewer", To: "publisher", Condition: &arkv1alpha1.TeamGraphEdgeCondition{JSONPath: ".decision", Value: "approve"}},
+			{From: "reviewer", To: "writer"},
+		},
+	}
+	maxTurns := 10
+	team.MaxTurns = &maxTurns
+	return team, writer, reviewer, publisher
+}
+
+func TestTeamGraphFollowsConditionalEdges(t *testing.T) {
+	team, writer, reviewer, publisher := newReviewTestTeam(`{"decision":"reject"}`, `{"decision":"approve"}`)
+
+	result, err := team.Execute(context.Background(), NewUserMessage("write a post"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, writer.calls)
+	assert.Equal(t, 2, reviewer.calls)
+	assert.Equal(t, 1, publisher.calls)
+	require.Len(t, result.Messages, 5)
+	assert.Equal(t, "published", result.Messages[4].OfAssistant.Content.OfString.Value)
+}
+
+func TestTeamGraphCyclesUntilMaxTurns(t *testing.T) {
+	team, writer, reviewer, publisher := newReviewTestTeam(`{"decision":"reject"}`)
+	maxTurns := 5
+	team.MaxTurns = &maxTurns
+
+	result, err := team.Execute(context.Background(), NewUserMessage("write a post"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Len(t, result.Messages, 5)
+	assert.Equal(t, 3, writer.calls)
+	assert.Equal(t, 2, reviewer.calls)
+	assert.Equal(t, 0, publisher.calls)
+}
+
+func TestTeamGraphResumesWithCheckpointedNextMember(t *testing.T) {
+	team, writer, reviewer, publisher := newReviewTestTeam(`{"decision":"approve"}`)
+
+	checkpointer := &recordingCheckpointer{
+		resume: ExecutionProgress{
+			Messages:   []Message{NewAssistantMessage("draft"), NewAssistantMessage(`{"decision":"approve"}`)},
+			Turns:      2,
+			NextMember: "publisher",
+		},
+	}
+	ctx := WithCheckpointer(context.Background(), checkpointer)
+
+	result, err := team.Execute(ctx, NewUserMessage("write a post"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, writer.calls)
+	assert.Equal(t, 0, reviewer.calls)
+	assert.Equal(t, 1, publisher.calls)
+	require.Len(t, result.Messages, 3)
+	require.Len(t, checkpointer.checkpoints, 1)
+	assert.Equal(t, 3, checkpointer.checkpoints[0].Turns)
+	assert.Empty(t, checkpointer.checkpoints[0].NextMember)
+}
+
+func TestTeamGraphResumesCheckpointsWithoutNextMember(t *testing.T) {
+	team, writer, reviewer, publisher := newReviewTestTeam(`{"decision":"approve"}`)
+
+	draft := NewAssistantMessage("draft")
+	draft.OfAssistant.Name = param.NewOpt("writer")
+	review := NewAssistantMessage(`{"decision":"approve"}`)
+	review.OfAssistant.Name = param.NewOpt("reviewer")
+	checkpointer := &recordingCheckpointer{resume: ExecutionProgress{Messages: []Message{draft, review}, Turns: 2}}
+	ctx := WithCheckpointer(context.Background(), checkpointer)
+
+	result, err := team.Execute(ctx, NewUserMessage("write a post"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, writer.calls)
+	assert.Equal(t, 0, reviewer.calls)
+	assert.Equal(t, 1, publisher.calls)
+	require.Len(t, result.Messages, 3)
+}
+
+func TestGraphConditionMatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition arkv1alpha1.TeamGraphEdgeCondition
+		answer    string
+		want      bool
+	}{
+		{name: "regex match", condition: arkv1alpha1.TeamGraphEdgeCondition{Regex: `(?i)\bapproved\b`}, answer: "Looks good. APPROVED", want: true},
+		{name: "regex mismatch", condition: arkv1alpha1.TeamGraphEdgeCondition{Regex: `(?i)\bapproved\b`}, answer: "Unapproved, please revise", want: false},
+		{name: "regex anchored mismatch", condition: arkv1alpha1.TeamGraphEdgeCondition{Regex: `^APPROVED`}, answer: "REJECTED", want: false},
+		{name: "jsonpath value", condition: arkv1alpha1.TeamGraphEdgeCondition{JSONPath: ".review.decision", Value: "approve"}, answer: `{"review":{"decision":"approve"}}`, want: true},
+		{name: "jsonpath other value", condition: arkv1alpha1.TeamGraphEdgeCondition{JSONPath: "{.review.decision}", Value: "approve"}, answer: `{"review":{"decision":"reject"}}`, want: false},
+		{name: "jsonpath true", condition: arkv1alpha1.TeamGraphEdgeCondition{JSONPath: ".done"}, answer: `{"done":true}`, want: true},
+		{name: "jsonpath false", condition: arkv1alpha1.TeamGraphEdgeCondition{JSONPath: ".done"}, answer: `{"done":false}`, want: false},
+		{name: "jsonpath null", condition: arkv1alpha1.TeamGraphEdgeCondition{JSONPath: ".done"}, answer: `{"done":null}`, want: false},
+		{name: "jsonpath missing", condition: arkv1alpha1.TeamGraphEdgeCondition{JSONPath: ".done"}, answer: `{}`, want: false},
+		{name: "jsonpath not json", condition: arkv1alpha1.TeamGraphEdgeCondition{JSONPath: ".done"}, answer: "done", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := compileGraphCondition(&tt.condition)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, condition.matches(tt.answer))
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package genai
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const defaultAggregatorPrompt = `Several team members worked on the user's request independently. Their answers were:
+
+{{.Outputs}}
+
+Combine their answers into a single final answer to the request. Resolve disagreements and do not mention the individual members.`
+
+type AggregatorTemplateData struct {
+	Outputs string
+}
+
+// parallelBranch is the outcome of one member of a parallel team
+type parallelBranch struct {
+	member   TeamMember
+	messages []Message
+	err      error
+}
+
+// failed reports whether the branch ended with an error other than a request to end the team
+func (b parallelBranch) failed() bool {
+	return b.err != nil && !IsTerminateTeam(b.err)
+}
+
+// branchOutput is what the aggregator is told about a branch: its answer, or why it has none
+type branchOutput struct {
+	member string
+	answer string
+	err    error
+}
+
+// executeParallel gives the input to every selected member at once. Members only see the
+// conversation so far, not each other's work; their outputs are then merged by the aggregator,
+// if any. The team only fails when every branch failed: otherwise the messages of the failed
+// branches are dropped and the aggregator is told which members have no answer. Branches
+// completed by an interrupted attempt are not run again.
+func (t *Team) executeParallel(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
+	members, err := t.parallelMembers()
+	if err != nil {
+		return nil, err
+	}
+
+	_, newMessages, completedTurns := t.resumeProgress(history)
+	outputs := resumedBranchOutputs(newMessages)
+	if completedTurns < len(members) {
+		branches := t.executeParallelBranches(ctx, members, userInput, history)
+		newMessages, outputs = nil, nil
+		var errs []error
+		for _, branch := range branches {
+			if branch.failed() {
+				errs = append(errs, fmt.Errorf("member %s failed in team %s: %w", branch.member.GetName(), t.FullName(), branch.err))
+				outputs = append(outputs, branchOutput{member: branch.member.GetName(), err: branch.err})
+				continue
+			}
+			newMessages = append(newMessages, branch.messages...)
+			outputs = append(outputs, branchOutput{member: branch.member.GetName(), answer: finalAnswer(branch.messages)})
+		}
+		if len(errs) == len(branches) {
+			return newMessages, errors.Join(errs...)
+		}
+		if len(errs) > 0 {
+			logf.FromContext(ctx).Info("parallel team continues without failed members", "team", t.FullName(), "errors", errors.Join(errs...).Error())
+		}
+		t.recordProgress(ctx, newMessages, len(members))
+	}
+
+	if t.Parallel == nil || t.Parallel.Aggregator == "" || t.stopped() != "" {
+		return newMessages, nil
+	}
+	return t.executeAggregator(ctx, userInput, history, newMessages, outputs, len(members))
+}
+
+func (t *Team) executeParallelBranches(ctx context.Context, members []TeamMember, userInput Message, history []Message) []parallelBranch {
+	branches := make([]parallelBranch, len(members))
+	var wg sync.WaitGroup
+	for i, member := range members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			messages, err := t.executeParallelBranch(ctx, i, member, userInput, history)
+			branches[i] = parallelBranch{member: member, messages: messages, err: err}
+		}()
+	}
+	wg.Wait()
+	return branches
+}
+
+// executeParallelBranch runs one member as its own turn, with its own copy of the history and
+// its own token count, which is added to the team's once the branch is done
+func (t *Team) executeParallelBranch(ctx context.Context, turn int, member TeamMember, userInput Message, history []Message) ([]Message, error) {
+	turnCtx, turnSpan := t.telemetryRecorder.StartTurn(ctx, turn, member.GetName(), member.GetType())
+	defer turnSpan.End()
+
+	operationData := map[string]string{
+		"teamName": t.Name,
+		"strategy": t.Strategy,
+		"turn":     fmt.Sprintf("%d", turn),
+	}
+	turnCtx = t.eventingRecorder.Start(turnCtx, "TeamTurn", fmt.Sprintf("Executing turn %d for team %s", turn, t.Name), operationData)
+	branchCtx := t.eventingRecorder.StartTokenCollection(turnCtx)
+	t.startBranchUsage(branchCtx, turn)
+
+	messages := slices.Clone(history)
+	var newMessages []Message
+	err := t.executeMemberAndAccumulate(branchCtx, member, userInput, &messages, &newMessages, turn)
+
+	usage := t.eventingRecorder.GetTokenSummary(branchCtx)
+	t.finishBranchUsage(turnCtx, turn, usage)
+	t.telemetryRecorder.RecordTokenUsage(turnSpan, usage.PromptTokens, usage.CompletionTokens, usage.TotalTokens)
+	operationData["totalTokens"] = fmt.Sprintf("%d", usage.TotalTokens)
+
+	if len(newMessages) > 0 {
+		t.telemetryRecorder.RecordTurnOutput(turnSpan, newMessages, len(newMessages))
+	}
+	if err != nil {
+		t.telemetryRecorder.RecordError(turnSpan, err)
+		t.eventingRecorder.Fail(turnCtx, "TeamTurn", fmt.Sprintf("Team turn failed: %v", err), err, operationData)
+		return newMessages, err
+	}
+
+	t.telemetryRecorder.RecordSuccess(turnSpan)
+	t.eventingRecorder.Complete(turnCtx, "TeamTurn", fmt.Sprintf("Team turn %d completed successfully", turn), operationData)
+	return newMessages, nil
+}
+
+// executeAggregator merges the outputs of the branches in a final turn
+func (t *Team) executeAggregator(ctx context.Context, userInput Message, history, branchMessages []Message, outputs []branchOutput, turn int) ([]Message, error) {
+	aggregator, err := t.loadAggregatorAgent(ctx)
+	if err != nil {
+		return branchMessages, err
+	}
+
+	promptTemplate := defaultAggregatorPrompt
+	if t.Parallel.AggregatorPrompt != "" {
+		promptTemplate = t.Parallel.AggregatorPrompt
+	}
+	tmpl, err := template.New("aggregator").Parse(promptTemplate)
+	if err != nil {
+		return branchMessages, fmt.Errorf("failed to parse aggregator prompt for team %s: %w", t.FullName(), err)
+	}
+	var prompt bytes.Buffer
+	if err := tmpl.Execute(&prompt, AggregatorTemplateData{Outputs: buildBranchOutputs(outputs)}); err != nil {
+		return branchMessages, fmt.Errorf("failed to render aggregator prompt for team %s: %w", t.FullName(), err)
+	}
+
+	turnCtx, turnSpan := t.telemetryRecorder.StartTurn(ctx, turn, aggregator.GetName(), aggregator.GetType())
+	defer turnSpan.End()
+
+	operationData := map[string]string{
+		"teamName": t.Name,
+		"strategy": t.Strategy,
+		"turn":     fmt.Sprintf("%d", turn),
+	}
+	turnCtx = t.eventingRecorder.Start(turnCtx, "TeamTurn", fmt.Sprintf("Executing aggregation turn for team %s", t.Name), operationData)
+
+	messages := append(slices.Clone(history), NewSystemMessage(prompt.String()))
+	newMessages := slices.Clone(branchMessages)
+	err = t.executeMemberAndAccumulate(turnCtx, aggregator, userInput, &messages, &newMessages, turn)
+	if err != nil && !IsTerminateTeam(err) {
+		t.telemetryRecorder.RecordError(turnSpan, err)
+		t.eventingRecorder.Fail(turnCtx, "TeamTurn", fmt.Sprintf("Team turn failed: %v", err), err, operationData)
+		return newMessages, fmt.Errorf("aggregator %s failed in team %s: %w", aggregator.GetName(), t.FullName(), err)
+	}
+
+	t.telemetryRecorder.RecordTurnOutput(turnSpan, newMessages[len(branchMessages):], len(newMessages)-len(branchMessages))
+	t.telemetryRecorder.RecordSuccess(turnSpan)
+	t.eventingRecorder.Complete(turnCtx, "TeamTurn", "Team aggregation turn completed successfully", operationData)
+	return newMessages, nil
+}
+
+// parallelMembers returns the members selected by spec.parallel.members, in team order
+func (t *Team) parallelMembers() ([]TeamMember, error) {
+	if t.Parallel == nil || len(t.Parallel.Members) == 0 {
+		return t.Members, nil
+	}
+
+	members := make([]TeamMember, 0, len(t.Parallel.Members))
+	for _, member := range t.Members {
+		if slices.Contains(t.Parallel.Members, member.GetName()) {
+			members = append(members, member)
+		}
+	}
+	if len(members) != len(t.Parallel.Members) {
+		return nil, fmt.Errorf("parallel members of team %s must all be team members", t.FullName())
+	}
+	return members, nil
+}
+
+func (t *Team) loadAggregatorAgent(ctx context.Context) (*Agent, error) {
+	var agentCRD arkv1alpha1.Agent
+	key := types.NamespacedName{Name: t.Parallel.Aggregator, Namespace: t.Namespace}
+	if err := t.Client.Get(ctx, key, &agentCRD); err != nil {
+		return nil, fmt.Errorf("failed to get aggregator agent %s in namespace %s: %w", t.Parallel.Aggregator, t.Namespace, err)
+	}
+
+	agent, err := MakeAgent(ctx, t.Client, &agentCRD, t.telemetry, t.eventing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aggregator agent: %w", err)
+	}
+	return agent, nil
+}
+
+// buildBranchOutputs lists the answer of each member, and the members that failed
+func buildBranchOutputs(outputs []branchOutput) string {
+	var sections []string
+	for _, output := range outputs {
+		switch {
+		case output.err != nil:
+			sections = append(sections, fmt.Sprintf("# %s:\nNo answer, the member failed: %v\n", output.member, output.err))
+		case output.answer != "":
+			sections = append(sections, fmt.Sprintf("# %s:\n%s\n", output.member, output.answer))
+		}
+	}
+	return strings.Join(sections, "\n")
+}
+
+// finalAnswer returns the content of the last assistant message of a branch. Earlier assistant
+// messages carry the member's reasoning between tool calls rather than its answer.
+func finalAnswer(messages []Message) string {
+	for _, msg := range slices.Backward(messages) {
+		if m := msg.OfAssistant; m != nil && m.Content.OfString.Value != "" {
+			return m.Content.OfString.Value
+		}
+	}
+	return ""
+}
+
+// resumedBranchOutputs recovers the answers of branches checkpointed by an interrupted attempt,
+// which only kept their messages: the last assistant message of each member is its answer.
+// Failed branches are not checkpointed, so they are not listed.
+func resumedBranchOutputs(messages []Message) []branchOutput {
+	var outputs []branchOutput
+	index := make(map[string]int)
+	for _, msg := range messages {
+		m := msg.OfAssistant
+		if m == nil || m.Content.OfString.Value == "" {
+			continue
+		}
+		i, exists := index[m.Name.Value]
+		if !exists {
+			i = len(outputs)
+			index[m.Name.Value] = i
+			outputs = append(outputs, branchOutput{member: m.Name.Value})
+		}
+		outputs[i].answer = m.Content.OfString.Value
+	}
+	return outputs
+}
@@ -0,0 +1,142 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// parallelTestMember waits until every member has started, so a team that does not run its
+// members concurrently fails
+type parallelTestMember struct {
+	mockTeamMember
+	started *sync.WaitGroup
+}
+
+func (m *parallelTestMember) Execute(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) (*ExecutionResult, error) {
+	m.started.Done()
+	allStarted := make(chan struct{})
+	go func() {
+		m.started.Wait()
+		close(allStarted)
+	}()
+	select {
+	case <-allStarted:
+	case <-time.After(5 * time.Second):
+		return nil, errors.New("members were not executed concurrently")
+	}
+	return m.mockTeamMember.Execute(ctx, userInput, history, memory, eventStream)
+}
+
+// newParallelTestTeam creates a parallel team whose members wait for the given number of branches
+func newParallelTestTeam(branches int, names ...string) (*Team, map[string]*parallelTestMember) {
+	team := newTestTeam("parallel")
+	started := &sync.WaitGroup{}
+	started.Add(branches)
+	members := make(map[string]*parallelTestMember, len(names))
+	for _, name := range names {
+		member := &parallelTestMember{
+			mockTeamMember: mockTeamMember{
+				name:    name,
+				replies: []string{"answer from " + name},
+				usage:   arkv1alpha1.TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+			},
+			started: started,
+		}
+		members[name] = member
+		team.Members = append(team.Members, member)
+	}
+	return team, members
+}
+
+func TestTeamParallelRunsMembersConcurrently(t *testing.T) {
+	team, members := newParallelTestTeam(3, "researcher", "analyst", "writer")
+
+	history := []Message{NewUserMessage("earlier question"), NewAssistantMessage("earlier answer")}
+	ctx := team.eventingRecorder.StartTokenCollection(context.Background())
+	result, err := team.Execute(ctx, NewUserMessage("go"), history, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	require.Len(t, result.Messages, 3)
+	for i, name := range []string{"researcher", "analyst", "writer"} {
+		assert.Equal(t, "answer from "+name, result.Messages[i].OfAssistant.Content.OfString.Value)
+		assert.Len(t, members[name].history, 2, "members must not see each other's answers")
+	}
+	assert.Equal(t, arkv1alpha1.TokenUsage{PromptTokens: 30, CompletionTokens: 15, TotalTokens: 45}, team.eventingRecorder.GetTokenSummary(ctx))
+}
+
+func TestTeamParallelRunsSelectedMembers(t *testing.T) {
+	team, members := newParallelTestTeam(2, "researcher", "analyst", "writer")
+	team.Parallel = &arkv1alpha1.TeamParallelSpec{Members: []string{"writer", "researcher"}}
+
+	result, err := team.Execute(context.Background(), NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	require.Len(t, result.Messages, 2)
+	assert.Equal(t, "answer from researcher", result.Messages[0].OfAssistant.Content.OfString.Value)
+	assert.Equal(t, "answer from writer", result.Messages[1].OfAssistant.Content.OfString.Value)
+	assert.Nil(t, members["analyst"].history)
+
+	team.Parallel.Members = []string{"editor"}
+	_, err = team.Execute(context.Background(), NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	assert.ErrorContains(t, err, "must all be team members")
+}
+
+func TestTeamParallelContinuesWithoutFailedMembers(t *testing.T) {
+	team, members := newParallelTestTeam(3, "researcher", "analyst", "writer")
+	members["researcher"].err = errors.New("search unavailable")
+	members["writer"].err = errors.New("model overloaded")
+
+	checkpointer := &recordingCheckpointer{}
+	ctx := WithCheckpointer(context.Background(), checkpointer)
+	result, err := team.Execute(ctx, NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	require.Len(t, result.Messages, 1)
+	assert.Equal(t, "answer from analyst", result.Messages[0].OfAssistant.Content.OfString.Value)
+	require.Len(t, checkpointer.checkpoints, 1)
+	assert.Len(t, checkpointer.checkpoints[0].Messages, 1, "only the successful branches are checkpointed")
+}
+
+func TestTeamParallelReportsEveryFailedMember(t *testing.T) {
+	team, members := newParallelTestTeam(2, "researcher", "writer")
+	members["researcher"].err = errors.New("search unavailable")
+	members["writer"].err = errors.New("model overloaded")
+
+	checkpointer := &recordingCheckpointer{}
+	ctx := WithCheckpointer(context.Background(), checkpointer)
+	_, err := team.Execute(ctx, NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.Error(t, err)
+
+	assert.ErrorContains(t, err, "member researcher failed in team default/test-team: search unavailable")
+	assert.ErrorContains(t, err, "member writer failed in team default/test-team: model overloaded")
+	assert.Empty(t, checkpointer.checkpoints, "failed branches must not be checkpointed")
+}
+
+func TestBuildBranchOutputs(t *testing.T) {
+	reasoning := NewAssistantMessage("Let me look up the forecast")
+	reasoning.OfAssistant.Name = param.NewOpt("researcher")
+	researcher := NewAssistantMessage("Paris is sunny")
+	researcher.OfAssistant.Name = param.NewOpt("researcher")
+
+	outputs := buildBranchOutputs([]branchOutput{
+		{member: "researcher", answer: finalAnswer([]Message{reasoning, ToolMessage("ignored", "call-1"), researcher})},
+		{member: "analyst", err: errors.New("model overloaded")},
+	})
+	assert.Equal(t, "# researcher:\nParis is sunny\n\n# analyst:\nNo answer, the member failed: model overloaded\n", outputs)
+
+	analyst := NewAssistantMessage("Expect rain later")
+	analyst.OfAssistant.Name = param.NewOpt("analyst")
+	resumed := resumedBranchOutputs([]Message{reasoning, ToolMessage("ignored", "call-1"), researcher, analyst})
+	assert.Equal(t, []branchOutput{{member: "researcher", answer: "Paris is sunny"}, {member: "analyst", answer: "Expect rain later"}}, resumed)
+}
@@ -3,10 +3,13 @@ package genai
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"strings"
 	"text/template"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
@@ -20,6 +23,13 @@ Read the following conversation. Then select the next role from {{.Participants}
 
 Read the above conversation. Then select the next role from {{.Participants}} to play. Only return the role.`
 
+const (
+	// defaultSelectorMaxRetries is how often the selector is asked again after an invalid choice
+	defaultSelectorMaxRetries = 2
+	// selectorTerminateChoice is the choice that ends the team, when the selector may make it
+	selectorTerminateChoice = "TERMINATE"
+)
+
 type SelectorTemplateData struct {
 	Roles        string
 	Participants string
@@ -80,13 +90,11 @@ func (t *Team) loadSelectorAgent(ctx context.Context) (*Agent, error) {
 	return agent, nil
 }
 
-//nolint:gocognit // Complex function handling selector agent logic, but cohesive responsibilities
-func (t *Team) selectMember(ctx context.Context, messages []Message, tmpl *template.Template, participantsList, rolesList, previousMember string, candidateMembers []TeamMember) (TeamMember, error) {
-	history := buildHistory(messages)
+func (t *Team) selectMember(ctx context.Context, messages []Message, tmpl *template.Template, previousMember string, candidates []TeamMember) (TeamMember, error) {
 	data := SelectorTemplateData{
-		Roles:        rolesList,
-		Participants: participantsList,
-		History:      history,
+		Roles:        buildRoles(candidates),
+		Participants: buildParticipants(candidates),
+		History:      buildHistory(messages),
 	}
 
 	var buf bytes.Buffer
@@ -98,52 +106,135 @@ func (t *Team) selectMember(ctx context.Context, messages []Message, tmpl *templ
 	if err != nil {
 		return nil, err
 	}
+	// The selector answers with one of the candidates instead of free text. Agents that cannot
+	// produce structured output still answer with text, which is matched leniently.
+	selectorAgent.OutputSchema = selectionSchema(candidates, t.Selector.AllowTerminate)
 
-	result, err := selectorAgent.Execute(ctx, NewUserMessage("Select the next participant to respond."), []Message{NewSystemMessage(buf.String())}, nil, nil)
-	if err != nil {
-		if IsTerminateTeam(err) {
-			return nil, err
+	return t.runSelection(ctx, selectorAgent, buf.String(), previousMember, candidates)
+}
+
+// runSelection asks the selector for the next member, asking again when it chooses a member
+// that is not a candidate. Once the retries are used up the first candidate that did not
+// speak last is chosen.
+func (t *Team) runSelection(ctx context.Context, selector TeamMember, prompt, previousMember string, candidates []TeamMember) (TeamMember, error) {
+	selectionCtx, span := t.telemetryRecorder.StartSelection(ctx, buildParticipants(candidates))
+	defer span.End()
+
+	maxRetries := defaultSelectorMaxRetries
+	if t.Selector != nil && t.Selector.MaxRetries != nil {
+		maxRetries = *t.Selector.MaxRetries
+	}
+
+	history := []Message{NewSystemMessage(prompt)}
+	request := NewUserMessage("Select the next participant to respond.")
+	for attempt := 1; attempt <= maxRetries+1; attempt++ {
+		result, err := selector.Execute(selectionCtx, request, history, nil, nil)
+		if err != nil {
+			if IsTerminateTeam(err) {
+				t.telemetryRecorder.RecordSelection(span, selectorTerminateChoice, "", attempt)
+				return nil, err
+			}
+			t.telemetryRecorder.RecordError(span, err)
+			return nil, fmt.Errorf("selector agent call failed: %w", err)
+		}
+
+		response := ExtractLastAssistantMessageContent(result.Messages)
+		selection := parseSelection(response)
+		if t.Selector != nil && t.Selector.AllowTerminate && strings.EqualFold(normalizeSelection(selection.Next), selectorTerminateChoice) {
+			t.telemetryRecorder.RecordSelection(span, selectorTerminateChoice, selection.Reasoning, attempt)
+			t.stop("the selector ended the team")
+			return nil, &TerminateTeam{}
 		}
-		return nil, fmt.Errorf("selector agent call failed: %w", err)
+		if member := matchCandidate(selection.Next, candidates); member != nil {
+			t.telemetryRecorder.RecordSelection(span, member.GetName(), selection.Reasoning, attempt)
+			return member, nil
+		}
+
+		t.telemetryRecorder.RecordInvalidSelection(span, response, attempt)
+		history = append(history, request, NewAssistantMessage(response))
+		request = NewUserMessage(fmt.Sprintf("%q is not a valid choice. Select exactly one of: %s.", selection.Next, buildParticipants(candidates)))
+	}
+
+	fallback := candidates[0]
+	if fallback.GetName() == previousMember && len(candidates) > 1 {
+		fallback = candidates[1]
 	}
+	t.telemetryRecorder.RecordSelection(span, fallback.GetName(), "selector made no valid choice, falling back", maxRetries+1)
+	return fallback, nil
+}
 
-	if len(result.Messages) == 0 {
-		return nil, fmt.Errorf("selector agent returned no messages")
+// memberSelection is the structured answer of the selector
+type memberSelection struct {
+	Reasoning string `json:"reasoning"`
+	Next      string `json:"next"`
+}
+
+// selectionSchema is the output schema of the selector, which enumerates the candidates
+func selectionSchema(candidates []TeamMember, allowTerminate bool) *runtime.RawExtension {
+	choices := make([]string, 0, len(candidates)+1)
+	for _, member := range candidates {
+		choices = append(choices, member.GetName())
+	}
+	if allowTerminate {
+		choices = append(choices, selectorTerminateChoice)
 	}
 
-	var selectedName string
-	lastMsg := result.Messages[len(result.Messages)-1]
-	if lastMsg.OfAssistant != nil && lastMsg.OfAssistant.Content.OfString.Value != "" {
-		selectedName = strings.TrimSpace(lastMsg.OfAssistant.Content.OfString.Value)
-	} else {
-		return nil, fmt.Errorf("selector agent returned invalid response")
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"reasoning": map[string]any{
+				"type":        "string",
+				"description": "Why this participant should respond next",
+			},
+			"next": map[string]any{
+				"type":        "string",
+				"description": "The participant that responds next",
+				"enum":        choices,
+			},
+		},
+		"required":             []string{"reasoning", "next"},
+		"additionalProperties": false,
 	}
+	raw, _ := json.Marshal(schema)
+	return &runtime.RawExtension{Raw: raw}
+}
 
-	// Use candidateMembers if provided, otherwise use all team members
-	membersToSearch := t.Members
-	if candidateMembers != nil {
-		membersToSearch = candidateMembers
+// parseSelection reads the structured answer of the selector, treating any other answer as the
+// name of the member
+func parseSelection(response string) memberSelection {
+	var selection memberSelection
+	if err := json.Unmarshal([]byte(response), &selection); err == nil && selection.Next != "" {
+		return selection
 	}
+	return memberSelection{Next: response}
+}
 
-	// Find selected member
-	for _, member := range membersToSearch {
-		if member.GetName() == selectedName {
-			return member, nil
+// matchCandidate finds the chosen candidate. Case, surrounding punctuation and explanations are
+// tolerated as long as exactly one candidate is named.
+func matchCandidate(choice string, candidates []TeamMember) TeamMember {
+	normalized := normalizeSelection(choice)
+	for _, member := range candidates {
+		if strings.EqualFold(member.GetName(), normalized) {
+			return member
 		}
 	}
 
-	// Fallback to first member if not found
-	if len(membersToSearch) > 0 {
-		fallback := membersToSearch[0]
-
-		// Avoid repeating same member
-		if fallback.GetName() == previousMember && len(membersToSearch) > 1 {
-			fallback = membersToSearch[1]
+	var named TeamMember
+	lowered := strings.ToLower(choice)
+	for _, member := range candidates {
+		pattern := `(^|[^a-z0-9-])` + regexp.QuoteMeta(strings.ToLower(member.GetName())) + `([^a-z0-9-]|$)`
+		if regexp.MustCompile(pattern).MatchString(lowered) {
+			if named != nil {
+				return nil
+			}
+			named = member
 		}
-		return fallback, nil
 	}
+	return named
+}
 
-	return nil, fmt.Errorf("no members available")
+func normalizeSelection(choice string) string {
+	return strings.Trim(strings.TrimSpace(choice), " \t\n.,:;!?*_`'\"")
 }
 
 // determineNextMember routes to the appropriate selection logic based on whether graph constraints exist.
@@ -154,9 +245,7 @@ func (t *Team) determineNextMember(ctx context.Context, messages []Message, tmpl
 		return t.Members[0], nil
 	case len(legalTransitions) == 0:
 		// No graph constraints: use standard selector (all members available)
-		participantsList := buildParticipants(t.Members)
-		rolesList := buildRoles(t.Members)
-		return t.selectMember(ctx, messages, tmpl, participantsList, rolesList, previousMember, nil)
+		return t.selectMember(ctx, messages, tmpl, previousMember, t.Members)
 	default:
 		// Graph constraints provided: use legal transitions
 		return t.selectFromGraphConstraints(ctx, messages, tmpl, previousMember, legalTransitions)
@@ -191,16 +280,13 @@ func (t *Team) selectFromGraphConstraints(ctx context.Context, messages []Messag
 		return selectedMember, nil
 	default:
 		// Multiple legal transitions - use selector agent to choose from candidates
-		participantsList := buildParticipants(legal)
-		rolesList := buildRoles(legal)
-		return t.selectMember(ctx, messages, tmpl, participantsList, rolesList, previousMember, legal)
+		return t.selectMember(ctx, messages, tmpl, previousMember, legal)
 	}
 }
 
 //nolint:gocognit // Complex function orchestrating selector logic with graph constraints, but cohesive responsibilities
 func (t *Team) executeSelector(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
-	messages := append([]Message{}, history...)
-	var newMessages []Message
+	messages, newMessages, completedTurns := t.resumeProgress(history)
 
 	promptTemplate := defaultSelectorPrompt
 	if t.Selector != nil && t.Selector.SelectorPrompt != "" {
@@ -230,9 +316,13 @@ func (t *Team) executeSelector(ctx context.Context, userInput Message, history [
 		}
 	}
 
-	previousMember := ""
+	if t.maxTurnsReached(completedTurns) {
+		return newMessages, nil
+	}
+
+	previousMember := lastSpeaker(newMessages)
 
-	for turn := 0; ; turn++ {
+	for turn := completedTurns; ; turn++ {
 		// Determine next member based on graph constraints (if any)
 		nextMember, err := t.determineNextMember(ctx, messages, tmpl, previousMember, legalTransitions)
 		if err != nil {
@@ -272,11 +362,22 @@ func (t *Team) executeSelector(ctx context.Context, userInput Message, history [
 		t.telemetryRecorder.RecordSuccess(turnSpan)
 		turnSpan.End()
 		t.eventingRecorder.Complete(turnCtx, "TeamTurn", fmt.Sprintf("Team turn %d completed successfully", turn), operationData)
+		t.recordProgress(ctx, newMessages, turn+1)
 
 		previousMember = nextMember.GetName()
 
-		if t.MaxTurns != nil && turn+1 >= *t.MaxTurns {
+		if t.maxTurnsReached(turn + 1) {
 			return newMessages, nil
 		}
 	}
 }
+
+// lastSpeaker returns the name of the member that produced the last assistant message
+func lastSpeaker(messages []Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if assistant := messages[i].OfAssistant; assistant != nil {
+			return assistant.Name.Value
+		}
+	}
+	return ""
+}
@@ -4,13 +4,19 @@ package genai
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 	"text/template"
 
+	"github.com/openai/openai-go/packages/param"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/eventing/recorder/tokens"
+	telemetrymock "mckinsey.com/ark/internal/telemetry/mock"
+	"mckinsey.com/ark/internal/telemetry/noop"
 )
 
 func TestBuildLegalTransitions(t *testing.T) {
@@ -161,11 +167,22 @@ func TestFilterMembersByLegalTransitions(t *testing.T) {
 	}
 }
 
-// mockTeamMember implements TeamMember interface for testing
+// mockTeamMember implements TeamMember interface for testing. It answers with its replies in
+// turn, or fails with err, and reports usage for every execution. A blocking member answers
+// once and then waits until its context is done. The context, input and history of its last
+// execution are recorded.
 type mockTeamMember struct {
 	name        string
 	description string
 	memberType  string
+	replies     []string
+	usage       arkv1alpha1.TokenUsage
+	err         error
+	block       bool
+	calls       int
+	ctx         context.Context
+	input       Message
+	history     []Message
 }
 
 func (m *mockTeamMember) GetName() string {
@@ -187,7 +204,43 @@ func (m *mockTeamMember) GetType() string {
 }
 
 func (m *mockTeamMember) Execute(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) (*ExecutionResult, error) {
-	return &ExecutionResult{}, nil
+	m.ctx = ctx
+	m.input = userInput
+	m.history = history
+	if m.block && m.calls > 0 {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	m.calls++
+	if m.usage.TotalTokens > 0 {
+		tokenCollector := tokens.NewTokenCollector()
+		tokenCollector.AddTokenUsage(ctx, m.usage)
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	if len(m.replies) == 0 {
+		return &ExecutionResult{}, nil
+	}
+	msg := NewAssistantMessage(m.replies[(m.calls-1)%len(m.replies)])
+	msg.OfAssistant.Name = param.NewOpt(m.name)
+	return &ExecutionResult{Messages: []Message{msg}}, nil
+}
+
+// newTestTeam creates a team with the given members and noop telemetry and eventing
+func newTestTeam(strategy string, members ...TeamMember) *Team {
+	telemetryProvider := noop.NewProvider()
+	eventingProvider := eventnoop.NewProvider()
+	return &Team{
+		Name:              "test-team",
+		Namespace:         "default",
+		Members:           members,
+		Strategy:          strategy,
+		telemetryRecorder: telemetryProvider.TeamRecorder(),
+		eventingRecorder:  eventingProvider.TeamRecorder(),
+		telemetry:         telemetryProvider,
+		eventing:          eventingProvider,
+	}
 }
 
 func TestDetermineNextMember(t *testing.T) {
@@ -462,3 +515,127 @@ func TestBuildRoles(t *testing.T) {
 		})
 	}
 }
+
+func newSelectionTestTeam(selector *arkv1alpha1.TeamSelectorSpec) (*Team, *telemetrymock.MockTeamRecorder) {
+	recorder := telemetrymock.NewTeamRecorder()
+	team := &Team{
+		Name:              "test-team",
+		Namespace:         "default",
+		Members:           []TeamMember{&mockTeamMember{name: "researcher"}, &mockTeamMember{name: "analyst"}, &mockTeamMember{name: "writer"}},
+		Selector:          selector,
+		telemetryRecorder: recorder,
+	}
+	return team, recorder
+}
+
+func TestRunSelection(t *testing.T) {
+	tests := []struct {
+		name          string
+		replies       []string
+		wantMember    string
+		wantAttempts  int
+		wantInvalid   int
+		wantReasoning string
+	}{
+		{
+			name:          "structured answer",
+			replies:       []string{`{"reasoning":"the data needs analysis","next":"analyst"}`},
+			wantMember:    "analyst",
+			wantAttempts:  1,
+			wantReasoning: "the data needs analysis",
+		},
+		{
+			name:         "text with punctuation",
+			replies:      []string{"Analyst."},
+			wantMember:   "analyst",
+			wantAttempts: 1,
+		},
+		{
+			name:         "text with explanation",
+			replies:      []string{"The writer should go next, since the analysis is done."},
+			wantMember:   "writer",
+			wantAttempts: 1,
+		},
+		{
+			name:          "invalid choice is retried",
+			replies:       []string{`{"reasoning":"","next":"editor"}`, `{"reasoning":"drafting","next":"writer"}`},
+			wantMember:    "writer",
+			wantAttempts:  2,
+			wantInvalid:   1,
+			wantReasoning: "drafting",
+		},
+		{
+			name:          "falls back once retries are used up",
+			replies:       []string{"researcher or analyst"},
+			wantMember:    "analyst",
+			wantAttempts:  3,
+			wantInvalid:   3,
+			wantReasoning: "selector made no valid choice, falling back",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			team, recorder := newSelectionTestTeam(&arkv1alpha1.TeamSelectorSpec{})
+			selector := &mockTeamMember{name: "selector", replies: tt.replies}
+
+			member, err := team.runSelection(context.Background(), selector, "choose", "researcher", team.Members)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMember, member.GetName())
+			assert.Equal(t, tt.wantAttempts, selector.calls)
+
+			span := recorder.Tracer.FindSpan("team.selection")
+			require.NotNil(t, span)
+			assert.Equal(t, tt.wantMember, span.Attributes["selection.selected"])
+			assert.Equal(t, tt.wantReasoning, span.Attributes["selection.reasoning"])
+			assert.Equal(t, tt.wantAttempts, span.Attributes["selection.attempts"])
+			assert.Len(t, span.Events, tt.wantInvalid)
+		})
+	}
+}
+
+func TestRunSelectionTellsSelectorWhyChoiceWasInvalid(t *testing.T) {
+	team, _ := newSelectionTestTeam(&arkv1alpha1.TeamSelectorSpec{})
+	selector := &mockTeamMember{name: "selector", replies: []string{`{"reasoning":"","next":"editor"}`, "writer"}}
+
+	_, err := team.runSelection(context.Background(), selector, "choose", "", team.Members[1:])
+	require.NoError(t, err)
+
+	require.Len(t, selector.history, 3)
+	assert.Equal(t, `{"reasoning":"","next":"editor"}`, selector.history[2].OfAssistant.Content.OfString.Value)
+	assert.Equal(t, `"editor" is not a valid choice. Select exactly one of: analyst, writer.`, selector.input.OfUser.Content.OfString.Value)
+}
+
+func TestRunSelectionTerminates(t *testing.T) {
+	team, _ := newSelectionTestTeam(&arkv1alpha1.TeamSelectorSpec{AllowTerminate: true})
+	selector := &mockTeamMember{name: "selector", replies: []string{`{"reasoning":"the report is final","next":"TERMINATE"}`}}
+
+	_, err := team.runSelection(context.Background(), selector, "choose", "writer", team.Members)
+	assert.True(t, IsTerminateTeam(err))
+
+	team.Selector.AllowTerminate = false
+	maxRetries := 0
+	team.Selector.MaxRetries = &maxRetries
+	member, err := team.runSelection(context.Background(), selector, "choose", "writer", team.Members)
+	require.NoError(t, err)
+	assert.Equal(t, "researcher", member.GetName())
+}
+
+func TestSelectionSchemaEnumeratesCandidates(t *testing.T) {
+	members := []TeamMember{&mockTeamMember{name: "analyst"}, &mockTeamMember{name: "writer"}}
+
+	var schema struct {
+		Properties struct {
+			Next struct {
+				Enum []string `json:"enum"`
+			} `json:"next"`
+		} `json:"properties"`
+		Required []string `json:"required"`
+	}
+	require.NoError(t, json.Unmarshal(selectionSchema(members, true).Raw, &schema))
+	assert.Equal(t, []string{"analyst", "writer", "TERMINATE"}, schema.Properties.Next.Enum)
+	assert.ElementsMatch(t, []string{"reasoning", "next"}, schema.Required)
+
+	require.NoError(t, json.Unmarshal(selectionSchema(members, false).Raw, &schema))
+	assert.Equal(t, []string{"analyst", "writer"}, schema.Properties.Next.Enum)
+}
@@ -0,0 +1,177 @@
+package genai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// terminationCondition is a TeamTerminationCondition prepared for evaluation
+type terminationCondition struct {
+	member string
+	answer *answerCondition
+}
+
+// ValidateTerminationCondition checks that a team termination condition can be evaluated
+func ValidateTerminationCondition(condition arkv1alpha1.TeamTerminationCondition) error {
+	_, err := compileTerminationCondition(condition)
+	return err
+}
+
+func compileTerminationCondition(condition arkv1alpha1.TeamTerminationCondition) (terminationCondition, error) {
+	answer, err := compileAnswerCondition(condition.Text, condition.Regex, condition.JSONPath, condition.Value)
+	if err != nil {
+		return terminationCondition{}, err
+	}
+	if answer == nil && condition.Member == "" {
+		return terminationCondition{}, fmt.Errorf("condition must set a member, text, regex or jsonPath")
+	}
+	return terminationCondition{member: condition.Member, answer: answer}, nil
+}
+
+func (c terminationCondition) matches(member, answer string) bool {
+	if c.member != "" && c.member != member {
+		return false
+	}
+	return c.answer == nil || c.answer.matches(answer)
+}
+
+func (c terminationCondition) String() string {
+	var parts []string
+	if c.member != "" {
+		parts = append(parts, "member "+c.member+" answered")
+	}
+	switch {
+	case c.answer == nil:
+	case c.answer.text != "":
+		parts = append(parts, fmt.Sprintf("answer contains %q", c.answer.text))
+	case c.answer.regex != nil:
+		parts = append(parts, fmt.Sprintf("answer matches %q", c.answer.regex.String()))
+	case c.answer.value != "":
+		parts = append(parts, fmt.Sprintf("answer field matches %q", c.answer.value))
+	default:
+		parts = append(parts, "answer field is set")
+	}
+	return strings.Join(parts, " and ")
+}
+
+// startTermination prepares the termination conditions of an execution of the team
+func (t *Team) startTermination() error {
+	t.timeoutCause = nil
+	t.terminationConditions = nil
+	t.terminationReason = ""
+	t.branchUsage = nil
+	if t.Termination == nil {
+		return nil
+	}
+
+	for i, condition := range t.Termination.Conditions {
+		compiled, err := compileTerminationCondition(condition)
+		if err != nil {
+			return fmt.Errorf("termination condition %d of team %s: %w", i, t.FullName(), err)
+		}
+		t.terminationConditions = append(t.terminationConditions, compiled)
+	}
+	return nil
+}
+
+// checkTermination returns why the team has to stop after a turn of the member, if it has to
+func (t *Team) checkTermination(ctx context.Context, member TeamMember, turnMessages []Message) string {
+	answer := strings.TrimSpace(ExtractLastAssistantMessageContent(turnMessages))
+	for _, condition := range t.terminationConditions {
+		if condition.matches(member.GetName(), answer) {
+			return "termination condition met: " + condition.String()
+		}
+	}
+
+	if t.Termination == nil {
+		return ""
+	}
+	if maxTokens := t.Termination.MaxTokens; maxTokens != nil {
+		if used := t.usedTokens(); used >= *maxTokens {
+			return fmt.Sprintf("token budget of %d reached with %d tokens", *maxTokens, used)
+		}
+	}
+	return t.timeoutReached(ctx)
+}
+
+// withTimeout bounds the execution of the team by its timeout, interrupting the running turns
+// when it expires
+func (t *Team) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if t.Termination == nil || t.Termination.Timeout == nil {
+		return ctx, func() {}
+	}
+	t.timeoutCause = fmt.Errorf("timeout of %s reached", t.Termination.Timeout.Duration)
+	return context.WithTimeoutCause(ctx, t.Termination.Timeout.Duration, t.timeoutCause)
+}
+
+// timeoutReached returns why the team has to stop when the context was ended by the team's own
+// timeout rather than by the caller or an enclosing team
+func (t *Team) timeoutReached(ctx context.Context) string {
+	if t.timeoutCause == nil || !errors.Is(context.Cause(ctx), t.timeoutCause) {
+		return ""
+	}
+	return t.timeoutCause.Error()
+}
+
+// usedTokens returns the tokens used by the team so far. Parallel branches collect their usage
+// separately and only add it to the team's once they are done, so the usage of the running
+// branches is added as well.
+func (t *Team) usedTokens() int64 {
+	t.terminationMutex.Lock()
+	defer t.terminationMutex.Unlock()
+	used := t.eventingRecorder.GetTokenSummary(t.usageCtx).TotalTokens
+	for _, branchCtx := range t.branchUsage {
+		used += t.eventingRecorder.GetTokenSummary(branchCtx).TotalTokens
+	}
+	return used
+}
+
+// startBranchUsage makes the usage collected in the context of a running branch count towards
+// the team's token budget
+func (t *Team) startBranchUsage(branchCtx context.Context, turn int) {
+	t.terminationMutex.Lock()
+	defer t.terminationMutex.Unlock()
+	if t.branchUsage == nil {
+		t.branchUsage = make(map[int]context.Context)
+	}
+	t.branchUsage[turn] = branchCtx
+}
+
+// finishBranchUsage adds the usage of a finished branch to the team's. Both happen under the
+// lock, so the usage is never counted twice or missed by usedTokens.
+func (t *Team) finishBranchUsage(ctx context.Context, turn int, usage arkv1alpha1.TokenUsage) {
+	t.terminationMutex.Lock()
+	defer t.terminationMutex.Unlock()
+	delete(t.branchUsage, turn)
+	t.eventingRecorder.AddTokenUsage(ctx, usage)
+}
+
+// maxTurnsReached reports whether the team used up its turns, recording it as the reason the
+// team stopped
+func (t *Team) maxTurnsReached(completedTurns int) bool {
+	if t.MaxTurns == nil || completedTurns < *t.MaxTurns {
+		return false
+	}
+	t.stop(fmt.Sprintf("maxTurns of %d reached", *t.MaxTurns))
+	return true
+}
+
+// stop records why the team stopped early. Only the first reason is kept.
+func (t *Team) stop(reason string) {
+	t.terminationMutex.Lock()
+	defer t.terminationMutex.Unlock()
+	if t.terminationReason == "" {
+		t.terminationReason = reason
+	}
+}
+
+// stopped returns why the team stopped early, if it did
+func (t *Team) stopped() string {
+	t.terminationMutex.Lock()
+	defer t.terminationMutex.Unlock()
+	return t.terminationReason
+}
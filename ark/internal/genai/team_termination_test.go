@@ -0,0 +1,143 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func newDebateTestTeam(proposer, critic []string) (*Team, *mockTeamMember, *mockTeamMember) {
+	first := &mockTeamMember{name: "proposer", replies: proposer}
+	second := &mockTeamMember{name: "critic", replies: critic}
+
+	team := newTestTeam("round-robin", first, second)
+	maxTurns := 10
+	team.MaxTurns = &maxTurns
+	return team, first, second
+}
+
+func TestTeamTerminatesOnConsensus(t *testing.T) {
+	team, proposer, critic := newDebateTestTeam(
+		[]string{"Use Postgres", "Fine, Postgres with read replicas"},
+		[]string{"Postgres will not scale", "AGREED, replicas solve it"},
+	)
+	team.Termination = &arkv1alpha1.TeamTerminationSpec{
+		Conditions: []arkv1alpha1.TeamTerminationCondition{{Regex: `^AGREED\b`}},
+	}
+
+	result, err := team.Execute(context.Background(), NewUserMessage("pick a database"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, proposer.calls)
+	assert.Equal(t, 2, critic.calls)
+	assert.Len(t, result.Messages, 4)
+	assert.Equal(t, `termination condition met: answer matches "^AGREED\\b"`, result.TerminationReason)
+}
+
+func TestTeamTerminatesWhenMemberAnswers(t *testing.T) {
+	team, proposer, critic := newDebateTestTeam(
+		[]string{"draft", `{"final":true}`},
+		[]string{`{"final":true}`},
+	)
+	team.Termination = &arkv1alpha1.TeamTerminationSpec{
+		Conditions: []arkv1alpha1.TeamTerminationCondition{{Member: "proposer", JSONPath: ".final"}},
+	}
+
+	result, err := team.Execute(context.Background(), NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, proposer.calls)
+	assert.Equal(t, 1, critic.calls, "the condition only applies to the proposer")
+	assert.Equal(t, "termination condition met: member proposer answered and answer field is set", result.TerminationReason)
+}
+
+func TestTeamTerminatesOnTokenBudget(t *testing.T) {
+	member := &mockTeamMember{name: "writer", replies: []string{"more"}, usage: arkv1alpha1.TokenUsage{TotalTokens: 40}}
+	team := newTestTeam("round-robin", member)
+	maxTokens := int64(100)
+	team.Termination = &arkv1alpha1.TeamTerminationSpec{MaxTokens: &maxTokens}
+
+	ctx := team.eventingRecorder.StartTokenCollection(context.Background())
+	result, err := team.Execute(ctx, NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, member.calls)
+	assert.Equal(t, "token budget of 100 reached with 120 tokens", result.TerminationReason)
+}
+
+func TestTeamTimeoutInterruptsRunningTurn(t *testing.T) {
+	team := newTestTeam("round-robin", &mockTeamMember{name: "writer", replies: []string{"draft"}, block: true})
+	team.Termination = &arkv1alpha1.TeamTerminationSpec{Timeout: &metav1.Duration{Duration: 50 * time.Millisecond}}
+
+	start := time.Now()
+	result, err := team.Execute(context.Background(), NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.Len(t, result.Messages, 1)
+	assert.Equal(t, "timeout of 50ms reached", result.TerminationReason)
+}
+
+func TestTeamTerminatesOnTokenBudgetAcrossParallelBranches(t *testing.T) {
+	team, _ := newParallelTestTeam(3, "researcher", "analyst", "writer")
+	maxTokens := int64(40)
+	team.Termination = &arkv1alpha1.TeamTerminationSpec{MaxTokens: &maxTokens}
+
+	ctx := team.eventingRecorder.StartTokenCollection(context.Background())
+	result, err := team.Execute(ctx, NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "token budget of 40 reached with 45 tokens", result.TerminationReason,
+		"each branch used 15 tokens, so only the team's usage reaches the budget")
+	assert.Equal(t, int64(45), team.eventingRecorder.GetTokenSummary(ctx).TotalTokens)
+}
+
+func TestTeamReportsMaxTurns(t *testing.T) {
+	team, _, _ := newDebateTestTeam([]string{"yes"}, []string{"no"})
+	maxTurns := 3
+	team.MaxTurns = &maxTurns
+
+	result, err := team.Execute(context.Background(), NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+
+	assert.Len(t, result.Messages, 3)
+	assert.Equal(t, "maxTurns of 3 reached", result.TerminationReason)
+
+	team.Strategy = "sequential"
+	result, err = team.Execute(context.Background(), NewUserMessage("go"), nil, NewNoopMemory(), nil)
+	require.NoError(t, err)
+	assert.Empty(t, result.TerminationReason, "a team that runs to completion does not stop early")
+}
+
+func TestTerminationConditionMatches(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition arkv1alpha1.TeamTerminationCondition
+		member    string
+		answer    string
+		want      bool
+	}{
+		{name: "text", condition: arkv1alpha1.TeamTerminationCondition{Text: "DONE"}, member: "writer", answer: "All DONE here", want: true},
+		{name: "text mismatch", condition: arkv1alpha1.TeamTerminationCondition{Text: "DONE"}, member: "writer", answer: "done", want: false},
+		{name: "member", condition: arkv1alpha1.TeamTerminationCondition{Member: "editor"}, member: "editor", answer: "anything", want: true},
+		{name: "other member", condition: arkv1alpha1.TeamTerminationCondition{Member: "editor"}, member: "writer", answer: "anything", want: false},
+		{name: "member and text", condition: arkv1alpha1.TeamTerminationCondition{Member: "editor", Text: "ship it"}, member: "editor", answer: "revise", want: false},
+		{name: "jsonpath value", condition: arkv1alpha1.TeamTerminationCondition{JSONPath: ".status", Value: "done"}, member: "writer", answer: `{"status":"done"}`, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			condition, err := compileTerminationCondition(tt.condition)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, condition.matches(tt.member, tt.answer))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// DefaultToolApprovalTimeout is how long a tool call waits for a decision when the agent's
+// policy does not say otherwise
+const DefaultToolApprovalTimeout = time.Hour
+
+type toolApproverKeyType struct{}
+
+var toolApproverKey = toolApproverKeyType{}
+
+// ToolApprovalRequest describes a tool call that may only run once approved
+type ToolApprovalRequest struct {
+	Agent   string
+	Call    ToolCall
+	Timeout time.Duration
+}
+
+// ToolApprovalDecision is the outcome of an approval request
+type ToolApprovalDecision struct {
+	Approved bool
+	Reason   string
+}
+
+// ToolApprover obtains decisions on tool calls. RequestApproval blocks until a decision is made,
+// the request times out or the context is done; timeouts are reported as rejections.
+type ToolApprover interface {
+	RequestApproval(ctx context.Context, request ToolApprovalRequest) (ToolApprovalDecision, error)
+}
+
+// WithToolApprover makes the approver available to the tool calls executed with the context
+func WithToolApprover(ctx context.Context, approver ToolApprover) context.Context {
+	return context.WithValue(ctx, toolApproverKey, approver)
+}
+
+// GetToolApprover returns the approver of the context, if any
+func GetToolApprover(ctx context.Context) ToolApprover {
+	approver, _ := ctx.Value(toolApproverKey).(ToolApprover)
+	return approver
+}
+
+// SetApprovalPolicy records the agent owning the registry and its approval policy, which may be nil
+func (tr *ToolRegistry) SetApprovalPolicy(agentName string, policy *arkv1alpha1.ToolApprovalPolicy) {
+	tr.agentName = agentName
+	tr.approvalPolicy = policy
+}
+
+// SetRequiresApproval marks a registered tool as needing approval for every call
+func (tr *ToolRegistry) SetRequiresApproval(toolName string, required bool) {
+	if !required {
+		delete(tr.approvalRequired, toolName)
+		return
+	}
+	tr.approvalRequired[toolName] = true
+}
+
+// RequiresApproval reports whether calls to the tool must be approved before they run, either
+// because the tool asks for it or because the agent's policy selects the tool
+func (tr *ToolRegistry) RequiresApproval(toolName string) bool {
+	if tr.approvalRequired[toolName] {
+		return true
+	}
+	policy := tr.approvalPolicy
+	if policy == nil {
+		return false
+	}
+	if policy.Mode == arkv1alpha1.ToolApprovalModeAll || slices.Contains(policy.Tools, toolName) {
+		return true
+	}
+	annotations, exists := tr.annotations[toolName]
+	return exists && annotations.DestructiveHint && !annotations.ReadOnlyHint
+}
+
+func (tr *ToolRegistry) approvalTimeout() time.Duration {
+	if tr.approvalPolicy != nil && tr.approvalPolicy.Timeout != nil {
+		return tr.approvalPolicy.Timeout.Duration
+	}
+	return DefaultToolApprovalTimeout
+}
+
+// requestApproval asks the approver of the context for a decision on the call. Without an
+// approver nobody can approve the call, so it is rejected.
+func (tr *ToolRegistry) requestApproval(ctx context.Context, call ToolCall) (ToolApprovalDecision, error) {
+	approver := GetToolApprover(ctx)
+	if approver == nil {
+		return ToolApprovalDecision{Reason: "no approver is available for this execution"}, nil
+	}
+	return approver.RequestApproval(ctx, ToolApprovalRequest{
+		Agent:   tr.agentName,
+		Call:    call,
+		Timeout: tr.approvalTimeout(),
+	})
+}
+
+func toolRejectionMessage(reason string) string {
+	if reason == "" {
+		return "Tool call rejected"
+	}
+	return fmt.Sprintf("Tool call rejected: %s", reason)
+}
@@ -0,0 +1,91 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// recordingApprover answers every request with the same decision
+type recordingApprover struct {
+	decision ToolApprovalDecision
+	requests []ToolApprovalRequest
+}
+
+func (a *recordingApprover) RequestApproval(_ context.Context, request ToolApprovalRequest) (ToolApprovalDecision, error) {
+	a.requests = append(a.requests, request)
+	return a.decision, nil
+}
+
+func echoCall(id string) ToolCall {
+	call := ToolCall{ID: id, Type: "function"}
+	call.Function.Name = "echo"
+	call.Function.Arguments = `{"text":"hi"}`
+	return call
+}
+
+func TestToolRegistry_RequiresApproval(t *testing.T) {
+	registry := newToolCallbackTestRegistry()
+	registry.RegisterTool(ToolDefinition{Name: "delete"}, echoExecutor{})
+	registry.RegisterTool(ToolDefinition{Name: "read"}, echoExecutor{})
+	registry.SetToolAnnotations("delete", &arkv1alpha1.ToolAnnotations{DestructiveHint: true})
+	registry.SetToolAnnotations("read", &arkv1alpha1.ToolAnnotations{DestructiveHint: true, ReadOnlyHint: true})
+
+	assert.False(t, registry.RequiresApproval("delete"), "annotations alone do not require approval")
+
+	registry.SetRequiresApproval("echo", true)
+	assert.True(t, registry.RequiresApproval("echo"))
+	registry.SetRequiresApproval("echo", false)
+
+	registry.SetApprovalPolicy("ops", &arkv1alpha1.ToolApprovalPolicy{Mode: arkv1alpha1.ToolApprovalModeDestructive})
+	assert.True(t, registry.RequiresApproval("delete"))
+	assert.False(t, registry.RequiresApproval("read"))
+	assert.False(t, registry.RequiresApproval("echo"))
+
+	registry.SetApprovalPolicy("ops", &arkv1alpha1.ToolApprovalPolicy{Mode: arkv1alpha1.ToolApprovalModeDestructive, Tools: []string{"echo"}})
+	assert.True(t, registry.RequiresApproval("echo"))
+
+	registry.SetApprovalPolicy("ops", &arkv1alpha1.ToolApprovalPolicy{Mode: arkv1alpha1.ToolApprovalModeAll})
+	assert.True(t, registry.RequiresApproval("read"))
+}
+
+func TestToolRegistry_ExecuteToolWaitsForApproval(t *testing.T) {
+	registry := newToolCallbackTestRegistry()
+	registry.SetApprovalPolicy("ops", &arkv1alpha1.ToolApprovalPolicy{
+		Mode:    arkv1alpha1.ToolApprovalModeAll,
+		Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+	})
+
+	approver := &recordingApprover{decision: ToolApprovalDecision{Approved: true}}
+	result, err := registry.ExecuteTool(WithToolApprover(context.Background(), approver), echoCall("call_1"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text":"hi"}`, result.Content)
+
+	require.Len(t, approver.requests, 1)
+	assert.Equal(t, "ops", approver.requests[0].Agent)
+	assert.Equal(t, "call_1", approver.requests[0].Call.ID)
+	assert.Equal(t, 10*time.Minute, approver.requests[0].Timeout)
+
+	approver.decision = ToolApprovalDecision{Reason: "not during business hours"}
+	result, err = registry.ExecuteTool(WithToolApprover(context.Background(), approver), echoCall("call_2"))
+	require.NoError(t, err)
+	assert.Equal(t, "call_2", result.ID)
+	assert.Equal(t, "Tool call rejected: not during business hours", result.Content)
+}
+
+func TestToolRegistry_ExecuteToolRejectsWithoutApprover(t *testing.T) {
+	registry := newToolCallbackTestRegistry()
+	registry.SetRequiresApproval("echo", true)
+
+	result, err := registry.ExecuteTool(context.Background(), echoCall("call_1"))
+	require.NoError(t, err)
+	assert.Equal(t, "Tool call rejected: no approver is available for this execution", result.Content)
+}
@@ -0,0 +1,202 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// ToolCallbackPath is the path of the endpoint execution engines call to run a tool
+	ToolCallbackPath = "/v1/tool-calls"
+
+	// maxToolCallbackRequestSize bounds the tool calls engines send, which hold the name and
+	// arguments of a single call
+	maxToolCallbackRequestSize = 1 << 20
+)
+
+type toolCallbackServerKeyType struct{}
+
+var toolCallbackServerKey = toolCallbackServerKeyType{}
+
+// ToolCallback tells an execution engine where to send the tool calls of an execution. The token
+// identifies the execution and is only valid while it runs.
+type ToolCallback struct {
+	URL   string `json:"url"`
+	Token string `json:"token"`
+}
+
+// ToolCallbackRequest is a tool call sent by an execution engine. Arguments may be a JSON object
+// or a string holding one, as in OpenAI tool calls.
+type ToolCallbackRequest struct {
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// ToolCallbackServer runs the tool calls of external execution engines through the tool
+// registry of the agent being executed, so that engines do not have to implement Ark's tools
+type ToolCallbackServer struct {
+	bindAddress string
+	url         string
+	sessions    sync.Map
+}
+
+type toolCallbackSession struct {
+	ctx   context.Context
+	tools *ToolRegistry
+}
+
+// NewToolCallbackServer creates a server listening on bindAddress, which execution engines reach
+// at url
+func NewToolCallbackServer(bindAddress, url string) *ToolCallbackServer {
+	return &ToolCallbackServer{
+		bindAddress: bindAddress,
+		url:         strings.TrimSuffix(url, "/"),
+	}
+}
+
+// WithToolCallbackServer makes the server available to the agents executed with the context
+func WithToolCallbackServer(ctx context.Context, server *ToolCallbackServer) context.Context {
+	return context.WithValue(ctx, toolCallbackServerKey, server)
+}
+
+// GetToolCallbackServer returns the server of the context, if any
+func GetToolCallbackServer(ctx context.Context) *ToolCallbackServer {
+	server, _ := ctx.Value(toolCallbackServerKey).(*ToolCallbackServer)
+	return server
+}
+
+// Start serves tool calls until the context is done. It implements manager.Runnable.
+func (s *ToolCallbackServer) Start(ctx context.Context) error {
+	logf.FromContext(ctx).Info("starting tool callback server", "address", s.bindAddress, "url", s.url)
+	return serveHTTP(ctx, s.bindAddress, s.Handler())
+}
+
+// NeedLeaderElection returns false, so that the server is ready as soon as the manager starts
+func (s *ToolCallbackServer) NeedLeaderElection() bool {
+	return false
+}
+
+// Handler returns the HTTP handler of the tool call endpoint
+func (s *ToolCallbackServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+ToolCallbackPath, s.handleToolCall)
+	return mux
+}
+
+// Register makes the tools available to an execution running with ctx until the returned
+// function is called. Tool calls run with ctx, so they are traced and recorded as part of the
+// execution.
+func (s *ToolCallbackServer) Register(ctx context.Context, tools *ToolRegistry) (*ToolCallback, func(), error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate tool callback token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	s.sessions.Store(token, &toolCallbackSession{ctx: ctx, tools: tools})
+	unregister := func() { s.sessions.Delete(token) }
+	return &ToolCallback{URL: s.url + ToolCallbackPath, Token: token}, unregister, nil
+}
+
+func (s *ToolCallbackServer) handleToolCall(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		http.Error(w, "missing bearer token", http.StatusUnauthorized)
+		return
+	}
+	value, ok := s.sessions.Load(token)
+	if !ok {
+		http.Error(w, "unknown or finished execution", http.StatusUnauthorized)
+		return
+	}
+	session := value.(*toolCallbackSession)
+
+	var request ToolCallbackRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxToolCallbackRequestSize)).Decode(&request); err != nil {
+		status := http.StatusBadRequest
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, fmt.Sprintf("invalid tool call: %v", err), status)
+		return
+	}
+	if request.Name == "" {
+		http.Error(w, "tool name is required", http.StatusBadRequest)
+		return
+	}
+
+	// The call belongs to the execution, but stops when the engine gives up on it
+	ctx, cancel := context.WithCancel(session.ctx)
+	defer cancel()
+	stop := context.AfterFunc(r.Context(), cancel)
+	defer stop()
+
+	call := ToolCall{ID: request.ID, Type: "function"}
+	call.Function.Name = request.Name
+	call.Function.Arguments = toolCallbackArguments(request.Arguments)
+
+	result, err := session.tools.ExecuteTool(ctx, call)
+	if err != nil && result.Error == "" {
+		result.Error = err.Error()
+	}
+	if result.ID == "" {
+		result.ID = request.ID
+		result.Name = request.Name
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to write tool callback response", "tool", request.Name)
+	}
+}
+
+func toolCallbackArguments(arguments json.RawMessage) string {
+	if len(arguments) == 0 || string(arguments) == "null" {
+		return "{}"
+	}
+	var encoded string
+	if err := json.Unmarshal(arguments, &encoded); err == nil {
+		return encoded
+	}
+	return string(arguments)
+}
+
+// serveHTTP serves the handler on the address until the context is done
+func serveHTTP(ctx context.Context, address string, handler http.Handler) error {
+	server := &http.Server{
+		Addr:              address,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
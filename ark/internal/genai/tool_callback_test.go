@@ -0,0 +1,90 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// echoExecutor returns the arguments it was called with
+type echoExecutor struct{}
+
+func (echoExecutor) Execute(_ context.Context, call ToolCall) (ToolResult, error) {
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: call.Function.Arguments}, nil
+}
+
+func newToolCallbackTestRegistry() *ToolRegistry {
+	registry := NewToolRegistry(nil, noop.NewProvider().ToolRecorder(), eventnoop.NewProvider().ToolRecorder())
+	registry.RegisterTool(ToolDefinition{Name: "echo"}, echoExecutor{})
+	return registry
+}
+
+func postToolCall(t *testing.T, handler http.Handler, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, ToolCallbackPath, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func TestToolCallbackServer_ExecutesRegisteredTools(t *testing.T) {
+	server := NewToolCallbackServer(":0", "http://ark-tool-callback-service.ark-system.svc:8082/")
+	callback, unregister, err := server.Register(context.Background(), newToolCallbackTestRegistry())
+	require.NoError(t, err)
+	defer unregister()
+
+	assert.Equal(t, "http://ark-tool-callback-service.ark-system.svc:8082/v1/tool-calls", callback.URL)
+
+	for _, body := range []string{
+		`{"id":"call_1","name":"echo","arguments":{"text":"hi"}}`,
+		`{"id":"call_1","name":"echo","arguments":"{\"text\":\"hi\"}"}`,
+	} {
+		recorder := postToolCall(t, server.Handler(), callback.Token, body)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var result ToolResult
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+		assert.Equal(t, "call_1", result.ID)
+		assert.Equal(t, "echo", result.Name)
+		assert.JSONEq(t, `{"text":"hi"}`, result.Content)
+		assert.Empty(t, result.Error)
+	}
+
+	recorder := postToolCall(t, server.Handler(), callback.Token, `{"id":"call_2","name":"missing"}`)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var result ToolResult
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&result))
+	assert.Equal(t, "tool missing not found", result.Error)
+}
+
+func TestToolCallbackServer_RejectsUnknownAndFinishedExecutions(t *testing.T) {
+	server := NewToolCallbackServer(":0", "http://localhost:8082")
+	callback, unregister, err := server.Register(context.Background(), newToolCallbackTestRegistry())
+	require.NoError(t, err)
+
+	body := `{"id":"call_1","name":"echo"}`
+	assert.Equal(t, http.StatusUnauthorized, postToolCall(t, server.Handler(), "", body).Code)
+	assert.Equal(t, http.StatusUnauthorized, postToolCall(t, server.Handler(), "not-a-token", body).Code)
+	assert.Equal(t, http.StatusBadRequest, postToolCall(t, server.Handler(), callback.Token, `{"id":"call_1"}`).Code)
+	assert.Equal(t, http.StatusOK, postToolCall(t, server.Handler(), callback.Token, body).Code)
+
+	oversized := `{"id":"call_1","name":"echo","arguments":"` + strings.Repeat("a", maxToolCallbackRequestSize) + `"}`
+	assert.Equal(t, http.StatusRequestEntityTooLarge, postToolCall(t, server.Handler(), callback.Token, oversized).Code)
+
+	unregister()
+	assert.Equal(t, http.StatusUnauthorized, postToolCall(t, server.Handler(), callback.Token, body).Code)
+}
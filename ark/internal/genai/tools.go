@@ -35,6 +35,8 @@ type HTTPExecutor struct {
 	K8sClient     client.Client
 	ToolName      string
 	ToolNamespace string
+	// Headers are set on every request after the tool's own headers, e.g. by overrides
+	Headers map[string]string
 }
 
 // Execute implements ToolExecutor interface for HTTP tools
@@ -132,6 +134,9 @@ func (h *HTTPExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult,
 		}
 		req.Header.Set(header.Name, value)
 	}
+	for name, value := range h.Headers {
+		req.Header.Set(name, value)
+	}
 
 	// Set timeout
 	timeout := h.getTimeout(httpSpec.Timeout)
@@ -182,8 +187,13 @@ func (h *HTTPExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult,
 type ToolRegistry struct {
 	tools             map[string]ToolDefinition
 	executors         map[string]ToolExecutor
-	mcpPool           *MCPClientPool         // One MCP client pool per agent
-	mcpSettings       map[string]MCPSettings // MCP settings per MCP server (namespace/name)
+	annotations       map[string]*arkv1alpha1.ToolAnnotations
+	approvalRequired  map[string]bool
+	approvalPolicy    *arkv1alpha1.ToolApprovalPolicy
+	agentName         string
+	mcpPool           *MCPClientPool               // One MCP client pool per agent
+	mcpSettings       map[string]MCPSettings       // MCP settings per MCP server (namespace/name)
+	httpHeaders       map[string]map[string]string // Override headers per HTTP tool name
 	telemetryRecorder telemetry.ToolRecorder
 	eventingRecorder  eventing.ToolRecorder
 }
@@ -192,6 +202,8 @@ func NewToolRegistry(mcpSettings map[string]MCPSettings, telemetryRecorder telem
 	return &ToolRegistry{
 		tools:             make(map[string]ToolDefinition),
 		executors:         make(map[string]ToolExecutor),
+		annotations:       make(map[string]*arkv1alpha1.ToolAnnotations),
+		approvalRequired:  make(map[string]bool),
 		mcpPool:           NewMCPClientPool(),
 		mcpSettings:       mcpSettings,
 		telemetryRecorder: telemetryRecorder,
@@ -204,6 +216,31 @@ func (tr *ToolRegistry) RegisterTool(def ToolDefinition, executor ToolExecutor)
 	tr.executors[def.Name] = executor
 }
 
+// SetHTTPHeaders sets the headers, keyed by tool name, that HTTP tools registered afterwards send
+// on top of their own
+func (tr *ToolRegistry) SetHTTPHeaders(headers map[string]map[string]string) {
+	tr.httpHeaders = headers
+}
+
+// SetToolAnnotations records the annotations of a registered tool
+func (tr *ToolRegistry) SetToolAnnotations(toolName string, annotations *arkv1alpha1.ToolAnnotations) {
+	if annotations == nil {
+		delete(tr.annotations, toolName)
+		return
+	}
+	tr.annotations[toolName] = annotations
+}
+
+// IsParallelSafe reports whether calls to the tool may run concurrently with other calls.
+// Only tools annotated as read-only or idempotent qualify; all others run one at a time.
+func (tr *ToolRegistry) IsParallelSafe(toolName string) bool {
+	annotations, exists := tr.annotations[toolName]
+	if !exists {
+		return false
+	}
+	return annotations.ReadOnlyHint || annotations.IdempotentHint
+}
+
 func (tr *ToolRegistry) GetToolDefinitions() []ToolDefinition {
 	definitions := make([]ToolDefinition, 0, len(tr.tools))
 	for _, def := range tr.tools {
@@ -223,6 +260,8 @@ func (tr *ToolRegistry) GetToolType(toolName string) string {
 		return "builtin"
 	case *TerminateExecutor:
 		return "builtin"
+	case *AskUserExecutor:
+		return "builtin"
 	case *HTTPExecutor:
 		return "custom"
 	case *MCPExecutor:
@@ -256,6 +295,21 @@ func (tr *ToolRegistry) ExecuteTool(ctx context.Context, call ToolCall) (ToolRes
 	}
 	ctx = tr.eventingRecorder.Start(ctx, "ToolCall", fmt.Sprintf("Executing tool %s", call.Function.Name), operationData)
 
+	if tr.RequiresApproval(call.Function.Name) {
+		decision, err := tr.requestApproval(ctx, call)
+		if err != nil {
+			tr.telemetryRecorder.RecordError(span, err)
+			tr.eventingRecorder.Fail(ctx, "ToolCall", fmt.Sprintf("Tool approval failed: %v", err), err, operationData)
+			return ToolResult{ID: call.ID, Name: call.Function.Name, Error: err.Error()}, err
+		}
+		if !decision.Approved {
+			// The agent sees the rejection as the tool's output and can carry on without it
+			operationData["rejectionReason"] = decision.Reason
+			tr.eventingRecorder.Complete(ctx, "ToolCall", "Tool call rejected", operationData)
+			return ToolResult{ID: call.ID, Name: call.Function.Name, Content: toolRejectionMessage(decision.Reason)}, nil
+		}
+	}
+
 	result, err := executor.Execute(ctx, call)
 	if err != nil {
 		tr.telemetryRecorder.RecordError(span, err)
@@ -370,6 +424,45 @@ func GetTerminateTool() ToolDefinition {
 	}
 }
 
+// AskUserExecutor asks the end user of the query a question and returns the answer, so that
+// an agent can get clarification without ending its turn
+type AskUserExecutor struct{}
+
+func (a *AskUserExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
+	var arguments map[string]any
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+		logf.Log.Info("Error parsing tool arguments", "ToolCall", call)
+		arguments = make(map[string]any)
+	}
+	question, _ := arguments["question"].(string)
+	if question == "" {
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Error: "question is required"}, fmt.Errorf("question is required")
+	}
+
+	answer, err := requestInput(ctx, InputRequest{Question: question})
+	if err != nil {
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Error: err.Error()}, err
+	}
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: answer}, nil
+}
+
+func GetAskUserTool() ToolDefinition {
+	return ToolDefinition{
+		Name:        "ask-user",
+		Description: "Ask the user a question and wait for the answer. Use it when the request is ambiguous or information is missing",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"question": map[string]any{
+					"type":        "string",
+					"description": "The question to ask the user",
+				},
+			},
+			"required": []string{"question"},
+		},
+	}
+}
+
 func (h *HTTPExecutor) getTimeout(timeoutStr string) time.Duration {
 	if timeoutStr == "" {
 		return 30 * time.Second
@@ -62,3 +62,29 @@ func IsTerminateTeam(err error) bool {
 	var terminateErr *TerminateTeam
 	return errors.As(err, &terminateErr)
 }
+
+// Reasons reported by ExecutionLimitError
+const (
+	ReasonAgentLimitExceeded = "AgentLimitExceeded"
+	ReasonBudgetExceeded     = "BudgetExceeded"
+)
+
+// ExecutionLimitError stops an execution that reached a configured limit. Reason is a
+// CamelCase identifier suitable for use as a Kubernetes condition reason.
+type ExecutionLimitError struct {
+	Reason  string
+	Message string
+}
+
+func (e *ExecutionLimitError) Error() string {
+	return e.Message
+}
+
+// GetExecutionLimitReason returns the reason of an ExecutionLimitError in the chain, if any
+func GetExecutionLimitReason(err error) string {
+	var limitErr *ExecutionLimitError
+	if errors.As(err, &limitErr) {
+		return limitErr.Reason
+	}
+	return ""
+}
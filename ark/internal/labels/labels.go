@@ -3,6 +3,7 @@
 package labels
 
 const (
-	MCPServerLabel = "mcp/server"
-	A2AServerLabel = "a2a/server"
+	MCPServerLabel   = "mcp/server"
+	A2AServerLabel   = "a2a/server"
+	A2AEndpointLabel = "a2a/endpoint"
 )
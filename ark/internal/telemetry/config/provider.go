@@ -15,6 +15,7 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"mckinsey.com/ark/internal/telemetry"
+	"mckinsey.com/ark/internal/telemetry/metrics"
 	"mckinsey.com/ark/internal/telemetry/noop"
 	otelimpl "mckinsey.com/ark/internal/telemetry/otel"
 	"mckinsey.com/ark/internal/telemetry/routing"
@@ -114,11 +115,11 @@ func NewProvider(ctx context.Context, k8sClient client.Client) *Provider {
 	sendStartupEvent(serviceName)
 
 	tracer := otelimpl.NewTracer("ark/controller")
-	queryRecorder := otelimpl.NewQueryRecorder(tracer)
+	queryRecorder := metrics.NewQueryRecorder(otelimpl.NewQueryRecorder(tracer))
 	agentRecorder := otelimpl.NewAgentRecorder(tracer)
-	modelRecorder := otelimpl.NewModelRecorder(tracer)
-	toolRecorder := otelimpl.NewToolRecorder(tracer)
-	teamRecorder := otelimpl.NewTeamRecorder(tracer)
+	modelRecorder := metrics.NewModelRecorder(otelimpl.NewModelRecorder(tracer))
+	toolRecorder := metrics.NewToolRecorder(otelimpl.NewToolRecorder(tracer))
+	teamRecorder := metrics.NewTeamRecorder(otelimpl.NewTeamRecorder(tracer))
 
 	log.Info("OTEL telemetry initialized successfully", "exporters", len(spanProcessors))
 
@@ -136,14 +137,15 @@ func NewProvider(ctx context.Context, k8sClient client.Client) *Provider {
 	}
 }
 
-// newNoopProvider creates a no-op telemetry provider.
+// newNoopProvider creates a provider that records no traces. Prometheus metrics are
+// still recorded, so they are available without an OTEL collector.
 func newNoopProvider() *Provider {
 	tracer := noop.NewTracer()
-	queryRecorder := noop.NewQueryRecorder()
+	queryRecorder := metrics.NewQueryRecorder(noop.NewQueryRecorder())
 	agentRecorder := noop.NewAgentRecorder()
-	modelRecorder := noop.NewModelRecorder()
-	toolRecorder := noop.NewToolRecorder()
-	teamRecorder := noop.NewTeamRecorder()
+	modelRecorder := metrics.NewModelRecorder(noop.NewModelRecorder())
+	toolRecorder := metrics.NewToolRecorder(noop.NewToolRecorder())
+	teamRecorder := metrics.NewTeamRecorder(noop.NewTeamRecorder())
 
 	return &Provider{
 		tracer:        tracer,
@@ -0,0 +1,58 @@
+/* Copyright 2025. McKinsey & Company */
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const a2aTaskListTimeout = 5 * time.Second
+
+var a2aTasksDesc = prometheus.NewDesc(
+	"ark_a2a_tasks",
+	"Number of A2ATasks by namespace and phase.",
+	[]string{"namespace", "phase"}, nil,
+)
+
+// a2aTaskCollector counts A2ATasks by phase when metrics are scraped. Reading from the
+// manager's cache keeps the count exact without tracking every phase transition.
+type a2aTaskCollector struct {
+	reader client.Reader
+}
+
+// RegisterA2ATaskCollector registers the A2ATask phase gauge, reading tasks with the given reader.
+func RegisterA2ATaskCollector(reader client.Reader) error {
+	return ctrlmetrics.Registry.Register(&a2aTaskCollector{reader: reader})
+}
+
+func (c *a2aTaskCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- a2aTasksDesc
+}
+
+func (c *a2aTaskCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), a2aTaskListTimeout)
+	defer cancel()
+
+	var tasks arkv1alpha1.A2ATaskList
+	if err := c.reader.List(ctx, &tasks); err != nil {
+		logf.Log.WithName("metrics").Error(err, "failed to list A2ATasks for metrics")
+		return
+	}
+
+	type key struct{ namespace, phase string }
+	counts := make(map[key]int)
+	for _, task := range tasks.Items {
+		counts[key{task.Namespace, task.Status.Phase}]++
+	}
+	for k, count := range counts {
+		ch <- prometheus.MustNewConstMetric(a2aTasksDesc, prometheus.GaugeValue, float64(count), k.namespace, k.phase)
+	}
+}
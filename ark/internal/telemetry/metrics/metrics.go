@@ -0,0 +1,87 @@
+/* Copyright 2025. McKinsey & Company */
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Values of the result label
+const (
+	resultSuccess = "success"
+	resultError   = "error"
+)
+
+var (
+	queryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ark_query_duration_seconds",
+		Help:    "Duration of query executions by target type and final phase.",
+		Buckets: []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"target_type", "phase"})
+
+	modelCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ark_model_call_duration_seconds",
+		Help:    "Latency of model calls by model, provider and result.",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"model", "provider", "result"})
+
+	modelCallErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_model_call_errors_total",
+		Help: "Number of failed model calls by model and provider.",
+	}, []string{"model", "provider"})
+
+	modelTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_model_tokens_total",
+		Help: "Tokens used by model calls by model, provider and token type (prompt or completion).",
+	}, []string{"model", "provider", "type"})
+
+	modelFallbacks = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_model_fallbacks_total",
+		Help: "Number of model calls sent to a fallback model by fallback model and primary model.",
+	}, []string{"model", "fallback_for"})
+
+	toolCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ark_tool_call_duration_seconds",
+		Help:    "Latency of tool calls by tool type and result.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"tool_type", "result"})
+
+	toolCallErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "ark_tool_call_errors_total",
+		Help: "Number of failed tool calls by tool type.",
+	}, []string{"tool_type"})
+
+	teamTurns = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "ark_team_turns",
+		Help:    "Number of turns taken per team execution by strategy.",
+		Buckets: []float64{1, 2, 3, 5, 8, 13, 21, 34},
+	}, []string{"strategy"})
+
+	// MCPClients is the number of MCP client connections held open by client pools
+	MCPClients = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "ark_mcp_client_pool_size",
+		Help: "Number of MCP client connections currently open across all client pools.",
+	})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		queryDuration,
+		modelCallDuration,
+		modelCallErrors,
+		modelTokens,
+		modelFallbacks,
+		toolCallDuration,
+		toolCallErrors,
+		teamTurns,
+		MCPClients,
+	)
+}
+
+func result(failed bool) string {
+	if failed {
+		return resultError
+	}
+	return resultSuccess
+}
@@ -0,0 +1,249 @@
+/* Copyright 2025. McKinsey & Company */
+
+package metrics
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+// span wraps the span of a decorated recorder. It remembers when the operation started
+// and whether it failed, and observes the operation once when the span ends.
+type span struct {
+	telemetry.Span
+	start  time.Time
+	labels []string
+	failed atomic.Bool
+	once   sync.Once
+	onEnd  func(duration time.Duration, failed bool)
+}
+
+func newSpan(inner telemetry.Span, onEnd func(duration time.Duration, failed bool)) *span {
+	return &span{Span: inner, start: time.Now(), onEnd: onEnd}
+}
+
+func (s *span) End() {
+	s.once.Do(func() {
+		s.onEnd(time.Since(s.start), s.failed.Load())
+	})
+	s.Span.End()
+}
+
+// unwrap returns the span of the decorated recorder
+func unwrap(s telemetry.Span) telemetry.Span {
+	if wrapped, ok := s.(*span); ok {
+		return wrapped.Span
+	}
+	return s
+}
+
+func markFailed(s telemetry.Span) {
+	if wrapped, ok := s.(*span); ok {
+		wrapped.failed.Store(true)
+	}
+}
+
+// queryRecorder records query durations on top of a telemetry.QueryRecorder
+type queryRecorder struct {
+	telemetry.QueryRecorder
+}
+
+// NewQueryRecorder decorates a query recorder with Prometheus metrics.
+func NewQueryRecorder(inner telemetry.QueryRecorder) telemetry.QueryRecorder {
+	return &queryRecorder{QueryRecorder: inner}
+}
+
+func (r *queryRecorder) StartQuery(ctx context.Context, query *arkv1alpha1.Query, phase string) (context.Context, telemetry.Span) {
+	ctx, inner := r.QueryRecorder.StartQuery(ctx, query, phase)
+	targetType := ""
+	if query.Spec.Target != nil {
+		targetType = query.Spec.Target.Type
+	}
+	// The final phase is read when the span ends, after the controller has updated the query status
+	return ctx, newSpan(inner, func(duration time.Duration, _ bool) {
+		queryDuration.WithLabelValues(targetType, query.Status.Phase).Observe(duration.Seconds())
+	})
+}
+
+func (r *queryRecorder) RecordRootInput(s telemetry.Span, content string) {
+	r.QueryRecorder.RecordRootInput(unwrap(s), content)
+}
+
+func (r *queryRecorder) RecordRootOutput(s telemetry.Span, content string) {
+	r.QueryRecorder.RecordRootOutput(unwrap(s), content)
+}
+
+func (r *queryRecorder) RecordInput(s telemetry.Span, content string) {
+	r.QueryRecorder.RecordInput(unwrap(s), content)
+}
+
+func (r *queryRecorder) RecordOutput(s telemetry.Span, content string) {
+	r.QueryRecorder.RecordOutput(unwrap(s), content)
+}
+
+func (r *queryRecorder) RecordTokenUsage(s telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
+	r.QueryRecorder.RecordTokenUsage(unwrap(s), promptTokens, completionTokens, totalTokens)
+}
+
+func (r *queryRecorder) RecordSessionID(s telemetry.Span, sessionID string) {
+	r.QueryRecorder.RecordSessionID(unwrap(s), sessionID)
+}
+
+func (r *queryRecorder) RecordConversationID(s telemetry.Span, conversationID string) {
+	r.QueryRecorder.RecordConversationID(unwrap(s), conversationID)
+}
+
+func (r *queryRecorder) RecordSuccess(s telemetry.Span) {
+	r.QueryRecorder.RecordSuccess(unwrap(s))
+}
+
+func (r *queryRecorder) RecordError(s telemetry.Span, err error) {
+	markFailed(s)
+	r.QueryRecorder.RecordError(unwrap(s), err)
+}
+
+// modelRecorder records model call latency, errors and token usage on top of a telemetry.ModelRecorder
+type modelRecorder struct {
+	telemetry.ModelRecorder
+}
+
+// NewModelRecorder decorates a model recorder with Prometheus metrics.
+func NewModelRecorder(inner telemetry.ModelRecorder) telemetry.ModelRecorder {
+	return &modelRecorder{ModelRecorder: inner}
+}
+
+func (r *modelRecorder) StartModelExecution(ctx context.Context, modelName, modelType string) (context.Context, telemetry.Span) {
+	ctx, inner := r.ModelRecorder.StartModelExecution(ctx, modelName, modelType)
+	s := newSpan(inner, func(duration time.Duration, failed bool) {
+		modelCallDuration.WithLabelValues(modelName, modelType, result(failed)).Observe(duration.Seconds())
+		if failed {
+			modelCallErrors.WithLabelValues(modelName, modelType).Inc()
+		}
+	})
+	s.labels = []string{modelName, modelType}
+	return ctx, s
+}
+
+func (r *modelRecorder) RecordInput(s telemetry.Span, messages any) {
+	r.ModelRecorder.RecordInput(unwrap(s), messages)
+}
+
+func (r *modelRecorder) RecordOutput(s telemetry.Span, output any) {
+	r.ModelRecorder.RecordOutput(unwrap(s), output)
+}
+
+func (r *modelRecorder) RecordTokenUsage(s telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
+	if wrapped, ok := s.(*span); ok {
+		modelTokens.WithLabelValues(append(wrapped.labels, "prompt")...).Add(float64(promptTokens))
+		modelTokens.WithLabelValues(append(wrapped.labels, "completion")...).Add(float64(completionTokens))
+	}
+	r.ModelRecorder.RecordTokenUsage(unwrap(s), promptTokens, completionTokens, totalTokens)
+}
+
+func (r *modelRecorder) RecordModelDetails(s telemetry.Span, modelName, modelType string) {
+	r.ModelRecorder.RecordModelDetails(unwrap(s), modelName, modelType)
+}
+
+func (r *modelRecorder) RecordAttempt(s telemetry.Span, attempt, maxAttempts int) {
+	r.ModelRecorder.RecordAttempt(unwrap(s), attempt, maxAttempts)
+}
+
+func (r *modelRecorder) RecordFallback(s telemetry.Span, modelName, primaryModelName string) {
+	modelFallbacks.WithLabelValues(modelName, primaryModelName).Inc()
+	r.ModelRecorder.RecordFallback(unwrap(s), modelName, primaryModelName)
+}
+
+func (r *modelRecorder) RecordSuccess(s telemetry.Span) {
+	r.ModelRecorder.RecordSuccess(unwrap(s))
+}
+
+func (r *modelRecorder) RecordError(s telemetry.Span, err error) {
+	markFailed(s)
+	r.ModelRecorder.RecordError(unwrap(s), err)
+}
+
+// toolRecorder records tool call latency and errors on top of a telemetry.ToolRecorder
+type toolRecorder struct {
+	telemetry.ToolRecorder
+}
+
+// NewToolRecorder decorates a tool recorder with Prometheus metrics.
+func NewToolRecorder(inner telemetry.ToolRecorder) telemetry.ToolRecorder {
+	return &toolRecorder{ToolRecorder: inner}
+}
+
+func (r *toolRecorder) StartToolExecution(ctx context.Context, toolName, toolType, toolID, arguments string) (context.Context, telemetry.Span) {
+	ctx, inner := r.ToolRecorder.StartToolExecution(ctx, toolName, toolType, toolID, arguments)
+	return ctx, newSpan(inner, func(duration time.Duration, failed bool) {
+		toolCallDuration.WithLabelValues(toolType, result(failed)).Observe(duration.Seconds())
+		if failed {
+			toolCallErrors.WithLabelValues(toolType).Inc()
+		}
+	})
+}
+
+func (r *toolRecorder) RecordToolResult(s telemetry.Span, result string) {
+	r.ToolRecorder.RecordToolResult(unwrap(s), result)
+}
+
+func (r *toolRecorder) RecordSuccess(s telemetry.Span) {
+	r.ToolRecorder.RecordSuccess(unwrap(s))
+}
+
+func (r *toolRecorder) RecordError(s telemetry.Span, err error) {
+	markFailed(s)
+	r.ToolRecorder.RecordError(unwrap(s), err)
+}
+
+type teamTurnsKeyType struct{}
+
+var teamTurnsKey = teamTurnsKeyType{}
+
+// teamRecorder records the turns taken per team execution on top of a telemetry.TeamRecorder
+type teamRecorder struct {
+	telemetry.TeamRecorder
+}
+
+// NewTeamRecorder decorates a team recorder with Prometheus metrics.
+func NewTeamRecorder(inner telemetry.TeamRecorder) telemetry.TeamRecorder {
+	return &teamRecorder{TeamRecorder: inner}
+}
+
+func (r *teamRecorder) StartTeamExecution(ctx context.Context, teamName, namespace, strategy string, memberCount, maxTurns int) (context.Context, telemetry.Span) {
+	ctx, inner := r.TeamRecorder.StartTeamExecution(ctx, teamName, namespace, strategy, memberCount, maxTurns)
+	// Turns of this team are counted through the context, nested teams get their own counter
+	turns := &atomic.Int64{}
+	ctx = context.WithValue(ctx, teamTurnsKey, turns)
+	return ctx, newSpan(inner, func(_ time.Duration, _ bool) {
+		teamTurns.WithLabelValues(strategy).Observe(float64(turns.Load()))
+	})
+}
+
+func (r *teamRecorder) StartTurn(ctx context.Context, turn int, memberName, memberType string) (context.Context, telemetry.Span) {
+	if turns, ok := ctx.Value(teamTurnsKey).(*atomic.Int64); ok {
+		turns.Add(1)
+	}
+	return r.TeamRecorder.StartTurn(ctx, turn, memberName, memberType)
+}
+
+func (r *teamRecorder) RecordTurnOutput(s telemetry.Span, messages any, messageCount int) {
+	r.TeamRecorder.RecordTurnOutput(unwrap(s), messages, messageCount)
+}
+
+func (r *teamRecorder) RecordTokenUsage(s telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
+	r.TeamRecorder.RecordTokenUsage(unwrap(s), promptTokens, completionTokens, totalTokens)
+}
+
+func (r *teamRecorder) RecordSuccess(s telemetry.Span) {
+	r.TeamRecorder.RecordSuccess(unwrap(s))
+}
+
+func (r *teamRecorder) RecordError(s telemetry.Span, err error) {
+	markFailed(s)
+	r.TeamRecorder.RecordError(unwrap(s), err)
+}
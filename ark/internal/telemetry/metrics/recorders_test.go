@@ -0,0 +1,87 @@
+/* Copyright 2025. McKinsey & Company */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func readMetric(t *testing.T, metric prometheus.Metric) *dto.Metric {
+	t.Helper()
+	var m dto.Metric
+	require.NoError(t, metric.Write(&m))
+	return &m
+}
+
+func histogramCount(t *testing.T, vec *prometheus.HistogramVec, labels ...string) uint64 {
+	t.Helper()
+	return readMetric(t, vec.WithLabelValues(labels...).(prometheus.Metric)).GetHistogram().GetSampleCount()
+}
+
+func counterValue(t *testing.T, vec *prometheus.CounterVec, labels ...string) float64 {
+	t.Helper()
+	return readMetric(t, vec.WithLabelValues(labels...)).GetCounter().GetValue()
+}
+
+func TestQueryRecorderObservesFinalPhase(t *testing.T) {
+	recorder := NewQueryRecorder(noop.NewQueryRecorder())
+	query := &arkv1alpha1.Query{
+		Spec: arkv1alpha1.QuerySpec{Target: &arkv1alpha1.QueryTarget{Type: "agent", Name: "metrics-agent"}},
+	}
+	before := histogramCount(t, queryDuration, "agent", "done")
+
+	_, span := recorder.StartQuery(context.Background(), query, "execute")
+	query.Status.Phase = "done"
+	recorder.RecordSuccess(span)
+	span.End()
+	span.End()
+
+	assert.Equal(t, before+1, histogramCount(t, queryDuration, "agent", "done"))
+}
+
+func TestModelRecorderCountsErrorsAndTokens(t *testing.T) {
+	recorder := NewModelRecorder(noop.NewModelRecorder())
+	beforeErrors := counterValue(t, modelCallErrors, "metrics-model", "openai")
+	beforePrompt := counterValue(t, modelTokens, "metrics-model", "openai", "prompt")
+	beforeCompletion := counterValue(t, modelTokens, "metrics-model", "openai", "completion")
+
+	_, span := recorder.StartModelExecution(context.Background(), "metrics-model", "openai")
+	recorder.RecordTokenUsage(span, 100, 20, 120)
+	recorder.RecordSuccess(span)
+	span.End()
+
+	_, span = recorder.StartModelExecution(context.Background(), "metrics-model", "openai")
+	recorder.RecordError(span, errors.New("rate limited"))
+	span.End()
+
+	assert.Equal(t, beforeErrors+1, counterValue(t, modelCallErrors, "metrics-model", "openai"))
+	assert.Equal(t, beforePrompt+100, counterValue(t, modelTokens, "metrics-model", "openai", "prompt"))
+	assert.Equal(t, beforeCompletion+20, counterValue(t, modelTokens, "metrics-model", "openai", "completion"))
+	assert.Equal(t, uint64(1), histogramCount(t, modelCallDuration, "metrics-model", "openai", resultError))
+}
+
+func TestTeamRecorderCountsTurnsPerExecution(t *testing.T) {
+	recorder := NewTeamRecorder(noop.NewTeamRecorder())
+	before := readMetric(t, teamTurns.WithLabelValues("metrics-strategy").(prometheus.Metric)).GetHistogram()
+
+	ctx, span := recorder.StartTeamExecution(context.Background(), "team", "default", "metrics-strategy", 2, 0)
+	for turn := range 3 {
+		_, turnSpan := recorder.StartTurn(ctx, turn, "member", "agent")
+		turnSpan.End()
+	}
+	span.End()
+
+	after := readMetric(t, teamTurns.WithLabelValues("metrics-strategy").(prometheus.Metric)).GetHistogram()
+	assert.Equal(t, before.GetSampleCount()+1, after.GetSampleCount())
+	assert.Equal(t, before.GetSampleSum()+3, after.GetSampleSum())
+}
@@ -4,6 +4,7 @@ package mock
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
@@ -307,6 +308,13 @@ func (r *MockAgentRecorder) RecordToolResult(span telemetry.Span, result string)
 	span.SetAttributes(telemetry.String(telemetry.AttrToolOutput, result))
 }
 
+func (r *MockAgentRecorder) RecordDelegationChain(span telemetry.Span, chain []string) {
+	span.SetAttributes(
+		telemetry.String(telemetry.AttrDelegationChain, strings.Join(chain, " -> ")),
+		telemetry.Int(telemetry.AttrDelegationDepth, len(chain)),
+	)
+}
+
 func (r *MockAgentRecorder) RecordTokenUsage(span telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
 	span.SetAttributes(
 		telemetry.Int64(telemetry.AttrTokensPrompt, promptTokens),
@@ -361,6 +369,36 @@ func (r *MockTeamRecorder) RecordTurnOutput(span telemetry.Span, messages any, m
 	)
 }
 
+func (r *MockTeamRecorder) StartSelection(ctx context.Context, candidates string) (context.Context, telemetry.Span) {
+	return r.Tracer.Start(ctx, "team.selection",
+		telemetry.WithAttributes(
+			telemetry.String("selection.candidates", candidates),
+		),
+	)
+}
+
+func (r *MockTeamRecorder) RecordInvalidSelection(span telemetry.Span, response string, attempt int) {
+	span.AddEvent("selection.invalid",
+		telemetry.Int("selection.attempt", attempt),
+		telemetry.String("selection.response", response),
+	)
+}
+
+func (r *MockTeamRecorder) RecordSelection(span telemetry.Span, selected, reasoning string, attempts int) {
+	span.SetAttributes(
+		telemetry.String("selection.selected", selected),
+		telemetry.String("selection.reasoning", reasoning),
+		telemetry.Int("selection.attempts", attempts),
+	)
+}
+
+func (r *MockTeamRecorder) RecordDelegationChain(span telemetry.Span, chain []string) {
+	span.SetAttributes(
+		telemetry.String(telemetry.AttrDelegationChain, strings.Join(chain, " -> ")),
+		telemetry.Int(telemetry.AttrDelegationDepth, len(chain)),
+	)
+}
+
 func (r *MockTeamRecorder) RecordTokenUsage(span telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
 	span.SetAttributes(
 		telemetry.Int64(telemetry.AttrTokensPrompt, promptTokens),
@@ -82,7 +82,8 @@ func (r *noopAgentRecorder) StartToolCall(ctx context.Context, toolName, toolTyp
 	return ctx, &noopSpan{}
 }
 
-func (r *noopAgentRecorder) RecordToolResult(span telemetry.Span, result string) {} //nolint:revive
+func (r *noopAgentRecorder) RecordToolResult(span telemetry.Span, result string)       {} //nolint:revive
+func (r *noopAgentRecorder) RecordDelegationChain(span telemetry.Span, chain []string) {} //nolint:revive
 func (r *noopAgentRecorder) RecordTokenUsage(span telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
 }                                                                       //nolint:revive
 func (r *noopAgentRecorder) RecordSuccess(span telemetry.Span)          {} //nolint:revive
@@ -107,6 +108,10 @@ func (r *noopModelRecorder) RecordOutput(span telemetry.Span, output any)  {} //
 func (r *noopModelRecorder) RecordTokenUsage(span telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
 } //nolint:revive
 func (r *noopModelRecorder) RecordModelDetails(span telemetry.Span, modelName, modelType string) {
+} //nolint:revive
+func (r *noopModelRecorder) RecordAttempt(span telemetry.Span, attempt, maxAttempts int) {
+} //nolint:revive
+func (r *noopModelRecorder) RecordFallback(span telemetry.Span, modelName, primaryModelName string) {
 }                                                                       //nolint:revive
 func (r *noopModelRecorder) RecordSuccess(span telemetry.Span)          {} //nolint:revive
 func (r *noopModelRecorder) RecordError(span telemetry.Span, err error) {} //nolint:revive
@@ -141,6 +146,17 @@ func (r *noopTeamRecorder) StartTurn(ctx context.Context, turn int, memberName,
 
 func (r *noopTeamRecorder) RecordTurnOutput(span telemetry.Span, messages any, messageCount int) {
 } //nolint:revive
+
+func (r *noopTeamRecorder) StartSelection(ctx context.Context, candidates string) (context.Context, telemetry.Span) {
+	return ctx, &noopSpan{}
+}
+
+func (r *noopTeamRecorder) RecordInvalidSelection(span telemetry.Span, response string, attempt int) {
+} //nolint:revive
+func (r *noopTeamRecorder) RecordSelection(span telemetry.Span, selected, reasoning string, attempts int) {
+} //nolint:revive
+
+func (r *noopTeamRecorder) RecordDelegationChain(span telemetry.Span, chain []string) {} //nolint:revive
 func (r *noopTeamRecorder) RecordTokenUsage(span telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
 }                                                                      //nolint:revive
 func (r *noopTeamRecorder) RecordSuccess(span telemetry.Span)          {} //nolint:revive
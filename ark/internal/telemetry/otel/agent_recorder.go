@@ -4,6 +4,7 @@ package otel
 
 import (
 	"context"
+	"strings"
 
 	"mckinsey.com/ark/internal/telemetry"
 )
@@ -70,6 +71,14 @@ func (r *agentRecorder) RecordToolResult(span telemetry.Span, result string) {
 	span.SetAttributes(telemetry.String(telemetry.AttrToolOutput, result))
 }
 
+// RecordDelegationChain records the agents and teams that delegated to this agent.
+func (r *agentRecorder) RecordDelegationChain(span telemetry.Span, chain []string) {
+	span.SetAttributes(
+		telemetry.String(telemetry.AttrDelegationChain, strings.Join(chain, " -> ")),
+		telemetry.Int(telemetry.AttrDelegationDepth, len(chain)),
+	)
+}
+
 // RecordTokenUsage records token consumption for LLM calls.
 func (r *agentRecorder) RecordTokenUsage(span telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
 	span.SetAttributes(
@@ -170,6 +170,20 @@ func (r *modelRecorder) RecordModelDetails(span telemetry.Span, modelName, model
 	)
 }
 
+func (r *modelRecorder) RecordAttempt(span telemetry.Span, attempt, maxAttempts int) {
+	span.SetAttributes(
+		telemetry.Int(telemetry.AttrModelAttempt, attempt),
+		telemetry.Int(telemetry.AttrModelAttempts, maxAttempts),
+	)
+}
+
+func (r *modelRecorder) RecordFallback(span telemetry.Span, modelName, primaryModelName string) {
+	span.SetAttributes(
+		telemetry.String(telemetry.AttrModelResource, modelName),
+		telemetry.String(telemetry.AttrFallbackFor, primaryModelName),
+	)
+}
+
 func (r *modelRecorder) RecordSuccess(span telemetry.Span) {
 	span.SetStatus(telemetry.StatusOk, "success")
 }
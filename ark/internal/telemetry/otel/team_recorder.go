@@ -5,6 +5,7 @@ package otel
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"mckinsey.com/ark/internal/telemetry"
 )
@@ -68,6 +69,40 @@ func (r *teamRecorder) RecordTurnOutput(span telemetry.Span, messages any, messa
 	}
 }
 
+func (r *teamRecorder) StartSelection(ctx context.Context, candidates string) (context.Context, telemetry.Span) {
+	return r.tracer.Start(ctx, "team.selection",
+		telemetry.WithSpanKind(telemetry.SpanKindInternal),
+		telemetry.WithAttributes(
+			telemetry.String("selection.candidates", candidates),
+			telemetry.String(telemetry.AttrComponentName, "team.selection"),
+			telemetry.String("type", telemetry.ObservationTypeAgent),
+			telemetry.String("name", "Select next member"),
+		),
+	)
+}
+
+func (r *teamRecorder) RecordInvalidSelection(span telemetry.Span, response string, attempt int) {
+	span.AddEvent("selection.invalid",
+		telemetry.Int("selection.attempt", attempt),
+		telemetry.String("selection.response", response),
+	)
+}
+
+func (r *teamRecorder) RecordSelection(span telemetry.Span, selected, reasoning string, attempts int) {
+	span.SetAttributes(
+		telemetry.String("selection.selected", selected),
+		telemetry.String("selection.reasoning", reasoning),
+		telemetry.Int("selection.attempts", attempts),
+	)
+}
+
+func (r *teamRecorder) RecordDelegationChain(span telemetry.Span, chain []string) {
+	span.SetAttributes(
+		telemetry.String(telemetry.AttrDelegationChain, strings.Join(chain, " -> ")),
+		telemetry.Int(telemetry.AttrDelegationDepth, len(chain)),
+	)
+}
+
 func (r *teamRecorder) RecordTokenUsage(span telemetry.Span, promptTokens, completionTokens, totalTokens int64) {
 	span.SetAttributes(
 		telemetry.Int64(telemetry.AttrTokensPrompt, promptTokens),
@@ -60,6 +60,9 @@ type AgentRecorder interface {
 	// RecordToolResult records the tool execution result.
 	RecordToolResult(span Span, result string)
 
+	// RecordDelegationChain records the agents and teams that delegated to this agent.
+	RecordDelegationChain(span Span, chain []string)
+
 	// RecordTokenUsage records token consumption for LLM calls.
 	RecordTokenUsage(span Span, promptTokens, completionTokens, totalTokens int64)
 
@@ -92,6 +95,12 @@ type ModelRecorder interface {
 	// RecordModelDetails records model configuration. Provider is extracted from modelType.
 	RecordModelDetails(span Span, modelName, modelType string)
 
+	// RecordAttempt records which attempt of a retried model call the span covers.
+	RecordAttempt(span Span, attempt, maxAttempts int)
+
+	// RecordFallback records that the span covers a fallback model used in place of the primary model.
+	RecordFallback(span Span, modelName, primaryModelName string)
+
 	// RecordSuccess marks a span as successfully completed.
 	RecordSuccess(span Span)
 
@@ -127,6 +136,18 @@ type TeamRecorder interface {
 	// RecordTurnOutput records turn execution output messages.
 	RecordTurnOutput(span Span, messages any, messageCount int)
 
+	// StartSelection begins tracing the choice of the next member by a selector.
+	StartSelection(ctx context.Context, candidates string) (context.Context, Span)
+
+	// RecordInvalidSelection records an answer of the selector that named no candidate.
+	RecordInvalidSelection(span Span, response string, attempt int)
+
+	// RecordSelection records the member chosen by the selector and its reasoning.
+	RecordSelection(span Span, selected, reasoning string, attempts int)
+
+	// RecordDelegationChain records the agents and teams that delegated to this team.
+	RecordDelegationChain(span Span, chain []string)
+
 	// RecordTokenUsage records token consumption for team execution.
 	RecordTokenUsage(span Span, promptTokens, completionTokens, totalTokens int64)
 
@@ -159,10 +180,18 @@ const (
 	// Team attributes
 	AttrTeamName = "team.name"
 
+	// Delegation attributes, the agents and teams that led to an execution
+	AttrDelegationChain = "delegation.chain"
+	AttrDelegationDepth = "delegation.depth"
+
 	// Model attributes (aligned with OpenTelemetry GenAI conventions)
 	AttrModelName     = "llm.model.name"
 	AttrModelProvider = "llm.model.provider"
 	AttrModelType     = "llm.model.type"
+	AttrModelAttempt  = "llm.attempt"
+	AttrModelAttempts = "llm.max_attempts"
+	AttrModelResource = "llm.model.resource"
+	AttrFallbackFor   = "llm.fallback_for"
 
 	// Token usage (aligned with OpenTelemetry GenAI conventions)
 	AttrTokensPrompt     = "gen_ai.usage.input_tokens"
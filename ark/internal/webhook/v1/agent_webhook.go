@@ -122,6 +122,10 @@ func (v *AgentCustomValidator) validateAgent(ctx context.Context, agent *arkv1al
 		warnings = append(warnings, toolWarnings...)
 	}
 
+	if err := v.ValidateNoDelegationCycle(ctx, agent.Namespace, delegationNode{kind: MemberTypeAgent, name: agent.Name}, agentDelegationRefs(agent)); err != nil {
+		return warnings, err
+	}
+
 	// Collect migration warnings (e.g., deprecated 'custom' tool type)
 	warnings = append(warnings, collectMigrationWarnings(agent.Annotations)...)
 
@@ -132,6 +136,21 @@ func (v *AgentCustomValidator) validateAgentModel(ctx context.Context, agent *ar
 	// Model validation is now handled at runtime via status conditions
 	// Agents without valid models will show as Available: False
 	// This allows for eventual consistency when models are created after agents
+	if len(agent.Spec.FallbackModels) == 0 {
+		return nil
+	}
+	if agent.Spec.ModelRef == nil {
+		return fmt.Errorf("fallbackModels require a modelRef")
+	}
+
+	seen := map[string]bool{agent.Spec.ModelRef.Namespace + "/" + agent.Spec.ModelRef.Name: true}
+	for i, fallback := range agent.Spec.FallbackModels {
+		key := fallback.Namespace + "/" + fallback.Name
+		if seen[key] {
+			return fmt.Errorf("fallbackModels[%d]: model '%s' is already referenced by the agent", i, fallback.Name)
+		}
+		seen[key] = true
+	}
 	return nil
 }
 
@@ -0,0 +1,134 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+const kindTool = "tool"
+
+// delegationNode is an agent, team or tool that can hand work to other agents and teams
+type delegationNode struct {
+	kind string
+	name string
+}
+
+func (n delegationNode) String() string {
+	return n.kind + "/" + n.name
+}
+
+// ValidateNoDelegationCycle checks that the agents, teams and tools referenced by a resource
+// never lead back to it. The resource being admitted is followed through its new references;
+// the rest are read from the cluster. Missing resources end the search, as they are reported
+// by the resources referencing them or loaded lazily at runtime.
+func (v *ResourceValidator) ValidateNoDelegationCycle(ctx context.Context, namespace string, start delegationNode, refs []delegationNode) error {
+	visited := map[delegationNode]bool{start: true}
+	path := []delegationNode{start}
+
+	var visit func(refs []delegationNode) error
+	visit = func(refs []delegationNode) error {
+		for _, ref := range refs {
+			if ref == start {
+				return delegationCycleError(append(path, ref))
+			}
+			if visited[ref] {
+				continue
+			}
+			visited[ref] = true
+
+			next, err := v.delegationRefs(ctx, namespace, ref)
+			if err != nil {
+				return err
+			}
+			path = append(path, ref)
+			if err := visit(next); err != nil {
+				return err
+			}
+			path = path[:len(path)-1]
+		}
+		return nil
+	}
+	return visit(refs)
+}
+
+func delegationCycleError(path []delegationNode) error {
+	names := make([]string, len(path))
+	for i, node := range path {
+		names[i] = node.String()
+	}
+	return fmt.Errorf("delegation cycle: %s", strings.Join(names, " -> "))
+}
+
+// delegationRefs loads a resource and returns the agents, teams and tools it delegates to
+func (v *ResourceValidator) delegationRefs(ctx context.Context, namespace string, node delegationNode) ([]delegationNode, error) {
+	key := types.NamespacedName{Name: node.name, Namespace: namespace}
+	var (
+		obj  client.Object
+		refs func() []delegationNode
+	)
+	switch node.kind {
+	case MemberTypeAgent:
+		agent := &arkv1alpha1.Agent{}
+		obj, refs = agent, func() []delegationNode { return agentDelegationRefs(agent) }
+	case MemberTypeTeam:
+		team := &arkv1alpha1.Team{}
+		obj, refs = team, func() []delegationNode { return teamDelegationRefs(team) }
+	default:
+		tool := &arkv1alpha1.Tool{}
+		obj, refs = tool, func() []delegationNode { return toolDelegationRefs(tool) }
+	}
+
+	if err := v.Client.Get(ctx, key, obj); err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed to get %s '%s' in namespace '%s': %v", node.kind, node.name, namespace, err)
+		}
+		return nil, nil
+	}
+	return refs(), nil
+}
+
+func agentDelegationRefs(agent *arkv1alpha1.Agent) []delegationNode {
+	refs := make([]delegationNode, 0, len(agent.Spec.Tools))
+	for _, tool := range agent.Spec.Tools {
+		if name := tool.GetToolCRDName(); name != "" {
+			refs = append(refs, delegationNode{kind: kindTool, name: name})
+		}
+	}
+	return refs
+}
+
+// teamDelegationRefs returns the members of a team and the agents it runs to select or
+// aggregate them
+func teamDelegationRefs(team *arkv1alpha1.Team) []delegationNode {
+	refs := make([]delegationNode, 0, len(team.Spec.Members)+1)
+	for _, member := range team.Spec.Members {
+		refs = append(refs, delegationNode{kind: member.Type, name: member.Name})
+	}
+	if team.Spec.Selector != nil && team.Spec.Selector.Agent != "" {
+		refs = append(refs, delegationNode{kind: MemberTypeAgent, name: team.Spec.Selector.Agent})
+	}
+	if team.Spec.Parallel != nil && team.Spec.Parallel.Aggregator != "" {
+		refs = append(refs, delegationNode{kind: MemberTypeAgent, name: team.Spec.Parallel.Aggregator})
+	}
+	return refs
+}
+
+func toolDelegationRefs(tool *arkv1alpha1.Tool) []delegationNode {
+	switch {
+	case tool.Spec.Type == genai.ToolTypeAgent && tool.Spec.Agent != nil && tool.Spec.Agent.Name != "":
+		return []delegationNode{{kind: MemberTypeAgent, name: tool.Spec.Agent.Name}}
+	case tool.Spec.Type == genai.ToolTypeTeam && tool.Spec.Team != nil && tool.Spec.Team.Name != "":
+		return []delegationNode{{kind: MemberTypeTeam, name: tool.Spec.Team.Name}}
+	default:
+		return nil
+	}
+}
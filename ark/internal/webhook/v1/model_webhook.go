@@ -149,6 +149,10 @@ func (v *ModelValidator) validateProviderConfig(ctx context.Context, model *arkv
 		return v.validateOpenAIConfig(ctx, model)
 	case genai.ProviderBedrock:
 		return v.validateBedrockConfig(ctx, model)
+	case genai.ProviderAnthropic:
+		return v.validateAnthropicConfig(ctx, model)
+	case genai.ProviderGemini:
+		return v.validateGeminiConfig(ctx, model)
 	default:
 		if model.Spec.Provider == "" {
 			if genai.IsDeprecatedProviderInType(model.Spec.Type) {
@@ -221,6 +225,72 @@ func (v *ModelValidator) validateOpenAIConfig(ctx context.Context, model *arkv1a
 	return nil
 }
 
+func (v *ModelValidator) validateAnthropicConfig(ctx context.Context, model *arkv1alpha1.Model) error {
+	if model.Spec.Config.Anthropic == nil {
+		return fmt.Errorf("anthropic configuration is required for anthropic provider")
+	}
+
+	if err := v.validateValueSource(ctx, &model.Spec.Config.Anthropic.BaseURL, model.GetNamespace(), "spec.config.anthropic.baseUrl"); err != nil {
+		return err
+	}
+	if err := v.validateValueSource(ctx, &model.Spec.Config.Anthropic.APIKey, model.GetNamespace(), "spec.config.anthropic.apiKey"); err != nil {
+		return err
+	}
+
+	_, err := v.Resolver.ResolveValueSource(ctx, model.Spec.Config.Anthropic.BaseURL, model.GetNamespace())
+	if err != nil {
+		modellog.Error(err, "Failed to resolve Anthropic BaseURL", "model", model.GetName())
+		return fmt.Errorf("failed to resolve Anthropic BaseURL: %w", err)
+	}
+
+	for i, header := range model.Spec.Config.Anthropic.Headers {
+		contextPrefix := fmt.Sprintf("spec.config.anthropic.headers[%d]", i)
+		if err := ValidateHeader(header, contextPrefix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (v *ModelValidator) validateGeminiConfig(ctx context.Context, model *arkv1alpha1.Model) error {
+	config := model.Spec.Config.Gemini
+	if config == nil {
+		return fmt.Errorf("gemini configuration is required for gemini provider")
+	}
+	if (config.APIKey == nil) == (config.ServiceAccountKey == nil) {
+		return fmt.Errorf("spec.config.gemini requires exactly one of apiKey or serviceAccountKey")
+	}
+
+	valueSources := []struct {
+		field string
+		value *arkv1alpha1.ValueSource
+	}{
+		{"baseUrl", config.BaseURL},
+		{"apiKey", config.APIKey},
+		{"serviceAccountKey", config.ServiceAccountKey},
+		{"project", config.Project},
+		{"location", config.Location},
+	}
+	for _, vs := range valueSources {
+		if vs.value == nil {
+			continue
+		}
+		if err := v.validateValueSource(ctx, vs.value, model.GetNamespace(), "spec.config.gemini."+vs.field); err != nil {
+			return err
+		}
+	}
+
+	for i, header := range config.Headers {
+		contextPrefix := fmt.Sprintf("spec.config.gemini.headers[%d]", i)
+		if err := ValidateHeader(header, contextPrefix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (v *ModelValidator) validateBedrockConfig(ctx context.Context, model *arkv1alpha1.Model) error {
 	if model.Spec.Config.Bedrock == nil {
 		return fmt.Errorf("bedrock configuration is required for bedrock model type")
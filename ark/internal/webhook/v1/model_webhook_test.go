@@ -104,6 +104,59 @@ var _ = Describe("Model Webhook", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(warnings).To(BeEmpty())
 		})
+
+		It("Should allow valid Anthropic model with direct values", func() {
+			model.Spec.Provider = genai.ProviderAnthropic
+			model.Spec.Config = arkv1alpha1.ModelConfig{
+				Anthropic: &arkv1alpha1.AnthropicModelConfig{
+					BaseURL: arkv1alpha1.ValueSource{
+						Value: "https://api.anthropic.com/v1",
+					},
+					APIKey: arkv1alpha1.ValueSource{
+						Value: "anthropic-key",
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, model)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should reject Anthropic model without anthropic configuration", func() {
+			model.Spec.Provider = genai.ProviderAnthropic
+
+			_, err := validator.ValidateCreate(ctx, model)
+			Expect(err).To(MatchError(ContainSubstring("anthropic configuration is required")))
+		})
+
+		It("Should allow valid Gemini model with an API key", func() {
+			model.Spec.Provider = genai.ProviderGemini
+			model.Spec.Config = arkv1alpha1.ModelConfig{
+				Gemini: &arkv1alpha1.GeminiModelConfig{
+					APIKey: &arkv1alpha1.ValueSource{
+						Value: "gemini-key",
+					},
+				},
+			}
+
+			warnings, err := validator.ValidateCreate(ctx, model)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should reject Gemini model with both an API key and a service account key", func() {
+			model.Spec.Provider = genai.ProviderGemini
+			model.Spec.Config = arkv1alpha1.ModelConfig{
+				Gemini: &arkv1alpha1.GeminiModelConfig{
+					APIKey:            &arkv1alpha1.ValueSource{Value: "gemini-key"},
+					ServiceAccountKey: &arkv1alpha1.ValueSource{Value: "{}"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, model)
+			Expect(err).To(MatchError(ContainSubstring("exactly one of apiKey or serviceAccountKey")))
+		})
 	})
 
 	Context("When validating models with Secret references", func() {
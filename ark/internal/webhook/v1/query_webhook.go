@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -58,6 +59,14 @@ func (v *QueryCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newOb
 	}
 	log.V(3).Info("Validate update", "query", query.ObjectMeta)
 	if query.DeletionTimestamp.IsZero() {
+		if oldQuery, ok := oldObj.(*arkv1alpha1.Query); ok {
+			if err := validateToolApprovalChanges(oldQuery.Spec.ToolApprovals, query.Spec.ToolApprovals); err != nil {
+				return nil, err
+			}
+			if err := validateInputResponseChanges(oldQuery.Spec.InputResponses, query.Spec.InputResponses); err != nil {
+				return nil, err
+			}
+		}
 		return v.validateQuery(ctx, query)
 	}
 	return nil, nil
@@ -88,9 +97,48 @@ func (v *QueryCustomValidator) validateQuery(ctx context.Context, query *arkv1al
 		return warnings, err
 	}
 
+	if err := v.validateModelOverrides(ctx, query); err != nil {
+		return warnings, err
+	}
+
+	if err := validateToolApprovals(query.Spec.ToolApprovals); err != nil {
+		return warnings, err
+	}
+
+	if err := validateInputResponses(query.Spec.InputResponses); err != nil {
+		return warnings, err
+	}
+
 	return warnings, nil
 }
 
+func validateToolApprovals(approvals []arkv1alpha1.ToolApproval) error {
+	seen := make(map[string]bool, len(approvals))
+	for i, approval := range approvals {
+		if seen[approval.RequestID] {
+			return fmt.Errorf("toolApprovals[%d]: duplicate decision for request '%s'", i, approval.RequestID)
+		}
+		seen[approval.RequestID] = true
+	}
+	return nil
+}
+
+// validateToolApprovalChanges rejects updates that change or remove a recorded decision, since
+// the tool call may already have run or been rejected
+func validateToolApprovalChanges(oldApprovals, newApprovals []arkv1alpha1.ToolApproval) error {
+	decisions := make(map[string]string, len(newApprovals))
+	for _, approval := range newApprovals {
+		decisions[approval.RequestID] = approval.Decision
+	}
+	for _, approval := range oldApprovals {
+		decision, exists := decisions[approval.RequestID]
+		if !exists || decision != approval.Decision {
+			return fmt.Errorf("toolApprovals: the decision for request '%s' cannot be changed once recorded", approval.RequestID)
+		}
+	}
+	return nil
+}
+
 func (v *QueryCustomValidator) validateQueryTargets(ctx context.Context, query *arkv1alpha1.Query) error {
 	if query.Spec.Target == nil && query.Spec.Selector == nil {
 		return fmt.Errorf("target or selector must be specified")
@@ -122,3 +170,55 @@ func (v *QueryCustomValidator) validateQueryTargets(ctx context.Context, query *
 
 	return nil
 }
+
+// validateModelOverrides checks that every model override changes something, selects agents with
+// a valid label selector and substitutes a model that exists
+func (v *QueryCustomValidator) validateModelOverrides(ctx context.Context, query *arkv1alpha1.Query) error {
+	for i, override := range query.Spec.ModelOverrides {
+		if override.ModelRef == nil && len(override.Properties) == 0 {
+			return fmt.Errorf("modelOverrides[%d]: modelRef or properties must be specified", i)
+		}
+		if override.LabelSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(override.LabelSelector); err != nil {
+				return fmt.Errorf("modelOverrides[%d].labelSelector: %v", i, err)
+			}
+		}
+		if override.ModelRef != nil {
+			namespace := override.ModelRef.Namespace
+			if namespace == "" {
+				namespace = query.Namespace
+			}
+			if err := v.ValidateLoadModel(ctx, override.ModelRef.Name, namespace); err != nil {
+				return fmt.Errorf("modelOverrides[%d].modelRef references %v", i, err)
+			}
+		}
+	}
+	return nil
+}
+
+func validateInputResponses(responses []arkv1alpha1.InputResponse) error {
+	seen := make(map[string]bool, len(responses))
+	for i, response := range responses {
+		if seen[response.RequestID] {
+			return fmt.Errorf("inputResponses[%d]: duplicate answer for request '%s'", i, response.RequestID)
+		}
+		seen[response.RequestID] = true
+	}
+	return nil
+}
+
+// validateInputResponseChanges rejects updates that change or remove a recorded answer, since
+// the agent may already have received it
+func validateInputResponseChanges(oldResponses, newResponses []arkv1alpha1.InputResponse) error {
+	answers := make(map[string]string, len(newResponses))
+	for _, response := range newResponses {
+		answers[response.RequestID] = response.Answer
+	}
+	for _, response := range oldResponses {
+		answer, exists := answers[response.RequestID]
+		if !exists || answer != response.Answer {
+			return fmt.Errorf("inputResponses: the answer to request '%s' cannot be changed once recorded", response.RequestID)
+		}
+	}
+	return nil
+}
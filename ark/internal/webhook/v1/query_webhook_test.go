@@ -3,8 +3,13 @@
 package v1
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	// TODO (user): Add any additional imports if needed
@@ -52,5 +57,54 @@ var _ = Describe("Query Webhook", func() {
 		//     obj.SomeRequiredField = "updated_value"
 		//     Expect(validator.ValidateUpdate(ctx, oldObj, obj)).To(BeNil())
 		// })
+
+		It("Should deny changing a recorded tool approval", func() {
+			approved := []arkv1alpha1.ToolApproval{{RequestID: "req-1", Decision: arkv1alpha1.ToolApprovalApproved}}
+			Expect(validateToolApprovalChanges(nil, approved)).To(Succeed())
+			Expect(validateToolApprovalChanges(approved, append(approved,
+				arkv1alpha1.ToolApproval{RequestID: "req-2", Decision: arkv1alpha1.ToolApprovalRejected}))).To(Succeed())
+
+			rejected := []arkv1alpha1.ToolApproval{{RequestID: "req-1", Decision: arkv1alpha1.ToolApprovalRejected}}
+			Expect(validateToolApprovalChanges(approved, rejected)).To(MatchError(ContainSubstring("cannot be changed")))
+			Expect(validateToolApprovalChanges(approved, nil)).To(MatchError(ContainSubstring("cannot be changed")))
+		})
+
+		It("Should deny duplicate tool approvals", func() {
+			Expect(validateToolApprovals([]arkv1alpha1.ToolApproval{
+				{RequestID: "req-1", Decision: arkv1alpha1.ToolApprovalApproved},
+				{RequestID: "req-1", Decision: arkv1alpha1.ToolApprovalRejected},
+			})).To(MatchError(ContainSubstring("duplicate decision")))
+		})
+
+		It("Should deny changing a recorded input response", func() {
+			answered := []arkv1alpha1.InputResponse{{RequestID: "req-1", Answer: "Paris"}}
+			Expect(validateInputResponseChanges(nil, answered)).To(Succeed())
+			Expect(validateInputResponseChanges(answered, []arkv1alpha1.InputResponse{{RequestID: "req-1", Answer: "Rome"}})).
+				To(MatchError(ContainSubstring("cannot be changed")))
+			Expect(validateInputResponses(append(answered, answered...))).To(MatchError(ContainSubstring("duplicate answer")))
+		})
+
+		It("Should deny model overrides that substitute a missing model", func() {
+			s := runtime.NewScheme()
+			Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+			validator.ResourceValidator = &ResourceValidator{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(
+				&arkv1alpha1.Model{ObjectMeta: metav1.ObjectMeta{Name: "gpt-4o", Namespace: "default"}},
+			).Build()}
+			obj.Namespace = "default"
+
+			obj.Spec.ModelOverrides = []arkv1alpha1.ModelOverride{
+				{Agent: "weather", ModelRef: &arkv1alpha1.AgentModelRef{Name: "gpt-4o"}},
+				{Properties: map[string]string{"temperature": "0"}},
+			}
+			Expect(validator.validateModelOverrides(context.Background(), obj)).To(Succeed())
+
+			obj.Spec.ModelOverrides = []arkv1alpha1.ModelOverride{{ModelRef: &arkv1alpha1.AgentModelRef{Name: "claude"}}}
+			Expect(validator.validateModelOverrides(context.Background(), obj)).
+				To(MatchError(ContainSubstring("modelOverrides[0].modelRef references model 'claude' does not exist")))
+
+			obj.Spec.ModelOverrides = []arkv1alpha1.ModelOverride{{Agent: "weather"}}
+			Expect(validator.validateModelOverrides(context.Background(), obj)).
+				To(MatchError(ContainSubstring("modelRef or properties must be specified")))
+		})
 	})
 })
@@ -5,6 +5,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"text/template"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -75,6 +76,10 @@ func (v *TeamCustomValidator) validateTeamMembers(ctx context.Context, team *ark
 		return warnings, err
 	}
 
+	if err := v.validateTermination(team); err != nil {
+		return warnings, err
+	}
+
 	for i, member := range team.Spec.Members {
 		if member.Name == team.Name {
 			return warnings, fmt.Errorf("team member %d: team '%s' cannot reference itself", i, member.Name)
@@ -101,6 +106,10 @@ func (v *TeamCustomValidator) validateTeamMembers(ctx context.Context, team *ark
 		return warnings, err
 	}
 
+	if err := v.ValidateNoDelegationCycle(ctx, team.Namespace, delegationNode{kind: MemberTypeTeam, name: team.Name}, teamDelegationRefs(team)); err != nil {
+		return warnings, err
+	}
+
 	return warnings, nil
 }
 
@@ -145,9 +154,69 @@ func (v *TeamCustomValidator) validateStrategy(ctx context.Context, team *arkv1a
 		return nil
 	case "graph":
 		return v.validateGraphStrategy(team)
+	case "parallel":
+		return v.validateParallelStrategy(ctx, team)
 	default:
-		return fmt.Errorf("unsupported strategy '%s': must be 'sequential', 'round-robin', 'selector', or 'graph'", team.Spec.Strategy)
+		return fmt.Errorf("unsupported strategy '%s': must be 'sequential', 'round-robin', 'selector', 'graph', or 'parallel'", team.Spec.Strategy)
+	}
+}
+
+func (v *TeamCustomValidator) validateParallelStrategy(ctx context.Context, team *arkv1alpha1.Team) error {
+	parallel := team.Spec.Parallel
+	if parallel == nil {
+		return nil
+	}
+
+	memberNames := make(map[string]bool)
+	for _, member := range team.Spec.Members {
+		memberNames[member.Name] = true
+	}
+	seen := make(map[string]bool)
+	for i, name := range parallel.Members {
+		if !memberNames[name] {
+			return fmt.Errorf("parallel.members[%d]: member '%s' not found in team members", i, name)
+		}
+		if seen[name] {
+			return fmt.Errorf("parallel.members[%d]: member '%s' is listed more than once", i, name)
+		}
+		seen[name] = true
+	}
+
+	if parallel.AggregatorPrompt != "" {
+		if _, err := template.New("aggregator").Parse(parallel.AggregatorPrompt); err != nil {
+			return fmt.Errorf("parallel.aggregatorPrompt: %v", err)
+		}
 	}
+
+	if parallel.Aggregator != "" {
+		if err := v.ValidateLoadAgent(ctx, parallel.Aggregator, team.Namespace); err != nil {
+			return fmt.Errorf("aggregator agent '%s' not found in namespace %s: %v", parallel.Aggregator, team.Namespace, err)
+		}
+	}
+
+	return nil
+}
+
+func (v *TeamCustomValidator) validateTermination(team *arkv1alpha1.Team) error {
+	termination := team.Spec.Termination
+	if termination == nil {
+		return nil
+	}
+
+	memberNames := make(map[string]bool)
+	for _, member := range team.Spec.Members {
+		memberNames[member.Name] = true
+	}
+	for i, condition := range termination.Conditions {
+		if condition.Member != "" && !memberNames[condition.Member] {
+			return fmt.Errorf("termination.conditions[%d]: member '%s' not found in team members", i, condition.Member)
+		}
+		if err := genai.ValidateTerminationCondition(condition); err != nil {
+			return fmt.Errorf("termination.conditions[%d]: %w", i, err)
+		}
+	}
+
+	return nil
 }
 
 func (v *TeamCustomValidator) validateSelectorAgent(ctx context.Context, team *arkv1alpha1.Team) error {
@@ -179,7 +248,9 @@ func (v *TeamCustomValidator) validateGraphStrategy(team *arkv1alpha1.Team) erro
 		memberNames[member.Name] = true
 	}
 
-	transitionMap := make(map[string]bool)
+	// Edges leaving a member are evaluated in order, so an edge after one without a condition
+	// could never be taken
+	hasDefaultEdge := make(map[string]bool)
 	for i, edge := range team.Spec.Graph.Edges {
 		if !memberNames[edge.From] {
 			return fmt.Errorf("graph edge %d: 'from' member '%s' not found in team members", i, edge.From)
@@ -187,10 +258,13 @@ func (v *TeamCustomValidator) validateGraphStrategy(team *arkv1alpha1.Team) erro
 		if !memberNames[edge.To] {
 			return fmt.Errorf("graph edge %d: 'to' member '%s' not found in team members", i, edge.To)
 		}
-		if _, exists := transitionMap[edge.From]; exists {
-			return fmt.Errorf("member '%s' has more than one outgoing edge", edge.From)
+		if hasDefaultEdge[edge.From] {
+			return fmt.Errorf("graph edge %d: member '%s' already has an earlier outgoing edge without a condition", i, edge.From)
 		}
-		transitionMap[edge.From] = true
+		if err := genai.ValidateGraphCondition(edge.Condition); err != nil {
+			return fmt.Errorf("graph edge %d: %w", i, err)
+		}
+		hasDefaultEdge[edge.From] = edge.Condition == nil
 	}
 
 	if team.Spec.MaxTurns == nil {
@@ -224,6 +298,9 @@ func (v *TeamCustomValidator) validateGraphForSelector(team *arkv1alpha1.Team) e
 		if !memberNames[edge.To] {
 			return fmt.Errorf("graph edge %d: 'to' member '%s' not found in team members", i, edge.To)
 		}
+		if edge.Condition != nil {
+			return fmt.Errorf("graph edge %d: conditions are only supported by the graph strategy", i)
+		}
 	}
 
 	// Note: maxTurns is optional for selector strategy (it handles termination differently)
@@ -4,6 +4,7 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -178,27 +179,173 @@ var _ = Describe("Team Webhook", func() {
 		})
 	})
 
-	Context("Graph strategy validation (should remain strict)", func() {
-		It("Should reject multiple edges from same source for graph strategy", func() {
-			By("creating a graph team with multiple edges from same source")
+	Context("Graph strategy validation", func() {
+		BeforeEach(func() {
 			obj.Spec.Strategy = "graph"
 			obj.Spec.Members = []arkv1alpha1.TeamMember{
 				{Name: "researcher", Type: "agent"},
 				{Name: "analyst", Type: "agent"},
 				{Name: "writer", Type: "agent"},
 			}
+			maxTurns := 10
+			obj.Spec.MaxTurns = &maxTurns
+		})
+
+		It("Should reject an edge after an unconditional edge from the same source", func() {
+			By("creating a graph team with multiple unconditional edges from same source")
 			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
 				Edges: []arkv1alpha1.TeamGraphEdge{
 					{From: "researcher", To: "analyst"},
-					{From: "researcher", To: "writer"}, // Multiple edges from same source - NOT allowed for graph
+					{From: "researcher", To: "writer"}, // Never taken, the first edge always matches
 				},
 			}
-			maxTurns := 10
-			obj.Spec.MaxTurns = &maxTurns
 
 			_, err := validator.ValidateCreate(ctx, obj)
-			Expect(err).To(HaveOccurred(), "graph strategy should reject multiple edges from same source")
-			Expect(err.Error()).To(ContainSubstring("more than one outgoing edge"))
+			Expect(err).To(HaveOccurred(), "graph strategy should reject edges that can never be taken")
+			Expect(err.Error()).To(ContainSubstring("earlier outgoing edge without a condition"))
+		})
+
+		It("Should allow conditional edges followed by a default edge", func() {
+			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
+				Edges: []arkv1alpha1.TeamGraphEdge{
+					{From: "researcher", To: "writer"},
+					{From: "writer", To: "analyst", Condition: &arkv1alpha1.TeamGraphEdgeCondition{JSONPath: ".decision", Value: "approve"}},
+					{From: "writer", To: "researcher", Condition: &arkv1alpha1.TeamGraphEdgeCondition{Regex: "(?i)more research"}},
+					{From: "writer", To: "writer"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject invalid conditions", func() {
+			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
+				Edges: []arkv1alpha1.TeamGraphEdge{
+					{From: "researcher", To: "writer", Condition: &arkv1alpha1.TeamGraphEdgeCondition{Regex: "(unclosed"}},
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("invalid condition regex")))
+
+			obj.Spec.Graph.Edges[0].Condition = &arkv1alpha1.TeamGraphEdgeCondition{Regex: "done", JSONPath: ".done"}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("exactly one of regex and jsonPath")))
+		})
+
+		It("Should reject conditions for the selector strategy", func() {
+			obj.Spec.Strategy = "selector"
+			obj.Spec.Selector = &arkv1alpha1.TeamSelectorSpec{Agent: "coordinator"}
+			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
+				Edges: []arkv1alpha1.TeamGraphEdge{
+					{From: "researcher", To: "writer", Condition: &arkv1alpha1.TeamGraphEdgeCondition{Regex: "done"}},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("only supported by the graph strategy")))
+		})
+	})
+
+	Context("Parallel strategy validation", func() {
+		BeforeEach(func() {
+			obj.Spec.Strategy = "parallel"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "analyst", Type: "agent"},
+			}
+		})
+
+		It("Should allow a subset of members and an aggregator", func() {
+			obj.Spec.Parallel = &arkv1alpha1.TeamParallelSpec{
+				Members:    []string{"analyst"},
+				Aggregator: "writer",
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject parallel members that are not team members", func() {
+			obj.Spec.Parallel = &arkv1alpha1.TeamParallelSpec{Members: []string{"writer"}}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("not found in team members")))
+		})
+
+		It("Should reject a missing aggregator agent", func() {
+			obj.Spec.Parallel = &arkv1alpha1.TeamParallelSpec{Aggregator: "editor"}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("aggregator agent 'editor' not found")))
+		})
+	})
+
+	Context("Termination validation", func() {
+		BeforeEach(func() {
+			obj.Spec.Strategy = "round-robin"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "analyst", Type: "agent"},
+			}
+		})
+
+		It("Should allow conditions, a token budget and a timeout", func() {
+			maxTokens := int64(10000)
+			obj.Spec.Termination = &arkv1alpha1.TeamTerminationSpec{
+				Conditions: []arkv1alpha1.TeamTerminationCondition{
+					{Regex: "(?i)\\bagreed\\b"},
+					{Member: "analyst", JSONPath: ".final", Value: "true"},
+				},
+				MaxTokens: &maxTokens,
+				Timeout:   &metav1.Duration{Duration: 2 * time.Minute},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject conditions on members outside the team", func() {
+			obj.Spec.Termination = &arkv1alpha1.TeamTerminationSpec{
+				Conditions: []arkv1alpha1.TeamTerminationCondition{{Member: "writer"}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("member 'writer' not found in team members")))
+		})
+
+		It("Should reject invalid conditions", func() {
+			obj.Spec.Termination = &arkv1alpha1.TeamTerminationSpec{
+				Conditions: []arkv1alpha1.TeamTerminationCondition{{}},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("must set a member, text, regex or jsonPath")))
+
+			obj.Spec.Termination.Conditions[0] = arkv1alpha1.TeamTerminationCondition{Text: "DONE", Regex: "DONE"}
+			_, err = validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ContainSubstring("only one of text, regex and jsonPath")))
+		})
+	})
+
+	Context("Delegation cycle validation", func() {
+		It("Should reject a nested team that leads back to the team", func() {
+			nested := &arkv1alpha1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: "review-team", Namespace: "default"},
+				Spec: arkv1alpha1.TeamSpec{
+					Strategy: "sequential",
+					Members:  []arkv1alpha1.TeamMember{{Name: "test-team", Type: "team"}},
+				},
+			}
+			Expect(validator.Client.Create(ctx, nested)).To(Succeed())
+
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "writer", Type: "agent"},
+				{Name: "review-team", Type: "team"},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError("delegation cycle: team/test-team -> team/review-team -> team/test-team"))
 		})
 	})
 })
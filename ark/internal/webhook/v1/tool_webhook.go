@@ -25,13 +25,15 @@ var log = logf.Log
 // SetupToolWebhookWithManager registers the webhook for Tool in the manager.
 func SetupToolWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&arkv1alpha1.Tool{}).
-		WithValidator(&ToolCustomValidator{}).
+		WithValidator(&ToolCustomValidator{ResourceValidator: &ResourceValidator{Client: mgr.GetClient()}}).
 		Complete()
 }
 
 // +kubebuilder:webhook:path=/validate-ark-mckinsey-com-v1alpha1-tool,mutating=false,failurePolicy=fail,sideEffects=None,groups=ark.mckinsey.com,resources=tools,verbs=create;update,versions=v1alpha1,name=vtool-v1.kb.io,admissionReviewVersions=v1
 
-type ToolCustomValidator struct{}
+type ToolCustomValidator struct {
+	*ResourceValidator
+}
 
 var _ webhook.CustomValidator = &ToolCustomValidator{}
 
@@ -62,7 +64,7 @@ func (v *ToolCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Ob
 	return nil, nil
 }
 
-func (v *ToolCustomValidator) validateTool(_ context.Context, tool *arkv1alpha1.Tool) (admission.Warnings, error) {
+func (v *ToolCustomValidator) validateTool(ctx context.Context, tool *arkv1alpha1.Tool) (admission.Warnings, error) {
 	var warnings admission.Warnings
 
 	// Validate inputSchema if present
@@ -78,9 +80,15 @@ func (v *ToolCustomValidator) validateTool(_ context.Context, tool *arkv1alpha1.
 	case genai.ToolTypeMCP:
 		return v.validateMCPTool(tool.Spec.MCP)
 	case genai.ToolTypeAgent:
-		return v.validateAgentTool(tool.Spec.Agent.Name)
+		if warnings, err := v.validateAgentTool(tool.Spec.Agent.Name); err != nil {
+			return warnings, err
+		}
+		return warnings, v.ValidateNoDelegationCycle(ctx, tool.Namespace, delegationNode{kind: kindTool, name: tool.Name}, toolDelegationRefs(tool))
 	case genai.ToolTypeTeam:
-		return v.validateTeamTool(tool.Spec.Team.Name)
+		if warnings, err := v.validateTeamTool(tool.Spec.Team.Name); err != nil {
+			return warnings, err
+		}
+		return warnings, v.ValidateNoDelegationCycle(ctx, tool.Namespace, delegationNode{kind: kindTool, name: tool.Name}, toolDelegationRefs(tool))
 	case genai.ToolTypeBuiltin:
 		return v.validateBuiltinTool(tool.Name)
 	default:
@@ -160,7 +168,7 @@ func (v *ToolCustomValidator) validateTeamTool(team string) (admission.Warnings,
 func (v *ToolCustomValidator) validateBuiltinTool(toolName string) (admission.Warnings, error) {
 	var warnings admission.Warnings
 
-	supportedBuiltinTools := []string{genai.BuiltinToolNoop, genai.BuiltinToolTerminate}
+	supportedBuiltinTools := []string{genai.BuiltinToolNoop, genai.BuiltinToolTerminate, genai.BuiltinToolAskUser}
 	for _, supportedTool := range supportedBuiltinTools {
 		if toolName == supportedTool {
 			return warnings, nil
@@ -9,6 +9,8 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/genai"
@@ -22,11 +24,42 @@ var _ = Describe("Tool Webhook", func() {
 
 	BeforeEach(func() {
 		ctx = context.Background()
-		validator = &ToolCustomValidator{}
 
 		// Setup scheme
 		s := runtime.NewScheme()
 		Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+
+		// The researcher asks the reviewer team, whose member asks the researcher again
+		objects := []client.Object{
+			&arkv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "researcher", Namespace: "default"},
+				Spec: arkv1alpha1.AgentSpec{
+					Tools: []arkv1alpha1.AgentTool{{Type: "team", Name: "ask-reviewers"}},
+				},
+			},
+			&arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "ask-reviewers", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type: genai.ToolTypeTeam,
+					Team: &arkv1alpha1.TeamToolRef{Name: "reviewers"},
+				},
+			},
+			&arkv1alpha1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: "reviewers", Namespace: "default"},
+				Spec: arkv1alpha1.TeamSpec{
+					Strategy: "sequential",
+					Members:  []arkv1alpha1.TeamMember{{Name: "reviewer", Type: "agent"}},
+				},
+			},
+			&arkv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "reviewer", Namespace: "default"},
+				Spec: arkv1alpha1.AgentSpec{
+					Tools: []arkv1alpha1.AgentTool{{Type: "agent", Name: "ask-researcher"}},
+				},
+			},
+		}
+		fakeClient := fake.NewClientBuilder().WithScheme(s).WithObjects(objects...).Build()
+		validator = &ToolCustomValidator{ResourceValidator: &ResourceValidator{Client: fakeClient}}
 	})
 
 	Context("When validating team tool", func() {
@@ -69,4 +102,32 @@ var _ = Describe("Tool Webhook", func() {
 			Expect(warnings).To(BeEmpty())
 		})
 	})
+
+	Context("When validating delegation cycles", func() {
+		It("Should reject an agent tool that leads back to itself", func() {
+			tool := &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "ask-researcher", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type:  genai.ToolTypeAgent,
+					Agent: &arkv1alpha1.AgentToolRef{Name: "researcher"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).To(MatchError("delegation cycle: tool/ask-researcher -> agent/researcher -> tool/ask-reviewers -> team/reviewers -> agent/reviewer -> tool/ask-researcher"))
+		})
+
+		It("Should allow an agent tool without a cycle", func() {
+			tool := &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "ask-reviewers", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type: genai.ToolTypeTeam,
+					Team: &arkv1alpha1.TeamToolRef{Name: "reviewers"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
 })
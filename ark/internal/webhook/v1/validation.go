@@ -5,6 +5,8 @@ package v1
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -332,9 +334,12 @@ func (v *ResourceValidator) ValidateOverrides(overrides []arkv1alpha1.Override)
 	return nil
 }
 
+// overrideResourceTypes are the kinds of resources overrides can set headers for
+var overrideResourceTypes = []string{"model", "mcpserver", "tool", "a2aserver", "executionengine", "memory"}
+
 func (v *ResourceValidator) ValidateOverrideEntry(override arkv1alpha1.Override, index int) error {
-	if override.ResourceType != "model" && override.ResourceType != "mcpserver" {
-		return fmt.Errorf("overrides[%d]: resourceType must be either 'model' or 'mcpserver'", index)
+	if !slices.Contains(overrideResourceTypes, override.ResourceType) {
+		return fmt.Errorf("overrides[%d]: resourceType must be one of %s", index, strings.Join(overrideResourceTypes, ", "))
 	}
 
 	if len(override.Headers) == 0 {
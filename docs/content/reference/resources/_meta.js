@@ -5,6 +5,7 @@ export default {
   memory: 'Memories',
   models: 'Models',
   query: 'Queries',
+  queryquota: 'QueryQuotas',
   team: 'Teams',
   tools: 'Tools',
   a2atask: 'A2ATask'
@@ -12,10 +12,18 @@ class TeamMember(BaseModel):
     type: str
 
 
+class GraphEdgeCondition(BaseModel):
+    """Condition on the last answer for taking a graph edge."""
+    regex: Optional[str] = None
+    jsonPath: Optional[str] = None
+    value: Optional[str] = None
+
+
 class GraphEdge(BaseModel):
     """Graph edge configuration."""
     from_: str = Field(..., alias='from')
     to: str
+    condition: Optional[GraphEdgeCondition] = None
 
     model_config = {
         "populate_by_name": True
@@ -31,6 +39,8 @@ class Selector(BaseModel):
     """Team selector configuration."""
     agent: Optional[str] = None
     selectorPrompt: Optional[str] = None
+    allowTerminate: Optional[bool] = None
+    maxRetries: Optional[int] = None
 
 
 class TeamResponse(BaseModel):
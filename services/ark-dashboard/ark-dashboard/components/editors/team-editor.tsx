@@ -321,19 +321,24 @@ export function TeamEditor({
         setGraphEdgesError('At least one edge is required for graph strategy');
         return;
       }
-      // Graph strategy doesn't allow multiple outgoing edges from same member
-      const fromCounts = new Map<string, number>();
-      for (const edge of graphEdges) {
-        if (edge.from) {
-          fromCounts.set(edge.from, (fromCounts.get(edge.from) || 0) + 1);
+      // Edges leaving a member are evaluated in order, so an edge after one
+      // without a condition could never be taken
+      const hasDefaultEdge = new Set<string>();
+      const unreachable = graphEdges.find(edge => {
+        if (!edge.from) {
+          return false;
         }
-      }
-      const duplicateFrom = Array.from(fromCounts.entries()).find(
-        ([, count]) => count > 1,
-      );
-      if (duplicateFrom) {
+        if (hasDefaultEdge.has(edge.from)) {
+          return true;
+        }
+        if (!edge.condition) {
+          hasDefaultEdge.add(edge.from);
+        }
+        return false;
+      });
+      if (unreachable) {
         setGraphEdgesError(
-          `Member "${duplicateFrom[0]}" has more than one outgoing edge`,
+          `Member "${unreachable.from}" already has an earlier outgoing edge without a condition`,
         );
         return;
       }
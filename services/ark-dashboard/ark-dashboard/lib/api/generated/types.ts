@@ -3591,6 +3591,19 @@ export interface components {
             from: string;
             /** To */
             to: string;
+            condition?: components["schemas"]["GraphEdgeCondition"] | null;
+        };
+        /**
+         * GraphEdgeCondition
+         * @description Condition on the last answer for taking a graph edge.
+         */
+        GraphEdgeCondition: {
+            /** Regex */
+            regex?: string | null;
+            /** Jsonpath */
+            jsonPath?: string | null;
+            /** Value */
+            value?: string | null;
         };
         /**
          * HTTPRouteInfo
@@ -4479,6 +4492,10 @@ export interface components {
             agent?: string | null;
             /** Selectorprompt */
             selectorPrompt?: string | null;
+            /** Allowterminate */
+            allowTerminate?: boolean | null;
+            /** Maxretries */
+            maxRetries?: number | null;
         };
         /**
          * ServiceListResponse
@@ -30,10 +30,19 @@ export type QueryUpdateRequest = Omit<
 };
 
 // Define terminal status phases
-type TerminalQueryStatusPhase = 'done' | 'error' | 'canceled' | 'unknown';
+type TerminalQueryStatusPhase =
+  | 'done'
+  | 'error'
+  | 'canceled'
+  | 'budget-exceeded'
+  | 'unknown';
 
 // Define non-terminal status phases
-type NonTerminalQueryStatusPhase = 'pending' | 'running';
+type NonTerminalQueryStatusPhase =
+  | 'pending'
+  | 'running'
+  | 'awaiting-approval'
+  | 'input-required';
 
 // Combined query status phase type
 type QueryStatusPhase = TerminalQueryStatusPhase | NonTerminalQueryStatusPhase;
@@ -43,10 +52,11 @@ const TERMINAL_QUERY_STATUS_PHASES: readonly TerminalQueryStatusPhase[] = [
   'done',
   'error',
   'canceled',
+  'budget-exceeded',
   'unknown',
 ] as const;
 const NON_TERMINAL_QUERY_STATUS_PHASES: readonly NonTerminalQueryStatusPhase[] =
-  ['pending', 'running'] as const;
+  ['pending', 'running', 'awaiting-approval', 'input-required'] as const;
 const QUERY_STATUS_PHASES: readonly QueryStatusPhase[] = [
   ...TERMINAL_QUERY_STATUS_PHASES,
   ...NON_TERMINAL_QUERY_STATUS_PHASES,
@@ -103,7 +103,8 @@ export const queriesService = {
           if (
             status === 'done' ||
             status === 'error' ||
-            status === 'canceled'
+            status === 'canceled' ||
+            status === 'budget-exceeded'
           ) {
             resolve({ terminal: true, finalStatus: status });
           } else {
@@ -143,6 +143,7 @@ class TeamGenerator {
           {name: 'Sequential - Agents execute in order', value: 'sequential'},
           {name: 'Round Robin - Agents take turns', value: 'round-robin'},
           {name: 'Graph - Custom workflow with dependencies', value: 'graph'},
+          {name: 'Parallel - Agents work on the input at the same time', value: 'parallel'},
           {
             name: 'Selector - AI chooses the next agent (can add graph constraints)',
             value: 'selector',
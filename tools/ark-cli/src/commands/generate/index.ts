@@ -188,6 +188,7 @@ ${chalk.cyan('Strategies:')}
   • sequential - Agents work in order
   • round-robin - Agents take turns
   • graph - Custom workflow with dependencies
+  • parallel - Agents work on the input at the same time
   • selector - AI chooses the next agent`,
       },
       query: {
@@ -130,7 +130,15 @@ export interface QueryResponse {
 }
 
 export interface QueryStatus {
-  phase?: 'initializing' | 'running' | 'done' | 'error' | 'canceled';
+  phase?:
+    | 'initializing'
+    | 'running'
+    | 'awaiting-approval'
+    | 'input-required'
+    | 'done'
+    | 'error'
+    | 'canceled'
+    | 'budget-exceeded';
   conditions?: K8sCondition[];
   response?: QueryResponse;
   message?: string;
@@ -56,6 +56,14 @@ func handleQueryCompletion(result *QueryResult, id *ResourceIdentifier, opts *Ou
 		return nil
 	}
 
+	if result.Phase == "budget-exceeded" {
+		cleanupQuery(id.Config, id.Name, id.Namespace, id.Config.Logger)
+		if result.Query.Status.Response != nil && result.Query.Status.Response.Content != "" {
+			return fmt.Errorf("%s", result.Query.Status.Response.Content)
+		}
+		return fmt.Errorf("query budget exceeded")
+	}
+
 	if result.Phase == "error" {
 		errorMessage := getQueryErrorFromEvents(id.Config.DynamicClient, id.Name, id.Namespace, id.Config.Logger)
 		cleanupQuery(id.Config, id.Name, id.Namespace, id.Config.Logger)
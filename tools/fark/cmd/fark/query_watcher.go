@@ -153,14 +153,14 @@ func (qw *QueryWatcher) processQueryEvent(event watch.Event) *QueryResult {
 	}
 
 	// Log token usage when query completes
-	if query.Status.Phase == "done" || query.Status.Phase == "error" {
+	if isQueryFinished(query.Status.Phase) {
 		logTokenUsage(qw.logger, query, "")
 	}
 
 	result := &QueryResult{
 		Query: query,
 		Phase: query.Status.Phase,
-		Done:  query.Status.Phase == "done" || query.Status.Phase == "error",
+		Done:  isQueryFinished(query.Status.Phase),
 	}
 
 	// Send spinner stop command if query is done or errored
@@ -220,3 +220,8 @@ func (qw *QueryWatcher) sendResult(resultChan chan<- QueryResult, result QueryRe
 		qw.logger.Warn("Result channel full, dropping result")
 	}
 }
+
+// isQueryFinished reports whether the query reached a phase it will not leave
+func isQueryFinished(phase string) bool {
+	return phase == "done" || phase == "error" || phase == "budget-exceeded"
+}